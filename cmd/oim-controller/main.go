@@ -12,13 +12,15 @@ import (
 	"flag"
 	"time"
 
+	"github.com/prometheus/client_golang/prometheus"
+
 	"github.com/intel/oim/pkg/log"
 	"github.com/intel/oim/pkg/oim-common"
 	"github.com/intel/oim/pkg/oim-controller"
+	"github.com/intel/oim/pkg/version"
 )
 
 var (
-	version           = "unknown" // set at build time
 	printVersion      = flag.Bool("version", false, "output version information and exit")
 	endpoint          = flag.String("endpoint", "tcp://:8999", "OIM controller endpoint for net.Listen")
 	spdk              = flag.String("spdk", "/var/tmp/vhost.sock", "SPDK VHost RPC socket path")
@@ -30,6 +32,9 @@ var (
 	ca                = flag.String("ca", "", "the required CA's .crt file which is used for verifying connections to the registry")
 	key               = flag.String("key", "", "the base name of the required .key and .crt files that authenticate and authorize the registry client")
 	registryDelay     = flag.Duration("registry-delay", time.Minute, "determines how long the controller waits before registering at the OIM registry")
+	registryTTL       = flag.Duration("registry-ttl", 0, "lets the registry remove the registration unless renewed in time, must be a whole number of seconds larger than -registry-delay, 0 disables expiration")
+	metrics           = flag.String("metrics-endpoint", "", "serve Prometheus metrics via HTTP under /metrics at this listen address (for example, :9100), empty disables metrics")
+	logDedup          = flag.Duration("log-dedup-window", oimcommon.DefaultLogDedupWindow, "identical warnings and errors are logged only once per window, followed by a count of repetitions, 0 disables deduplication")
 	_                 = log.InitSimpleFlags()
 )
 
@@ -37,11 +42,11 @@ func main() {
 	flag.Parse()
 	app := "oim-controller"
 
-	logger := log.NewSimpleLogger(log.NewSimpleConfig())
+	logger := oimcommon.NewDedupLogger(log.NewSimpleLogger(log.NewSimpleConfig()), *logDedup)
 	log.Set(logger)
 
 	if *printVersion {
-		logger.Infof("oim-controller %s", version)
+		logger.Info(version.String("oim-controller"))
 		return
 	}
 
@@ -64,8 +69,15 @@ func main() {
 		oimcontroller.WithControllerAddress(*controllerAddress),
 		oimcontroller.WithRegistry(*registry),
 		oimcontroller.WithRegistryDelay(*registryDelay),
+		oimcontroller.WithRegistryTTL(*registryTTL),
 		oimcontroller.WithCreds(transportCreds),
 	}
+	if *metrics != "" {
+		options = append(options, oimcontroller.WithMetrics(prometheus.DefaultRegisterer))
+		go func() {
+			logger.Fatalw("serving metrics", "error", oimcommon.ServeMetrics(*metrics))
+		}()
+	}
 	controller, err := oimcontroller.New(options...)
 	if err != nil {
 		logger.Fatalf("Failed to initialize server: %s\n", err)
@@ -73,9 +85,12 @@ func main() {
 	if err := controller.Start(); err != nil {
 		logger.Fatalf("Failed to start auto-registrationg: %s\n", err)
 	}
-	defer controller.Stop()
 	server, service := controller.Server(*endpoint)
-	if err := server.Run(context.Background(), service); err != nil {
+	if err := oimcommon.RunServer(context.Background(), server, []oimcommon.RegisterService{service},
+		oimcommon.WithCleanup(func(ctx context.Context) error {
+			controller.Stop()
+			return nil
+		})); err != nil {
 		logger.Fatalf("Failed to run server: %s\n", err)
 	}
 }
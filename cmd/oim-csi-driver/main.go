@@ -14,31 +14,35 @@ import (
 	"github.com/intel/oim/pkg/log"
 	"github.com/intel/oim/pkg/oim-common"
 	"github.com/intel/oim/pkg/oim-csi-driver"
+	"github.com/intel/oim/pkg/version"
 )
 
 var (
-	version            = "unknown" // set at build time
 	printVersion       = flag.Bool("version", false, "output version information and exit")
 	endpoint           = flag.String("endpoint", "unix:///tmp/csi.sock", "CSI endpoint")
 	driverName         = flag.String("drivername", "oim-csi-driver", "name of the driver")
 	nodeID             = flag.String("nodeid", "", "node id")
 	spdkSocket         = flag.String("spdk-socket", "", "SPDK VHost socket path. If set, then the driver will controll that SPDK instance directly.")
-	oimRegistryAddress = flag.String("oim-registry-address", "", "OIM registry address in the format expected by grpc.Dial. If set, then the driver will use a OIM controller via the registry instead of a local SPDK daemon.")
+	oimRegistryAddress = flag.String("oim-registry-address", "", "OIM registry address in the format expected by grpc.Dial, or a comma-separated list of addresses of registry replicas. If set, then the driver will use a OIM controller via the registry instead of a local SPDK daemon.")
 	ca                 = flag.String("ca", "", "the required CA's .crt file which is used for verifying connections")
 	key                = flag.String("key", "", "the base name of the required .key and .crt files that authenticate and authorize the controller")
 	controllerID       = flag.String("controller-id", "", "The ID under which the OIM controller can be found in the registry.")
 	emulate            = flag.String("emulate", "", "name of CSI driver to emulate for node operations")
+	deviceTimeout      = flag.Duration("device-timeout", oimcsidriver.DefaultDeviceTimeout, "maximum time to wait for the block device of a volume after mapping it")
+	minVolumeSize      = flag.Int64("min-volume-size", 0, "minimum size of new volumes in bytes, smaller requests are rounded up")
+	stagingDir         = flag.String("staging-dir", "", "directory containing the staging directories of volumes, checked for orphaned directories at startup")
+	logDedup           = flag.Duration("log-dedup-window", oimcommon.DefaultLogDedupWindow, "identical warnings and errors are logged only once per window, followed by a count of repetitions, 0 disables deduplication")
 	_                  = log.InitSimpleFlags()
 )
 
 func main() {
 	flag.Parse()
 
-	logger := log.NewSimpleLogger(log.NewSimpleConfig())
+	logger := oimcommon.NewDedupLogger(log.NewSimpleLogger(log.NewSimpleConfig()), *logDedup)
 	log.Set(logger)
 
 	if *printVersion {
-		logger.Infof("oim-csi-driver %s", version)
+		logger.Info(version.String("oim-csi-driver"))
 		return
 	}
 
@@ -50,7 +54,7 @@ func main() {
 
 	options := []oimcsidriver.Option{
 		oimcsidriver.WithDriverName(*driverName),
-		oimcsidriver.WithDriverVersion(version),
+		oimcsidriver.WithDriverVersion(version.Version),
 		oimcsidriver.WithCSIEndpoint(*endpoint),
 		oimcsidriver.WithNodeID(*nodeID),
 		oimcsidriver.WithVHostEndpoint(*spdkSocket),
@@ -58,6 +62,9 @@ func main() {
 		oimcsidriver.WithOIMControllerID(*controllerID),
 		oimcsidriver.WithRegistryCreds(*ca, *key),
 		oimcsidriver.WithEmulation(*emulate),
+		oimcsidriver.WithDeviceTimeout(*deviceTimeout),
+		oimcsidriver.WithStagingDir(*stagingDir),
+		oimcsidriver.WithMinVolumeSize(*minVolumeSize),
 	}
 	driver, err := oimcsidriver.New(options...)
 	if err != nil {
@@ -11,17 +11,27 @@ import (
 	"context"
 	"flag"
 
+	"github.com/prometheus/client_golang/prometheus"
+
 	"github.com/intel/oim/pkg/log"
 	"github.com/intel/oim/pkg/oim-common"
 	"github.com/intel/oim/pkg/oim-registry"
+	"github.com/intel/oim/pkg/version"
 )
 
 var (
-	version      = "unknown" // set at build time
 	printVersion = flag.Bool("version", false, "output version information and exit")
 	endpoint     = flag.String("endpoint", "unix:///tmp/registry.sock", "OIM registry endpoint")
 	ca           = flag.String("ca", "", "the required CA's .crt file which is used for verifying connections")
 	key          = flag.String("key", "", "the base name of the required .key and .crt files that authenticate and authorize the registry")
+	minTTL       = flag.Duration("min-ttl", oimregistry.DefaultMinTTL, "the lower limit for the TTL of registry entries")
+	checkAddress = flag.Duration("check-address", 0, "if non-zero, controller addresses are only accepted if the registry can connect to the controller within this time")
+	metrics      = flag.String("metrics-endpoint", "", "serve Prometheus metrics via HTTP under /metrics at this listen address (for example, :9100), empty disables metrics")
+	retention    = flag.Duration("tombstone-retention", oimregistry.DefaultTombstoneRetention, "how long removed entries are remembered for watchers which resume after an older revision")
+	readRate     = flag.Float64("read-rate", oimregistry.DefaultReadRate, "the number of read requests per second that each client may send, zero for unlimited")
+	writeRate    = flag.Float64("write-rate", oimregistry.DefaultWriteRate, "the number of write requests per second that each client may send, zero for unlimited")
+	readPolicy   = flag.String("read-policy", "all", "determines who may read registry entries: all clients (all) or only the admin and the controller or host that the entries belong to (own)")
+	logDedup     = flag.Duration("log-dedup-window", oimcommon.DefaultLogDedupWindow, "identical warnings and errors are logged only once per window, followed by a count of repetitions, 0 disables deduplication")
 	_            = log.InitSimpleFlags()
 )
 
@@ -29,11 +39,11 @@ func main() {
 	flag.Parse()
 	app := "oim-registry"
 
-	logger := log.NewSimpleLogger(log.NewSimpleConfig())
+	logger := oimcommon.NewDedupLogger(log.NewSimpleLogger(log.NewSimpleConfig()), *logDedup)
 	log.Set(logger)
 
 	if *printVersion {
-		logger.Infof("oim-registry %s", version)
+		logger.Info(version.String("oim-registry"))
 		return
 	}
 
@@ -55,12 +65,31 @@ func main() {
 		logger.Fatalw("load TLS certs", "error", err)
 	}
 
-	registry, err := oimregistry.New(oimregistry.TLS(tlsConfig))
+	canRead, err := oimregistry.ParseReadPolicy(*readPolicy)
+	if err != nil {
+		logger.Fatalw("read policy", "error", err)
+	}
+
+	options := []oimregistry.Option{
+		oimregistry.TLS(tlsConfig),
+		oimregistry.MinTTL(*minTTL),
+		oimregistry.Reads(canRead),
+		oimregistry.CheckAddress(*checkAddress),
+		oimregistry.TombstoneRetention(*retention),
+		oimregistry.RateLimits(*readRate, *writeRate),
+	}
+	if *metrics != "" {
+		options = append(options, oimregistry.Metrics(prometheus.DefaultRegisterer))
+		go func() {
+			logger.Fatalw("serving metrics", "error", oimcommon.ServeMetrics(*metrics))
+		}()
+	}
+	registry, err := oimregistry.New(options...)
 	if err != nil {
 		logger.Fatalf("Failed to initialize server: %s\n", err)
 	}
 	server, service := registry.Server(*endpoint)
-	if err := server.Run(context.Background(), service); err != nil {
+	if err := oimcommon.RunServer(context.Background(), server, []oimcommon.RegisterService{service}); err != nil {
 		logger.Fatalf("Failed to run server: %s\n", err)
 	}
 }
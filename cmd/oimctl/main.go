@@ -8,37 +8,80 @@ package main
 
 import (
 	"context"
+	"encoding/json"
 	"flag"
 	"fmt"
+	"io"
 	"os"
 	"sort"
 	"strings"
+	"time"
 
-	"google.golang.org/grpc"
+	grpcmetadata "google.golang.org/grpc/metadata"
 
 	"github.com/intel/oim/pkg/log"
 	"github.com/intel/oim/pkg/oim-common"
 	"github.com/intel/oim/pkg/spec/oim/v0"
+	"github.com/intel/oim/pkg/version"
 )
 
 var (
-	version      = "unknown" // set at build time
 	printVersion = flag.Bool("version", false, "output version information and exit")
 
-	endpoint = flag.String("registry", "", "the gRPC endpoint of the OIM registry (for example, dns:///localhost:8999)")
-	ca       = flag.String("ca", "", "the required CA's .crt file which is used for verifying connections to the registry")
-	key      = flag.String("key", "", "the base name of the required .key and .crt files that authenticate and authorize the registry client")
-	_        = log.InitSimpleFlags()
+	// The connection parameters default to the corresponding
+	// OIMCTL_* environment variables.
+	endpoint     = flag.String("registry", fromEnv("OIMCTL_REGISTRY", ""), "the gRPC endpoint of the OIM registry (for example, dns:///localhost:8999), default from $OIMCTL_REGISTRY")
+	ca           = flag.String("ca", fromEnv("OIMCTL_CA", ""), "the required CA's .crt file which is used for verifying connections to the registry, default from $OIMCTL_CA")
+	key          = flag.String("key", fromEnv("OIMCTL_KEY", ""), "the base name of the required .key and .crt files that authenticate and authorize the registry client, default from $OIMCTL_KEY; controller operations require the host.<controller ID> key")
+	controllerID = flag.String("controller-id", fromEnv("OIMCTL_CONTROLLER_ID", ""), "the OIM controller that controller operations are sent to via the registry, default from $OIMCTL_CONTROLLER_ID")
+	output       = flag.String("o", "text", "output format: text or json (one JSON object per line)")
+	_            = log.InitSimpleFlags()
 
 	// Quick-and-dirty bool flags for triggering operations. What we want instead is
 	// probably something like a Cobra-based command line tool. We also need to consider
 	// keys which contain the = sign: right now, the command line parsing does not support those.
-	get   = flag.Bool("get", false, "retrieve values from the registry as <key>=<value> pairs to stdout")
-	set   = flag.Bool("set", false, "sets or updates a registry value, deletes it when value is empty")
-	path  = flag.String("path", "", "the complete path of a value (set, delete, get of single value) or a path prefix (get multiple values)")
-	value = flag.String("value", "", "the value to set or update")
+	get          = flag.Bool("get", false, "retrieve values from the registry as <key>=<value> pairs to stdout")
+	set          = flag.Bool("set", false, "sets or updates a registry value, deletes it when value is empty")
+	deleteValue  = flag.Bool("delete", false, "deletes a registry value")
+	list         = flag.Bool("list", false, "list values from the registry as <key>=<value> pairs to stdout, with the remaining time until expiration where applicable")
+	metadata     = flag.Bool("metadata", false, "with --list, also print when and by whom each value was last changed")
+	watch        = flag.Bool("watch", false, "print current values and all changes as <key>=<value> pairs to stdout until interrupted, with empty value for removed entries")
+	exportValues = flag.Bool("export", false, "write all registry entries including metadata as JSON objects to stdout")
+	importValues = flag.Bool("import", false, "read registry entries as written by --export from stdin and add them to the registry")
+	overwrite    = flag.Bool("overwrite", false, "with --import, overwrite existing entries instead of keeping them")
+	path         = flag.String("path", "", "the complete path of a value (set, delete, get of single value) or a path prefix (get multiple values)")
+	value        = flag.String("value", "", "the value to set or update")
+
+	// Controller operations.
+	mapVolume   = flag.Bool("map", false, "map the existing Malloc BDev with the name given by --volume-id and print where it is available")
+	unmapVolume = flag.Bool("unmap", false, "unmap the volume given by --volume-id")
+	listVolumes = flag.Bool("list-volumes", false, "list the Malloc BDevs of the controller with their size")
+	nodeInfo    = flag.Bool("node-info", false, "print how many volumes the controller can map and has mapped")
+	volumeID    = flag.String("volume-id", "", "the volume for --map and --unmap")
+
+	serverVersion = flag.Bool("server-version", false, "print the version of the registry or, with --controller-id, of that controller")
 )
 
+// fromEnv returns the value of the environment variable, or the
+// default when it is not set.
+func fromEnv(name, def string) string {
+	if value, ok := os.LookupEnv(name); ok {
+		return value
+	}
+	return def
+}
+
+var encoder = json.NewEncoder(os.Stdout)
+
+// printResult writes one result, either as JSON object or as text.
+func printResult(result interface{}, format string, args ...interface{}) error {
+	if *output == "json" {
+		return encoder.Encode(result)
+	}
+	_, err := fmt.Printf(format+"\n", args...)
+	return err
+}
+
 func main() {
 	ctx := context.Background()
 
@@ -50,7 +93,7 @@ func main() {
 	log.Set(logger)
 
 	if *printVersion {
-		logger.Infof("oimctl %s", version)
+		logger.Info(version.String("oimctl"))
 		return
 	}
 
@@ -63,19 +106,29 @@ func main() {
 	if *key == "" {
 		logger.Fatalf("A key file is required.")
 	}
+	if *output != "text" && *output != "json" {
+		logger.Fatalw("unsupported output format", "format", *output)
+	}
 
 	transportCreds, err := oimcommon.LoadTLS(*ca, *key, "component.registry")
 	if err != nil {
 		logger.Fatalw("load TLS certs", "error", err)
 	}
-	opts := oimcommon.ChooseDialOpts(*endpoint, grpc.WithTransportCredentials(transportCreds))
-	conn, err := grpc.DialContext(ctx, *endpoint, opts...)
+	conn, err := oimcommon.Dial(ctx, *endpoint, oimcommon.WithCredentials(transportCreds))
 	if err != nil {
 		logger.Fatalw("connecting to OIM registry", "error", err)
 	}
 	defer conn.Close()
 
 	registry := oim.NewRegistryClient(conn)
+	controller := oim.NewControllerClient(conn)
+	controllerCtx := func() context.Context {
+		if *controllerID == "" {
+			logger.Fatal("-controller-id must be set")
+		}
+		// The registry forwards the call to this controller.
+		return grpcmetadata.AppendToOutgoingContext(ctx, "controllerid", *controllerID)
+	}
 
 	// sanitize path
 	elements, err := oimcommon.SplitRegistryPath(*path)
@@ -88,7 +141,7 @@ func main() {
 		if key == "" {
 			logger.Fatal("key required")
 		}
-		_, err := registry.SetValue(ctx, &oim.SetValueRequest{
+		reply, err := registry.SetValue(ctx, &oim.SetValueRequest{
 			Value: &oim.Value{
 				Path:  key,
 				Value: *value,
@@ -97,6 +150,27 @@ func main() {
 		if err != nil {
 			logger.Fatalw("setting a registry value", "error", err, "path", key, "value", *value)
 		}
+		if *value == "" && !reply.Existed {
+			logger.Infow("nothing to remove", "path", key)
+		}
+	} else if *deleteValue {
+		if key == "" {
+			logger.Fatal("key required")
+		}
+		if *value != "" {
+			logger.Fatalw("value not allowed for --delete", "value", *value)
+		}
+		reply, err := registry.SetValue(ctx, &oim.SetValueRequest{
+			Value: &oim.Value{
+				Path: key,
+			},
+		})
+		if err != nil {
+			logger.Fatalw("deleting a registry value", "error", err, "path", key)
+		}
+		if !reply.Existed {
+			logger.Infow("nothing to remove", "path", key)
+		}
 	} else if *get {
 		if *value != "" {
 			logger.Fatalw("value not allowed for --get", "value", *value)
@@ -111,9 +185,172 @@ func main() {
 			return strings.Compare(reply.Values[i].Path, reply.Values[j].Path) < 0
 		})
 		for _, entry := range reply.Values {
-			fmt.Printf("%s=%s\n", entry.Path, entry.Value)
+			if err := printResult(entry, "%s=%s", entry.Path, entry.Value); err != nil {
+				logger.Fatalw("writing registry values", "error", err)
+			}
+		}
+	} else if *list {
+		if *value != "" {
+			logger.Fatalw("value not allowed for --list", "value", *value)
+		}
+		token := ""
+		for {
+			reply, err := registry.GetValues(ctx, &oim.GetValuesRequest{
+				Path:          key,
+				MaxEntries:    100,
+				StartingToken: token,
+				WithMetadata:  *metadata,
+			})
+			if err != nil {
+				logger.Fatalw("listing registry values", "error", err)
+			}
+			for _, entry := range reply.Values {
+				line := fmt.Sprintf("%s=%s", entry.Path, entry.Value)
+				if entry.RemainingTtlSeconds > 0 {
+					line += fmt.Sprintf(" (expires in %ds)", entry.RemainingTtlSeconds)
+				}
+				if entry.Updated != nil {
+					line += fmt.Sprintf(" (updated %s by %s)", time.Unix(entry.Updated.Seconds, int64(entry.Updated.Nanos)).Format(time.RFC3339), entry.UpdatedBy)
+				}
+				if err := printResult(entry, "%s", line); err != nil {
+					logger.Fatalw("writing registry values", "error", err)
+				}
+			}
+			token = reply.NextToken
+			if token == "" {
+				break
+			}
+		}
+	} else if *watch {
+		if *value != "" {
+			logger.Fatalw("value not allowed for --watch", "value", *value)
+		}
+		stream, err := registry.Watch(ctx, &oim.WatchRequest{
+			Path: key,
+		})
+		if err != nil {
+			logger.Fatalw("watching registry values", "error", err)
+		}
+		for {
+			reply, err := stream.Recv()
+			if err != nil {
+				logger.Fatalw("watching registry values", "error", err)
+			}
+			if err := printResult(reply.Value, "%s=%s", reply.Value.Path, reply.Value.Value); err != nil {
+				logger.Fatalw("writing registry values", "error", err)
+			}
+		}
+	} else if *exportValues {
+		stream, err := registry.Export(ctx, &oim.ExportRequest{})
+		if err != nil {
+			logger.Fatalw("exporting registry values", "error", err)
+		}
+		for {
+			value, err := stream.Recv()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				logger.Fatalw("exporting registry values", "error", err)
+			}
+			if err := encoder.Encode(value); err != nil {
+				logger.Fatalw("writing registry values", "error", err)
+			}
+		}
+	} else if *importValues {
+		stream, err := registry.Import(ctx)
+		if err != nil {
+			logger.Fatalw("importing registry values", "error", err)
+		}
+		decoder := json.NewDecoder(os.Stdin)
+		in := &oim.ImportRequest{Overwrite: *overwrite}
+		for {
+			var value oim.Value
+			err := decoder.Decode(&value)
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				logger.Fatalw("reading registry values", "error", err)
+			}
+			in.Values = append(in.Values, &value)
+			if len(in.Values) >= 100 {
+				if err := stream.Send(in); err != nil {
+					logger.Fatalw("importing registry values", "error", err)
+				}
+				in = &oim.ImportRequest{}
+			}
+		}
+		if err := stream.Send(in); err != nil {
+			logger.Fatalw("importing registry values", "error", err)
+		}
+		reply, err := stream.CloseAndRecv()
+		if err != nil {
+			logger.Fatalw("importing registry values", "error", err)
+		}
+		logger.Infow("imported registry values", "imported", reply.Imported, "skipped", reply.Skipped)
+	} else if *mapVolume {
+		if *volumeID == "" {
+			logger.Fatal("-volume-id must be set")
+		}
+		reply, err := controller.MapVolume(controllerCtx(), &oim.MapVolumeRequest{
+			VolumeId: *volumeID,
+			Params: &oim.MapVolumeRequest_Malloc{
+				Malloc: &oim.MallocParams{},
+			},
+		})
+		if err != nil {
+			logger.Fatalw("mapping volume", "error", err, "volumeid", *volumeID)
+		}
+		line := fmt.Sprintf("pci=%s", oimcommon.PrettyPCIAddress(reply.PciAddress))
+		if reply.ScsiDisk != nil {
+			line += fmt.Sprintf(" scsi=%d:%d", reply.ScsiDisk.Target, reply.ScsiDisk.Lun)
+		}
+		if err := printResult(reply, "%s", line); err != nil {
+			logger.Fatalw("writing map result", "error", err)
+		}
+	} else if *unmapVolume {
+		if *volumeID == "" {
+			logger.Fatal("-volume-id must be set")
+		}
+		if _, err := controller.UnmapVolume(controllerCtx(), &oim.UnmapVolumeRequest{
+			VolumeId: *volumeID,
+		}); err != nil {
+			logger.Fatalw("unmapping volume", "error", err, "volumeid", *volumeID)
+		}
+	} else if *listVolumes {
+		reply, err := controller.ListMallocBDevs(controllerCtx(), &oim.ListMallocBDevsRequest{})
+		if err != nil {
+			logger.Fatalw("listing volumes", "error", err)
+		}
+		for _, bdev := range reply.Bdevs {
+			if err := printResult(bdev, "%s %d", bdev.BdevName, bdev.Size); err != nil {
+				logger.Fatalw("writing volumes", "error", err)
+			}
+		}
+	} else if *nodeInfo {
+		reply, err := controller.GetNodeInfo(controllerCtx(), &oim.GetNodeInfoRequest{})
+		if err != nil {
+			logger.Fatalw("getting node info", "error", err)
+		}
+		if err := printResult(reply, "max volumes=%d mapped volumes=%d", reply.MaxVolumes, reply.MappedVolumes); err != nil {
+			logger.Fatalw("writing node info", "error", err)
+		}
+	} else if *serverVersion {
+		var reply *oim.GetVersionReply
+		var err error
+		if *controllerID != "" {
+			reply, err = controller.GetVersion(controllerCtx(), &oim.GetVersionRequest{})
+		} else {
+			reply, err = registry.GetVersion(ctx, &oim.GetVersionRequest{})
+		}
+		if err != nil {
+			logger.Fatalw("getting version", "error", err)
+		}
+		if err := printResult(reply, "version=%s commit=%s build date=%s", reply.Version, reply.Commit, reply.BuildDate); err != nil {
+			logger.Fatalw("writing version", "error", err)
 		}
 	} else {
-		logger.Fatal("either --get or --set must be chosen")
+		logger.Fatal("either --get, --set, --delete, --list, --watch, --export, --import, --map, --unmap, --list-volumes, --node-info or --server-version must be chosen")
 	}
 }
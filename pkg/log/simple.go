@@ -18,17 +18,26 @@ import (
 
 const (
 	logLevelName = "log.level"
+
+	// LogLevelEnv is the environment variable which sets the
+	// default for -log.level.
+	LogLevelEnv = "OIM_LOG_LEVEL"
 )
 
 var logLevel = level.Info
 var logOutput = os.Stdout
 
 // InitSimpleFlags sets up flags that configure a simple logger.
-// Currently there is only one, -log.level <threshold>.
+// Currently there is only one, -log.level <threshold>, with
+// $OIM_LOG_LEVEL as default if set.
 // Always returns true, which makes it possible to do:
 // var _ = InitSimpleFlags()
 func InitSimpleFlags() bool {
 	if flag.Lookup(logLevelName) == nil {
+		if err := levelFromEnv(&logLevel); err != nil {
+			// There is no logger yet which could report this.
+			fmt.Fprintf(os.Stderr, "%s\n", err)
+		}
 		valid := make([]string, 0, level.Max+-level.Min)
 		for i := level.Min; i <= level.Max; i++ {
 			valid = append(valid, i.String())
@@ -40,6 +49,18 @@ func InitSimpleFlags() bool {
 	return true
 }
 
+// levelFromEnv sets the threshold from $OIM_LOG_LEVEL, if set.
+func levelFromEnv(threshold *Threshold) error {
+	value, ok := os.LookupEnv(LogLevelEnv)
+	if !ok || value == "" {
+		return nil
+	}
+	if err := threshold.Set(value); err != nil {
+		return fmt.Errorf("ignoring invalid %s=%q: %s", LogLevelEnv, value, err)
+	}
+	return nil
+}
+
 // NewSimpleConfig returns a configuration for NewSimpleLogger
 // that is populated by command line flags. InitSimpleFlags and
 // flag.Parse must have been called first, otherwise the defaults
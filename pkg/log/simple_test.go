@@ -8,6 +8,7 @@ package log
 
 import (
 	"bytes"
+	"os"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -34,3 +35,20 @@ func TestSimpleLogger(t *testing.T) {
 		l.Panic("oh no")
 	})
 }
+
+func TestLevelFromEnv(t *testing.T) {
+	defer os.Unsetenv(LogLevelEnv)
+
+	threshold := level.Info
+	os.Unsetenv(LogLevelEnv)
+	assert.NoError(t, levelFromEnv(&threshold))
+	assert.Equal(t, level.Info, threshold, "not set")
+
+	os.Setenv(LogLevelEnv, "debug")
+	assert.NoError(t, levelFromEnv(&threshold))
+	assert.Equal(t, level.Debug, threshold, "debug")
+
+	os.Setenv(LogLevelEnv, "no-such-level")
+	assert.Error(t, levelFromEnv(&threshold))
+	assert.Equal(t, level.Debug, threshold, "unchanged")
+}
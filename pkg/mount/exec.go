@@ -16,19 +16,34 @@ limitations under the License.
 
 package mount
 
-import "k8s.io/utils/exec"
+import (
+	"context"
+
+	"k8s.io/utils/exec"
+)
 
 func NewOsExec() Exec {
 	return &osExec{}
 }
 
+// NewOsExecWithContext returns an Exec which kills commands when the
+// context is done.
+func NewOsExecWithContext(ctx context.Context) Exec {
+	return &osExec{ctx: ctx}
+}
+
 // Real implementation of Exec interface that uses simple util.Exec
-type osExec struct{}
+type osExec struct {
+	ctx context.Context
+}
 
 var _ Exec = &osExec{}
 
 func (e *osExec) Run(cmd string, args ...string) ([]byte, error) {
 	exe := exec.New()
+	if e.ctx != nil {
+		return exe.CommandContext(e.ctx, cmd, args...).CombinedOutput()
+	}
 	return exe.Command(cmd, args...).CombinedOutput()
 }
 
@@ -179,7 +179,14 @@ type SafeFormatAndMount struct {
 // disk is already formatted or it is being mounted as read-only, it
 // will be mounted without formatting.
 func (mounter *SafeFormatAndMount) FormatAndMount(source string, target string, fstype string, options []string) error {
-	return mounter.formatAndMount(source, target, fstype, options)
+	return mounter.formatAndMount(source, target, fstype, options, nil)
+}
+
+// FormatAndMountWithFormatOptions is the same as FormatAndMount, except
+// that the additional format options are passed to mkfs when the disk
+// needs to be formatted.
+func (mounter *SafeFormatAndMount) FormatAndMountWithFormatOptions(source string, target string, fstype string, options []string, formatOptions []string) error {
+	return mounter.formatAndMount(source, target, fstype, options, formatOptions)
 }
 
 // getMountRefsByDev finds all references to the device provided
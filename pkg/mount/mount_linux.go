@@ -20,6 +20,7 @@ package mount
 
 import (
 	"bytes"
+	"context"
 	"errors"
 	"fmt"
 	"io/ioutil"
@@ -68,6 +69,7 @@ const (
 type Mounter struct {
 	mounterPath string
 	withSystemd bool
+	ctx         context.Context
 }
 
 // New returns a mount.Interface for the current system.
@@ -80,6 +82,25 @@ func New(mounterPath string) Interface {
 	}
 }
 
+// NewWithContext is like New, except that the mount and umount
+// commands get killed when the context is done.
+func NewWithContext(ctx context.Context, mounterPath string) Interface {
+	return &Mounter{
+		mounterPath: mounterPath,
+		withSystemd: detectSystemd(),
+		ctx:         ctx,
+	}
+}
+
+// command creates a command which is bound to the context of the
+// mounter, if there is one.
+func (mounter *Mounter) command(name string, args ...string) *exec.Cmd {
+	if mounter.ctx != nil {
+		return exec.CommandContext(mounter.ctx, name, args...)
+	}
+	return exec.Command(name, args...)
+}
+
 // Mount mounts source to target as fstype with given options. 'source' and 'fstype' must
 // be an empty string in case it's not required, e.g. for remount, or for auto filesystem
 // type, where kernel handles fstype for you. The mount 'options' is a list of options,
@@ -144,7 +165,7 @@ func (m *Mounter) doMount(mounterPath string, mountCmd string, source string, ta
 	}
 
 	log.L().Debugw("mounting", "cmd", mountCmd, "arguments", mountArgs)
-	command := exec.Command(mountCmd, mountArgs...)
+	command := m.command(mountCmd, mountArgs...)
 	output, err := command.CombinedOutput()
 	if err != nil {
 		args := strings.Join(mountArgs, " ")
@@ -209,7 +230,7 @@ func addSystemdScope(systemdRunPath, mountName, command string, args []string) (
 // Unmount unmounts the target.
 func (mounter *Mounter) Unmount(target string) error {
 	log.L().Debugw("Unmounting", "target", target)
-	command := exec.Command("umount", target)
+	command := mounter.command("umount", target)
 	output, err := command.CombinedOutput()
 	if err != nil {
 		return fmt.Errorf("Unmount failed: %v\nUnmounting arguments: %s\nOutput: %s\n", err, target, string(output))
@@ -254,6 +275,25 @@ func (mounter *Mounter) IsLikelyNotMountPoint(file string) (bool, error) {
 	return true, nil
 }
 
+// IsCorruptedMnt returns true if err is about a corrupted mount
+// point, for example a stale NFS handle or a disconnected FUSE file
+// system. Such a mount point must be unmounted before it can be used
+// again.
+func IsCorruptedMnt(err error) bool {
+	var underlyingError error
+	switch pe := err.(type) {
+	case nil:
+		return false
+	case *os.PathError:
+		underlyingError = pe.Err
+	case *os.LinkError:
+		underlyingError = pe.Err
+	case *os.SyscallError:
+		underlyingError = pe.Err
+	}
+	return underlyingError == syscall.ENOTCONN || underlyingError == syscall.ESTALE || underlyingError == syscall.EIO
+}
+
 // DeviceOpened checks if block device in use by calling Open with O_EXCL flag.
 // If pathname is not a device, log and return false with nil error.
 // If open returns errno EBUSY, return true with nil error.
@@ -429,7 +469,7 @@ func (mounter *Mounter) EvalHostSymlinks(pathname string) (string, error) {
 }
 
 // formatAndMount uses unix utils to format and mount the given disk
-func (mounter *SafeFormatAndMount) formatAndMount(source string, target string, fstype string, options []string) error {
+func (mounter *SafeFormatAndMount) formatAndMount(source string, target string, fstype string, options []string, formatOptions []string) error {
 	readOnly := false
 	for _, option := range options {
 		if option == "ro" {
@@ -477,7 +517,7 @@ func (mounter *SafeFormatAndMount) formatAndMount(source string, target string,
 			}
 
 			// Disk is unformatted so format it.
-			args := []string{source}
+			var args []string
 			// Use 'ext4' as the default
 			if len(fstype) == 0 {
 				fstype = "ext4"
@@ -487,9 +527,10 @@ func (mounter *SafeFormatAndMount) formatAndMount(source string, target string,
 				args = []string{
 					"-F",  // Force flag
 					"-m0", // Zero blocks reserved for super-user
-					source,
 				}
 			}
+			args = append(args, formatOptions...)
+			args = append(args, source)
 			log.L().Infow("appears to be unformatted, attempting to format", "device", source, "fstype", fstype, "args", args)
 			_, err := mounter.Exec.Run("mkfs."+fstype, args...)
 			if err == nil {
@@ -0,0 +1,271 @@
+/*
+Copyright (C) 2018 Intel Corporation.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package oimcommon
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// BreakerState is the state of a CircuitBreaker.
+type BreakerState int
+
+const (
+	// BreakerClosed lets all calls through.
+	BreakerClosed BreakerState = iota
+	// BreakerOpen fails all calls immediately.
+	BreakerOpen
+	// BreakerHalfOpen lets a single call through to probe
+	// whether the server has recovered.
+	BreakerHalfOpen
+)
+
+func (s BreakerState) String() string {
+	switch s {
+	case BreakerClosed:
+		return "closed"
+	case BreakerOpen:
+		return "open"
+	case BreakerHalfOpen:
+		return "half-open"
+	}
+	return "unknown"
+}
+
+// BreakerStates reports the current state of each named circuit
+// breaker (0 = closed, 1 = open, 2 = half-open). Components which
+// export metrics must register it themselves, NewGRPCMetrics does
+// that.
+var BreakerStates = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+	Namespace: "oim",
+	Subsystem: "grpc_client",
+	Name:      "circuit_breaker_state",
+	Help:      "State of the circuit breaker by name (0 = closed, 1 = open, 2 = half-open).",
+}, []string{"name"})
+
+const (
+	// DefaultBreakerThreshold is the number of consecutive
+	// failures after which a CircuitBreaker opens by default.
+	DefaultBreakerThreshold = 5
+
+	// DefaultBreakerOpenTimeout is how long a CircuitBreaker
+	// stays open by default before probing the server again.
+	DefaultBreakerOpenTimeout = 10 * time.Second
+
+	// DefaultBreakerMaxOpenTimeout is the default upper limit for
+	// the open state after repeatedly failed probes.
+	DefaultBreakerMaxOpenTimeout = time.Minute
+)
+
+// CircuitBreaker stops sending calls to a server after it failed
+// repeatedly. While open, calls fail immediately with Unavailable.
+// After OpenTimeout, one call is let through; if it succeeds, the
+// breaker closes again, otherwise it stays open for twice as long
+// as before, up to MaxOpenTimeout.
+//
+// Only errors which indicate a problem with the server count as
+// failures, not those caused by the request itself (NotFound,
+// InvalidArgument, ...) or by the caller canceling it.
+type CircuitBreaker struct {
+	// Name identifies the breaker in the BreakerStates metric
+	// and in errors. Required when using the metric.
+	Name string
+	// Threshold is the number of consecutive failures which
+	// open the breaker, DefaultBreakerThreshold if zero.
+	Threshold int
+	// OpenTimeout is the initial duration of the open state,
+	// DefaultBreakerOpenTimeout if zero.
+	OpenTimeout time.Duration
+	// MaxOpenTimeout limits the duration of the open state,
+	// DefaultBreakerMaxOpenTimeout if zero. It is never shorter
+	// than OpenTimeout.
+	MaxOpenTimeout time.Duration
+	// OnStateChange, if set, gets called for each state
+	// transition. It must not block.
+	OnStateChange func(name string, from, to BreakerState)
+
+	mutex    sync.Mutex
+	state    BreakerState
+	failures int
+	openedAt time.Time
+	openFor  time.Duration
+	now      func() time.Time
+}
+
+// State returns the current state. An open breaker only becomes
+// half-open when the next call is attempted.
+func (cb *CircuitBreaker) State() BreakerState {
+	cb.mutex.Lock()
+	defer cb.mutex.Unlock()
+	return cb.state
+}
+
+func (cb *CircuitBreaker) threshold() int {
+	if cb.Threshold > 0 {
+		return cb.Threshold
+	}
+	return DefaultBreakerThreshold
+}
+
+func (cb *CircuitBreaker) openTimeout() time.Duration {
+	if cb.OpenTimeout > 0 {
+		return cb.OpenTimeout
+	}
+	return DefaultBreakerOpenTimeout
+}
+
+func (cb *CircuitBreaker) maxOpenTimeout() time.Duration {
+	max := cb.MaxOpenTimeout
+	if max <= 0 {
+		max = DefaultBreakerMaxOpenTimeout
+	}
+	if openTimeout := cb.openTimeout(); max < openTimeout {
+		return openTimeout
+	}
+	return max
+}
+
+func (cb *CircuitBreaker) timeNow() time.Time {
+	if cb.now != nil {
+		return cb.now()
+	}
+	return time.Now()
+}
+
+// setState must be called with the mutex locked.
+func (cb *CircuitBreaker) setState(state BreakerState) {
+	if state == cb.state {
+		return
+	}
+	from := cb.state
+	cb.state = state
+	switch state {
+	case BreakerOpen:
+		cb.openedAt = cb.timeNow()
+		if from != BreakerHalfOpen {
+			cb.openFor = cb.openTimeout()
+		}
+	case BreakerClosed:
+		cb.openFor = 0
+	}
+	if cb.Name != "" {
+		BreakerStates.WithLabelValues(cb.Name).Set(float64(state))
+	}
+	if cb.OnStateChange != nil {
+		cb.OnStateChange(cb.Name, from, state)
+	}
+}
+
+// allow decides whether a call may proceed.
+func (cb *CircuitBreaker) allow() bool {
+	cb.mutex.Lock()
+	defer cb.mutex.Unlock()
+	switch cb.state {
+	case BreakerClosed:
+		return true
+	case BreakerOpen:
+		if cb.timeNow().Sub(cb.openedAt) < cb.openFor {
+			return false
+		}
+		// This call is the probe, all others fail until
+		// it completes.
+		cb.setState(BreakerHalfOpen)
+		return true
+	}
+	return false
+}
+
+// done records the result of a call that was allowed.
+func (cb *CircuitBreaker) done(err error) {
+	cb.mutex.Lock()
+	defer cb.mutex.Unlock()
+	if !breakerFailure(err) {
+		cb.failures = 0
+		cb.setState(BreakerClosed)
+		return
+	}
+	cb.failures++
+	if cb.state == BreakerHalfOpen {
+		// Failed probe, back off further.
+		cb.openFor *= 2
+		if max := cb.maxOpenTimeout(); cb.openFor > max {
+			cb.openFor = max
+		}
+	}
+	if cb.state == BreakerHalfOpen || cb.failures >= cb.threshold() {
+		cb.setState(BreakerOpen)
+	}
+}
+
+// retryAt returns the time when an open breaker lets the next call
+// through, the zero time if it is not open.
+func (cb *CircuitBreaker) retryAt() time.Time {
+	cb.mutex.Lock()
+	defer cb.mutex.Unlock()
+	if cb.state != BreakerOpen {
+		return time.Time{}
+	}
+	return cb.openedAt.Add(cb.openFor)
+}
+
+// consecutiveFailures returns the number of failures since the last
+// successful call.
+func (cb *CircuitBreaker) consecutiveFailures() int {
+	cb.mutex.Lock()
+	defer cb.mutex.Unlock()
+	return cb.failures
+}
+
+// breakerFailure returns true for errors that are likely caused by
+// a server which cannot be reached or does not respond. Errors that
+// are not gRPC status errors, like those from dialing, count as
+// well. ResourceExhausted and Internal do not: the registry passes
+// them through from controllers for individual requests, for
+// example when a node has no room for another volume.
+func breakerFailure(err error) bool {
+	switch status.Code(err) {
+	case codes.Unavailable, codes.DeadlineExceeded, codes.Unknown:
+		return true
+	}
+	return false
+}
+
+// run calls f unless the breaker is open and records the result.
+// what describes the call in the error returned while open.
+func (cb *CircuitBreaker) run(ctx context.Context, what string, f func() error) error {
+	if !cb.allow() {
+		return status.Errorf(codes.Unavailable, "circuit breaker %q is open: %s not called", cb.Name, what)
+	}
+	err := f()
+	if ctx.Err() != nil && status.Code(err) != codes.OK {
+		// Failed because the caller gave up, which says
+		// nothing about the server. A pending probe must
+		// still be resolved, though.
+		cb.mutex.Lock()
+		if cb.state == BreakerHalfOpen {
+			cb.setState(BreakerOpen)
+		}
+		cb.mutex.Unlock()
+		return err
+	}
+	cb.done(err)
+	return err
+}
+
+// UnaryClient is a gRPC interceptor which applies the circuit
+// breaker to client calls.
+func (cb *CircuitBreaker) UnaryClient(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	return cb.run(ctx, method, func() error {
+		return invoker(ctx, method, req, reply, cc, opts...)
+	})
+}
@@ -0,0 +1,139 @@
+/*
+Copyright (C) 2018 Intel Corporation.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package oimcommon
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestCircuitBreaker(t *testing.T) {
+	now := time.Now()
+	var transitions []string
+	cb := &CircuitBreaker{
+		Name:        "test",
+		Threshold:   2,
+		OpenTimeout: time.Minute,
+		OnStateChange: func(name string, from, to BreakerState) {
+			assert.Equal(t, "test", name)
+			transitions = append(transitions, from.String()+" -> "+to.String())
+		},
+		now: func() time.Time { return now },
+	}
+
+	ctx := context.Background()
+	called := false
+	call := func(ctx context.Context, result error) error {
+		called = false
+		return cb.UnaryClient(ctx, "/test/Unary", nil, nil, nil, func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+			called = true
+			return result
+		})
+	}
+	unavailable := status.Error(codes.Unavailable, "down")
+
+	// Errors caused by the request do not count.
+	assert.Equal(t, codes.NotFound, status.Code(call(ctx, status.Error(codes.NotFound, ""))))
+	assert.Equal(t, codes.NotFound, status.Code(call(ctx, status.Error(codes.NotFound, ""))))
+	assert.Equal(t, BreakerClosed, cb.State(), "request errors")
+
+	// Success resets the failure count.
+	assert.Error(t, call(ctx, unavailable))
+	assert.NoError(t, call(ctx, nil))
+	assert.Error(t, call(ctx, unavailable))
+	assert.Equal(t, BreakerClosed, cb.State(), "not consecutive")
+
+	// Tripped by consecutive failures.
+	assert.Error(t, call(ctx, unavailable))
+	assert.Equal(t, BreakerOpen, cb.State(), "tripped")
+	assert.Equal(t, 1.0, gaugeValue(t, "test"), "metric")
+	err := call(ctx, nil)
+	assert.Equal(t, codes.Unavailable, status.Code(err), "fast fail")
+	assert.False(t, called, "fast fail")
+
+	// A failed probe keeps the breaker open.
+	now = now.Add(time.Minute)
+	assert.Error(t, call(ctx, unavailable))
+	assert.True(t, called, "probe")
+	assert.Equal(t, BreakerOpen, cb.State(), "failed probe")
+	assert.Error(t, call(ctx, nil))
+	assert.False(t, called, "open again")
+
+	// Only one probe at a time.
+	now = now.Add(time.Minute)
+	err = cb.UnaryClient(ctx, "/test/Unary", nil, nil, nil, func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		assert.Equal(t, BreakerHalfOpen, cb.State(), "probing")
+		assert.Error(t, call(ctx, nil), "concurrent call")
+		assert.False(t, called, "concurrent call")
+		return nil
+	})
+	assert.NoError(t, err, "successful probe")
+	assert.Equal(t, BreakerClosed, cb.State(), "recovered")
+	assert.Equal(t, 0.0, gaugeValue(t, "test"), "metric")
+
+	// Failures caused by the caller do not count.
+	canceled, cancel := context.WithCancel(ctx)
+	cancel()
+	for i := 0; i < 3; i++ {
+		assert.Error(t, call(canceled, status.Error(codes.Canceled, "")))
+	}
+	assert.Equal(t, BreakerClosed, cb.State(), "canceled calls")
+
+	assert.Equal(t, []string{
+		"closed -> open",
+		"open -> half-open",
+		"half-open -> open",
+		"open -> half-open",
+		"half-open -> closed",
+	}, transitions)
+}
+
+func TestCircuitBreakerBackoff(t *testing.T) {
+	now := time.Now()
+	cb := &CircuitBreaker{
+		Threshold:      1,
+		OpenTimeout:    time.Second,
+		MaxOpenTimeout: 3 * time.Second,
+		now:            func() time.Time { return now },
+	}
+	ctx := context.Background()
+	fail := func() error {
+		return cb.run(ctx, "test", func() error {
+			return status.Error(codes.Unavailable, "down")
+		})
+	}
+
+	// Each failed probe doubles the open timeout, up to the limit.
+	assert.Error(t, fail())
+	for _, timeout := range []time.Duration{time.Second, 2 * time.Second, 3 * time.Second, 3 * time.Second} {
+		assert.Equal(t, now.Add(timeout), cb.retryAt(), "open for %s", timeout)
+		now = now.Add(timeout)
+		assert.Error(t, fail())
+	}
+
+	// Success resets it.
+	now = now.Add(3 * time.Second)
+	assert.NoError(t, cb.run(ctx, "test", func() error { return nil }))
+	assert.Equal(t, time.Time{}, cb.retryAt())
+	assert.Error(t, fail())
+	assert.Equal(t, now.Add(time.Second), cb.retryAt())
+}
+
+func gaugeValue(t *testing.T, name string) float64 {
+	var metric dto.Metric
+	if !assert.NoError(t, BreakerStates.WithLabelValues(name).Write(&metric)) {
+		return -1
+	}
+	return metric.GetGauge().GetValue()
+}
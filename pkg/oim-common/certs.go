@@ -0,0 +1,147 @@
+/*
+Copyright (C) 2018 Intel Corporation.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package oimcommon
+
+import (
+	"crypto/tls"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/intel/oim/pkg/log"
+)
+
+// DefaultCertCheckInterval is the default for how often CertManager
+// checks whether the .crt and .key files were modified.
+const DefaultCertCheckInterval = 10 * time.Second
+
+// CertManager provides the certificate for TLS connections and
+// reloads it when the .crt or .key file changes, so that rotated
+// certificates get used for new connections without restarting the
+// process. Existing connections are not affected.
+//
+// The files are checked during the TLS handshake, at most once per
+// check interval, so no background goroutine is needed. A new pair
+// is only used once it can be loaded successfully; until then (for
+// example, while only one of the two files has been replaced) the
+// previous certificate remains in use.
+type CertManager struct {
+	crtFile, keyFile string
+	checkInterval    time.Duration
+	now              func() time.Time
+
+	mutex       sync.Mutex
+	certificate *tls.Certificate
+	crtStat     fileStat
+	keyStat     fileStat
+	lastCheck   time.Time
+}
+
+// fileStat is what gets compared to detect file modifications.
+type fileStat struct {
+	modTime time.Time
+	size    int64
+}
+
+// NewCertManager loads the .crt and .key files. The key can be
+// given like for LoadTLSConfig. A zero check interval checks the
+// files before each handshake.
+func NewCertManager(key string, checkInterval time.Duration) (*CertManager, error) {
+	crtFile, keyFile := certFiles(key)
+	cm := &CertManager{
+		crtFile:       crtFile,
+		keyFile:       keyFile,
+		checkInterval: checkInterval,
+		now:           time.Now,
+	}
+	if err := cm.load(); err != nil {
+		return nil, errors.Wrapf(err, "load X509 key pair for key=%q", key)
+	}
+	return cm, nil
+}
+
+// certFiles determines the .crt and .key file names for a key given
+// as base name or as one of the two files.
+func certFiles(key string) (string, string) {
+	base := key
+	if strings.HasSuffix(key, ".key") || strings.HasSuffix(key, ".crt") {
+		base = key[0 : len(key)-4]
+	}
+	return base + ".crt", base + ".key"
+}
+
+func statFile(path string) (fileStat, error) {
+	fi, err := os.Stat(path)
+	if err != nil {
+		return fileStat{}, err
+	}
+	return fileStat{modTime: fi.ModTime(), size: fi.Size()}, nil
+}
+
+// load reads both files and swaps the certificate. Must be called
+// with the mutex locked (or before the manager is in use).
+func (cm *CertManager) load() error {
+	crtStat, err := statFile(cm.crtFile)
+	if err != nil {
+		return err
+	}
+	keyStat, err := statFile(cm.keyFile)
+	if err != nil {
+		return err
+	}
+	certificate, err := tls.LoadX509KeyPair(cm.crtFile, cm.keyFile)
+	if err != nil {
+		return err
+	}
+	cm.certificate = &certificate
+	cm.crtStat = crtStat
+	cm.keyStat = keyStat
+	cm.lastCheck = cm.now()
+	return nil
+}
+
+// current returns the certificate, after reloading it if the files
+// have changed.
+func (cm *CertManager) current() *tls.Certificate {
+	cm.mutex.Lock()
+	defer cm.mutex.Unlock()
+
+	now := cm.now()
+	if now.Sub(cm.lastCheck) < cm.checkInterval {
+		return cm.certificate
+	}
+	cm.lastCheck = now
+	crtStat, crtErr := statFile(cm.crtFile)
+	keyStat, keyErr := statFile(cm.keyFile)
+	if crtErr == nil && keyErr == nil && crtStat == cm.crtStat && keyStat == cm.keyStat {
+		return cm.certificate
+	}
+	if err := cm.load(); err != nil {
+		log.L().Warnw("reloading certificate failed, continuing to use the old one",
+			"crt", cm.crtFile,
+			"key", cm.keyFile,
+			"error", err,
+		)
+		return cm.certificate
+	}
+	log.L().Infow("reloaded certificate", "crt", cm.crtFile, "key", cm.keyFile)
+	return cm.certificate
+}
+
+// GetCertificate can be used as tls.Config.GetCertificate in a server.
+func (cm *CertManager) GetCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	return cm.current(), nil
+}
+
+// GetClientCertificate can be used as tls.Config.GetClientCertificate
+// in a client.
+func (cm *CertManager) GetClientCertificate(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+	return cm.current(), nil
+}
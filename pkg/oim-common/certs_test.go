@@ -0,0 +1,180 @@
+/*
+Copyright (C) 2018 Intel Corporation.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package oimcommon
+
+import (
+	"bufio"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testCA signs certificates for the test.
+type testCA struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+}
+
+func newTestCA(t *testing.T) *testCA {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	return &testCA{cert: cert, key: key}
+}
+
+// writeCert creates a new key and certificate with the given serial
+// number and writes them to <base>.key and <base>.crt.
+func (ca *testCA) writeCert(t *testing.T, base string, serial int64) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(serial),
+		Subject:      pkix.Name{CommonName: "component.registry"},
+		DNSNames:     []string{"component.registry"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, ca.cert, &key.PublicKey, ca.key)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	// Replace atomically, like a tool rotating the files would.
+	write := func(file, blockType string, bytes []byte) {
+		tmp := file + ".tmp"
+		require.NoError(t, ioutil.WriteFile(tmp, pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: bytes}), 0600))
+		require.NoError(t, os.Rename(tmp, file))
+	}
+	write(base+".key", "EC PRIVATE KEY", keyDER)
+	write(base+".crt", "CERTIFICATE", der)
+}
+
+func TestCertManager(t *testing.T) {
+	tmp, err := ioutil.TempDir("", "certs")
+	require.NoError(t, err)
+	defer os.RemoveAll(tmp)
+	base := filepath.Join(tmp, "component.registry")
+
+	ca := newTestCA(t)
+	ca.writeCert(t, base, 100)
+	cm, err := NewCertManager(base+".crt", 0)
+	require.NoError(t, err)
+
+	// An echo server which uses the certificate manager.
+	listener, err := tls.Listen("tcp", "127.0.0.1:0", &tls.Config{
+		GetCertificate: cm.GetCertificate,
+	})
+	require.NoError(t, err)
+	defer listener.Close()
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				reader := bufio.NewReader(conn)
+				for {
+					line, err := reader.ReadString('\n')
+					if err != nil {
+						return
+					}
+					if _, err := conn.Write([]byte(line)); err != nil {
+						return
+					}
+				}
+			}()
+		}
+	}()
+
+	pool := x509.NewCertPool()
+	pool.AddCert(ca.cert)
+	connect := func() *tls.Conn {
+		conn, err := tls.Dial("tcp", listener.Addr().String(), &tls.Config{
+			RootCAs:    pool,
+			ServerName: "component.registry",
+		})
+		require.NoError(t, err)
+		return conn
+	}
+	serial := func(conn *tls.Conn) int64 {
+		return conn.ConnectionState().PeerCertificates[0].SerialNumber.Int64()
+	}
+	echo := func(conn *tls.Conn) {
+		_, err := conn.Write([]byte("hello\n"))
+		require.NoError(t, err)
+		line, err := bufio.NewReader(conn).ReadString('\n')
+		require.NoError(t, err)
+		assert.Equal(t, "hello\n", line)
+	}
+
+	old := connect()
+	defer old.Close()
+	echo(old)
+	assert.Equal(t, int64(100), serial(old), "initial certificate")
+
+	// Rotate while the first connection is open.
+	ca.writeCert(t, base, 101)
+	rotated := connect()
+	defer rotated.Close()
+	echo(rotated)
+	assert.Equal(t, int64(101), serial(rotated), "new connection uses rotated certificate")
+	echo(old)
+	assert.Equal(t, int64(100), serial(old), "old connection keeps working with old certificate")
+
+	// A broken key is ignored, the last good certificate remains in use.
+	require.NoError(t, ioutil.WriteFile(base+".key", []byte("garbage"), 0600))
+	broken := connect()
+	defer broken.Close()
+	assert.Equal(t, int64(101), serial(broken), "broken key")
+
+	// Without the check interval passing, nothing gets reloaded.
+	cm.mutex.Lock()
+	cm.checkInterval = time.Hour
+	cm.mutex.Unlock()
+	ca.writeCert(t, base, 102)
+	cached := connect()
+	defer cached.Close()
+	assert.Equal(t, int64(101), serial(cached), "within check interval")
+	cm.mutex.Lock()
+	cm.now = func() time.Time { return time.Now().Add(2 * time.Hour) }
+	cm.mutex.Unlock()
+	reloaded := connect()
+	defer reloaded.Close()
+	assert.Equal(t, int64(102), serial(reloaded), "after check interval")
+
+	_, err = NewCertManager(filepath.Join(tmp, "no-such-key"), 0)
+	assert.Error(t, err)
+}
@@ -0,0 +1,210 @@
+/*
+Copyright (C) 2018 Intel Corporation.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package oimcommon
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/keepalive"
+)
+
+const (
+	// DefaultKeepaliveTime is how long a connection with active
+	// calls may be idle before the client checks with a ping
+	// whether the server is still there.
+	DefaultKeepaliveTime = 30 * time.Second
+
+	// DefaultKeepaliveTimeout is how long the client waits for
+	// the reply to a keepalive ping before closing the connection.
+	DefaultKeepaliveTimeout = 10 * time.Second
+
+	// DefaultBackoffMaxDelay is the upper limit for the delay
+	// between attempts to re-establish a broken connection.
+	DefaultBackoffMaxDelay = 5 * time.Second
+
+	// DefaultMaxMsgSize is the largest message that clients and
+	// servers send or receive by default. The gRPC default of
+	// 4MB is too small for listing all volumes of a large host.
+	DefaultMaxMsgSize = 16 * 1024 * 1024
+)
+
+// KeepaliveEnforcementPolicy must be used by servers to accept the
+// keepalive pings sent by clients which connect with Dial. Without
+// it, the server closes connections which ping more often than
+// every five minutes.
+var KeepaliveEnforcementPolicy = keepalive.EnforcementPolicy{
+	MinTime: DefaultKeepaliveTime / 2,
+}
+
+type dialOptions struct {
+	creds        credentials.TransportCredentials
+	caFile       string
+	key          string
+	peerName     string
+	interceptors []grpc.UnaryClientInterceptor
+	keepalive    keepalive.ClientParameters
+	backoff      time.Duration
+	maxMsgSize   int
+	roundRobin   bool
+	grpcOptions  []grpc.DialOption
+}
+
+// DialOption is the parameter type taken by Dial and DialOpts.
+type DialOption func(*dialOptions)
+
+// WithTLSFiles enables mutual TLS with the CA and key files as in
+// LoadTLS. The files are loaded anew for each connection, so their
+// content may change over time.
+func WithTLSFiles(caFile, key, peerName string) DialOption {
+	return func(o *dialOptions) {
+		o.caFile = caFile
+		o.key = key
+		o.peerName = peerName
+	}
+}
+
+// WithCredentials enables TLS with credentials that were already
+// loaded.
+func WithCredentials(creds credentials.TransportCredentials) DialOption {
+	return func(o *dialOptions) {
+		o.creds = creds
+	}
+}
+
+// WithInterceptors adds client interceptors, see ChooseDialOptsWithInterceptors.
+func WithInterceptors(interceptors ...grpc.UnaryClientInterceptor) DialOption {
+	return func(o *dialOptions) {
+		o.interceptors = append(o.interceptors, interceptors...)
+	}
+}
+
+// WithMetrics records all calls made via the connection.
+func WithMetrics(metrics *GRPCMetrics) DialOption {
+	return func(o *dialOptions) {
+		o.interceptors = append(o.interceptors, metrics.UnaryClient)
+	}
+}
+
+// WithCircuitBreaker fails calls early while the server is known to
+// be failing. The breaker may be shared between connections to the
+// same server.
+func WithCircuitBreaker(cb *CircuitBreaker) DialOption {
+	return func(o *dialOptions) {
+		o.interceptors = append(o.interceptors, cb.UnaryClient)
+	}
+}
+
+// WithKeepalive replaces the default keepalive parameters.
+func WithKeepalive(params keepalive.ClientParameters) DialOption {
+	return func(o *dialOptions) {
+		o.keepalive = params
+	}
+}
+
+// WithBackoffMaxDelay replaces DefaultBackoffMaxDelay.
+func WithBackoffMaxDelay(delay time.Duration) DialOption {
+	return func(o *dialOptions) {
+		o.backoff = delay
+	}
+}
+
+// WithMaxMsgSize replaces DefaultMaxMsgSize for sending and
+// receiving.
+func WithMaxMsgSize(size int) DialOption {
+	return func(o *dialOptions) {
+		o.maxMsgSize = size
+	}
+}
+
+// WithRoundRobin treats the endpoint as a comma-separated list of
+// endpoints, for example of several controller instances on the
+// same node. Dial then connects to all of them and sends each call
+// to the next endpoint in turn, skipping those whose connection is
+// currently broken.
+func WithRoundRobin() DialOption {
+	return func(o *dialOptions) {
+		o.roundRobin = true
+	}
+}
+
+// WithGRPCOptions adds further options for grpc.DialContext.
+func WithGRPCOptions(opts ...grpc.DialOption) DialOption {
+	return func(o *dialOptions) {
+		o.grpcOptions = append(o.grpcOptions, opts...)
+	}
+}
+
+// DialOpts returns the options for grpc.DialContext that Dial would
+// use. Without TLS options, the connection is insecure. With
+// WithRoundRobin, grpc.DialContext must be called with
+// RoundRobinTarget(endpoint).
+func DialOpts(endpoint string, options ...DialOption) ([]grpc.DialOption, error) {
+	_, opts, err := dialOpts(endpoint, options...)
+	return opts, err
+}
+
+// dialOpts returns the target for grpc.DialContext and its options.
+func dialOpts(endpoint string, options ...DialOption) (string, []grpc.DialOption, error) {
+	o := dialOptions{
+		keepalive: keepalive.ClientParameters{
+			Time:    DefaultKeepaliveTime,
+			Timeout: DefaultKeepaliveTimeout,
+		},
+		backoff:    DefaultBackoffMaxDelay,
+		maxMsgSize: DefaultMaxMsgSize,
+	}
+	for _, option := range options {
+		option(&o)
+	}
+
+	opts := []grpc.DialOption{
+		grpc.WithKeepaliveParams(o.keepalive),
+		grpc.WithBackoffMaxDelay(o.backoff),
+		grpc.WithDefaultCallOptions(
+			grpc.MaxCallRecvMsgSize(o.maxMsgSize),
+			grpc.MaxCallSendMsgSize(o.maxMsgSize),
+		),
+	}
+	switch {
+	case o.creds != nil:
+		opts = append(opts, grpc.WithTransportCredentials(o.creds))
+	case o.key != "":
+		creds, err := LoadTLS(o.caFile, o.key, o.peerName)
+		if err != nil {
+			return "", nil, errors.Wrap(err, "load TLS certs")
+		}
+		opts = append(opts, grpc.WithTransportCredentials(creds))
+	default:
+		opts = append(opts, grpc.WithInsecure())
+	}
+	target := endpoint
+	if o.roundRobin {
+		target = RoundRobinTarget(endpoint)
+		opts = append(opts,
+			grpc.WithDialer(roundRobinDialer),
+			grpc.WithBalancerName(roundRobinBalancer),
+		)
+	}
+	opts = append(opts, o.grpcOptions...)
+	return target, ChooseDialOptsWithInterceptors(target, o.interceptors, opts...), nil
+}
+
+// Dial connects to a gRPC endpoint. Besides the addresses understood
+// by grpc.Dial, unix:// and tcp:// endpoints as defined for
+// ParseEndpoint are supported. All connections use keepalive pings
+// and the same reconnect backoff.
+func Dial(ctx context.Context, endpoint string, options ...DialOption) (*grpc.ClientConn, error) {
+	target, opts, err := dialOpts(endpoint, options...)
+	if err != nil {
+		return nil, err
+	}
+	return grpc.DialContext(ctx, target, opts...)
+}
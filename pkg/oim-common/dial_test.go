@@ -0,0 +1,88 @@
+/*
+Copyright (C) 2018 Intel Corporation.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package oimcommon
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+)
+
+func TestDial(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	tmp, err := ioutil.TempDir("", "dial")
+	require.NoError(t, err)
+	defer os.RemoveAll(tmp)
+
+	connect := func(endpoint string) {
+		conn, err := Dial(ctx, endpoint, WithGRPCOptions(grpc.WithBlock()))
+		if assert.NoError(t, err, "connect to %s", endpoint) {
+			conn.Close()
+		}
+	}
+
+	server := &NonBlockingGRPCServer{Endpoint: "tcp://127.0.0.1:0"}
+	require.NoError(t, server.Start(ctx))
+	connect(server.Addr().String())
+	connect("tcp://" + server.Addr().String())
+	server.ForceStop(ctx)
+	server.Wait(ctx)
+
+	path := filepath.Join(tmp, "server.sock")
+	server = &NonBlockingGRPCServer{Endpoint: "unix://" + path}
+	require.NoError(t, server.Start(ctx))
+	connect("unix://" + path)
+	server.ForceStop(ctx)
+	server.Wait(ctx)
+
+	_, err = Dial(ctx, "unix://"+path, WithTLSFiles(filepath.Join(tmp, "ca.crt"), filepath.Join(tmp, "no-such-key"), ""))
+	assert.Error(t, err, "missing TLS files")
+}
+
+func TestMaxMsgSize(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// check sends a message of the given size.
+	check := func(size, serverMaxMsgSize int, options ...DialOption) error {
+		server := &NonBlockingGRPCServer{
+			Endpoint:   "tcp://127.0.0.1:0",
+			MaxMsgSize: serverMaxMsgSize,
+		}
+		require.NoError(t, server.Start(ctx, func(s *grpc.Server) {
+			grpc_health_v1.RegisterHealthServer(s, &countingHealth{})
+		}))
+		defer func() {
+			server.ForceStop(ctx)
+			server.Wait(ctx)
+		}()
+		conn, err := Dial(ctx, server.Addr().String(), options...)
+		require.NoError(t, err)
+		defer conn.Close()
+		_, err = grpc_health_v1.NewHealthClient(conn).Check(ctx, &grpc_health_v1.HealthCheckRequest{
+			Service: strings.Repeat("x", size),
+		})
+		return err
+	}
+
+	// Above the 4MB gRPC default.
+	assert.NoError(t, check(5*1024*1024, 0), "default")
+	assert.Equal(t, codes.ResourceExhausted, status.Code(check(2000, 1024)), "server limit")
+	assert.Equal(t, codes.ResourceExhausted, status.Code(check(2000, 0, WithMaxMsgSize(1024))), "client limit")
+}
@@ -0,0 +1,100 @@
+/*
+Copyright 2018 Intel Corporation.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package oimcommon
+
+import (
+	"context"
+	"fmt"
+	"syscall"
+
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/intel/oim/pkg/spdk"
+)
+
+// spdkCodes maps SPDK JSON error codes to gRPC codes. Besides the
+// JSON-RPC codes, SPDK also returns negative errno values.
+//
+// SPDK currently reports many problems, including BDevs that were
+// not found, as ERROR_INVALID_PARAMS
+// (https://github.com/spdk/spdk/issues/319), so callers which can
+// tell better should check for those cases themselves.
+var spdkCodes = map[int]codes.Code{
+	spdk.ERROR_INVALID_PARAMS:   codes.InvalidArgument,
+	spdk.ERROR_METHOD_NOT_FOUND: codes.Unimplemented,
+	spdk.ERROR_INTERNAL_ERROR:   codes.Internal,
+	spdk.ERROR_INVALID_STATE:    codes.FailedPrecondition,
+	-int(syscall.EINVAL):        codes.InvalidArgument,
+	-int(syscall.ENOENT):        codes.NotFound,
+	-int(syscall.ENODEV):        codes.NotFound,
+	-int(syscall.EEXIST):        codes.AlreadyExists,
+	-int(syscall.ENOMEM):        codes.ResourceExhausted,
+	-int(syscall.ENOSPC):        codes.ResourceExhausted,
+	-int(syscall.EBUSY):         codes.FailedPrecondition,
+}
+
+// GRPCCode determines the gRPC status code which describes the cause
+// of an error. gRPC status errors, also when wrapped with
+// errors.Wrap, keep their code unless it is codes.Unknown. SPDK JSON
+// errors are mapped to the corresponding code. Everything else gets
+// the fallback code.
+func GRPCCode(err error, fallback codes.Code) codes.Code {
+	if err == nil {
+		return codes.OK
+	}
+	cause := errors.Cause(err)
+	if s, ok := status.FromError(cause); ok && s.Code() != codes.Unknown {
+		return s.Code()
+	}
+	if code, ok := spdk.JSONErrorCode(cause); ok {
+		if c, ok := spdkCodes[code]; ok {
+			return c
+		}
+	}
+	return fallback
+}
+
+// GRPCError returns a gRPC status error with the code chosen by
+// GRPCCode and a message that starts with the formatted text,
+// followed by the message of the original error.
+func GRPCError(err error, fallback codes.Code, format string, args ...interface{}) error {
+	message := err.Error()
+	if s, ok := status.FromError(err); ok {
+		message = s.Message()
+	}
+	return status.Errorf(GRPCCode(err, fallback), "%s: %s", fmt.Sprintf(format, args...), message)
+}
+
+// MallocBDevError is like GRPCError, but for errors returned by
+// ConstructMallocBDev after the name and size of the new BDev were
+// already checked by the caller. SPDK reports a failed allocation as
+// ERROR_INVALID_PARAMS, which then is most likely caused by running
+// out of memory and thus gets mapped to codes.ResourceExhausted.
+func MallocBDevError(err error, fallback codes.Code, format string, args ...interface{}) error {
+	if spdk.IsJSONError(err, spdk.ERROR_INVALID_PARAMS) {
+		return status.Errorf(codes.ResourceExhausted, "%s: %s", fmt.Sprintf(format, args...), err)
+	}
+	return GRPCError(err, fallback, format, args...)
+}
+
+// TranslateErrors is a gRPC interceptor which ensures that a server
+// only returns gRPC status errors. Errors returned by the handler
+// which are not status errors get converted with GRPCCode, using
+// codes.Internal as fallback, so that clients can decide whether a
+// call may be retried.
+func TranslateErrors(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	resp, err := handler(ctx, req)
+	if err != nil {
+		if _, ok := status.FromError(err); !ok {
+			err = status.Error(GRPCCode(err, codes.Internal), err.Error())
+		}
+	}
+	return resp, err
+}
@@ -0,0 +1,104 @@
+/*
+Copyright 2018 Intel Corporation.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package oimcommon
+
+import (
+	"context"
+	"fmt"
+	"net/rpc"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/intel/oim/pkg/spdk"
+)
+
+// spdkError creates an error as returned by the SPDK client.
+func spdkError(code int) error {
+	return rpc.ServerError(fmt.Sprintf("code: %d msg: some failure", code))
+}
+
+func TestGRPCCode(t *testing.T) {
+	for i, tc := range []struct {
+		err  error
+		code codes.Code
+	}{
+		{nil, codes.OK},
+		{errors.New("foo"), codes.Aborted},
+		{status.Error(codes.ResourceExhausted, "full"), codes.ResourceExhausted},
+		{status.Error(codes.Unknown, "unknown"), codes.Aborted},
+		{errors.Wrap(status.Error(codes.NotFound, "gone"), "wrapped"), codes.NotFound},
+		{spdkError(spdk.ERROR_INVALID_PARAMS), codes.InvalidArgument},
+		{errors.Wrap(spdkError(spdk.ERROR_INVALID_PARAMS), "ConstructMallocBDev"), codes.InvalidArgument},
+		{spdkError(spdk.ERROR_METHOD_NOT_FOUND), codes.Unimplemented},
+		{spdkError(spdk.ERROR_INTERNAL_ERROR), codes.Internal},
+		{spdkError(spdk.ERROR_INVALID_STATE), codes.FailedPrecondition},
+		{spdkError(-2), codes.NotFound},
+		{spdkError(-19), codes.NotFound},
+		{spdkError(-17), codes.AlreadyExists},
+		{spdkError(-12), codes.ResourceExhausted},
+		{spdkError(-28), codes.ResourceExhausted},
+		{spdkError(-22), codes.InvalidArgument},
+		{spdkError(-16), codes.FailedPrecondition},
+		{spdkError(-1000), codes.Aborted},
+	} {
+		assert.Equal(t, tc.code, GRPCCode(tc.err, codes.Aborted), "%d: %v", i, tc.err)
+	}
+}
+
+func TestGRPCError(t *testing.T) {
+	err := GRPCError(status.Error(codes.ResourceExhausted, "full"), codes.FailedPrecondition, "MapVolume for %s failed", "vol")
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+	assert.Equal(t, "MapVolume for vol failed: full", status.Convert(err).Message())
+
+	err = GRPCError(errors.New("broken"), codes.FailedPrecondition, "delete")
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	assert.Equal(t, "delete: broken", status.Convert(err).Message())
+}
+
+func TestMallocBDevError(t *testing.T) {
+	for i, tc := range []struct {
+		err  error
+		code codes.Code
+	}{
+		{spdkError(spdk.ERROR_INVALID_PARAMS), codes.ResourceExhausted},
+		{errors.Wrap(spdkError(spdk.ERROR_INVALID_PARAMS), "ConstructMallocBDev"), codes.ResourceExhausted},
+		{spdkError(-17), codes.AlreadyExists},
+		{spdkError(spdk.ERROR_INTERNAL_ERROR), codes.Internal},
+		{errors.New("broken"), codes.FailedPrecondition},
+	} {
+		err := MallocBDevError(tc.err, codes.FailedPrecondition, "create %s", "vol")
+		assert.Equal(t, tc.code, status.Code(err), "%d: %v", i, tc.err)
+		assert.Contains(t, status.Convert(err).Message(), "create vol: ", "%d", i)
+	}
+}
+
+func TestTranslateErrors(t *testing.T) {
+	for i, tc := range []struct {
+		err  error
+		code codes.Code
+	}{
+		{nil, codes.OK},
+		{errors.New("foo"), codes.Internal},
+		{status.Error(codes.Unknown, "unknown"), codes.Unknown},
+		{status.Error(codes.NotFound, "gone"), codes.NotFound},
+		{errors.Wrap(spdkError(-17), "ConstructMallocBDev"), codes.AlreadyExists},
+	} {
+		handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+			return nil, tc.err
+		}
+		_, err := TranslateErrors(context.Background(), nil, &grpc.UnaryServerInfo{}, handler)
+		assert.Equal(t, tc.code, status.Code(err), "%d: %v", i, tc.err)
+		if tc.err != nil {
+			assert.Contains(t, status.Convert(err).Message(), status.Convert(tc.err).Message(), "%d", i)
+		}
+	}
+}
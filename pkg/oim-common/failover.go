@@ -0,0 +1,165 @@
+/*
+Copyright (C) 2018 Intel Corporation.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package oimcommon
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+
+	"github.com/intel/oim/pkg/log"
+)
+
+// DefaultFailoverDialTimeout is the default time allowed for
+// connecting to a single endpoint.
+const DefaultFailoverDialTimeout = 5 * time.Second
+
+// DialOptsFunc returns the options for connecting to one endpoint.
+// It gets called for each connection attempt.
+type DialOptsFunc func(endpoint string) ([]grpc.DialOption, error)
+
+// Failover connects to one of several equivalent gRPC endpoints,
+// for example replicas of the OIM registry. Endpoints are tried in
+// the order in which they were given, except that endpoints which
+// recently failed are tried last. Each endpoint has its own
+// CircuitBreaker: after repeated failures, an endpoint is skipped
+// until its breaker lets a probe through again.
+type Failover struct {
+	endpoints   []string
+	dialOpts    DialOptsFunc
+	dialTimeout time.Duration
+	threshold   int
+	openTimeout time.Duration
+	now         func() time.Time
+
+	mutex    sync.Mutex
+	breakers map[string]*CircuitBreaker
+}
+
+// FailoverOption configures a Failover instance.
+type FailoverOption func(f *Failover)
+
+// WithFailoverDialTimeout sets the time allowed for connecting to a
+// single endpoint.
+func WithFailoverDialTimeout(timeout time.Duration) FailoverOption {
+	return func(f *Failover) {
+		f.dialTimeout = timeout
+	}
+}
+
+// WithFailoverBreaker sets CircuitBreaker.Threshold and
+// CircuitBreaker.OpenTimeout for the breakers of all endpoints.
+// Zero values select the defaults.
+func WithFailoverBreaker(threshold int, openTimeout time.Duration) FailoverOption {
+	return func(f *Failover) {
+		f.threshold = threshold
+		f.openTimeout = openTimeout
+	}
+}
+
+// NewFailover creates a new instance for the given endpoints.
+func NewFailover(endpoints []string, dialOpts DialOptsFunc, options ...FailoverOption) *Failover {
+	f := &Failover{
+		endpoints:   endpoints,
+		dialOpts:    dialOpts,
+		dialTimeout: DefaultFailoverDialTimeout,
+		now:         time.Now,
+		breakers:    map[string]*CircuitBreaker{},
+	}
+	for _, op := range options {
+		op(f)
+	}
+	return f
+}
+
+// Breaker returns the circuit breaker of the endpoint. Connections
+// to the endpoint can use it with WithCircuitBreaker, then failed
+// calls also cause the endpoint to be skipped.
+func (f *Failover) Breaker(endpoint string) *CircuitBreaker {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	cb := f.breakers[endpoint]
+	if cb == nil {
+		cb = &CircuitBreaker{
+			Name:        endpoint,
+			Threshold:   f.threshold,
+			OpenTimeout: f.openTimeout,
+			now:         func() time.Time { return f.now() },
+		}
+		f.breakers[endpoint] = cb
+	}
+	return cb
+}
+
+// Dial tries to connect to each endpoint in turn and returns the
+// first connection that could be established.
+func (f *Failover) Dial(ctx context.Context) (*grpc.ClientConn, error) {
+	if len(f.endpoints) == 0 {
+		return nil, errors.New("no endpoints")
+	}
+	var errs []string
+	for _, endpoint := range f.order() {
+		var conn *grpc.ClientConn
+		err := f.Breaker(endpoint).run(ctx, "dial", func() error {
+			var err error
+			conn, err = f.dial(ctx, endpoint)
+			return err
+		})
+		if err == nil {
+			return conn, nil
+		}
+		log.FromContext(ctx).Warnw("connection failed", "endpoint", endpoint, "error", err)
+		errs = append(errs, err.Error())
+		if ctx.Err() != nil {
+			break
+		}
+	}
+	return nil, errors.Errorf("connect to %v: %v", f.endpoints, errs)
+}
+
+func (f *Failover) dial(ctx context.Context, endpoint string) (*grpc.ClientConn, error) {
+	opts, err := f.dialOpts(endpoint)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(ctx, f.dialTimeout)
+	defer cancel()
+	conn, err := grpc.DialContext(ctx, endpoint, append(opts, grpc.WithBlock())...)
+	if err != nil {
+		return nil, errors.Wrapf(err, "connect to %s", endpoint)
+	}
+	return conn, nil
+}
+
+// order returns the endpoints, with those whose breaker is open at
+// the end, sorted by the time when they may be tried again. The
+// others are sorted by their number of consecutive failures.
+func (f *Failover) order() []string {
+	type health struct {
+		retryAt  time.Time
+		failures int
+	}
+	endpoints := append([]string{}, f.endpoints...)
+	state := map[string]health{}
+	for _, endpoint := range endpoints {
+		cb := f.Breaker(endpoint)
+		state[endpoint] = health{cb.retryAt(), cb.consecutiveFailures()}
+	}
+	sort.SliceStable(endpoints, func(i, j int) bool {
+		a, b := state[endpoints[i]], state[endpoints[j]]
+		if !a.retryAt.Equal(b.retryAt) {
+			return a.retryAt.Before(b.retryAt)
+		}
+		return a.failures < b.failures
+	})
+	return endpoints
+}
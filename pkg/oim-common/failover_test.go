@@ -0,0 +1,79 @@
+/*
+Copyright (C) 2018 Intel Corporation.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package oimcommon
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+)
+
+func TestFailover(t *testing.T) {
+	tmp, err := ioutil.TempDir("", "failover")
+	require.NoError(t, err)
+	defer os.RemoveAll(tmp)
+
+	up := "unix://" + filepath.Join(tmp, "up.sock")
+	down := "unix://" + filepath.Join(tmp, "down.sock")
+	server := &NonBlockingGRPCServer{Endpoint: up}
+	require.NoError(t, server.Start(context.Background()))
+	defer func() { server.ForceStop(context.Background()) }()
+
+	var dialed []string
+	dialOpts := func(endpoint string) ([]grpc.DialOption, error) {
+		dialed = append(dialed, endpoint)
+		return ChooseDialOpts(endpoint, grpc.WithInsecure()), nil
+	}
+	now := time.Now()
+	f := NewFailover([]string{down, up}, dialOpts,
+		WithFailoverDialTimeout(100*time.Millisecond),
+		WithFailoverBreaker(2, time.Second))
+	f.now = func() time.Time { return now }
+	dial := func() error {
+		dialed = nil
+		conn, err := f.Dial(context.Background())
+		if err == nil {
+			conn.Close()
+		}
+		return err
+	}
+
+	// The first endpoint fails, then gets tried last.
+	assert.NoError(t, dial())
+	assert.Equal(t, []string{down, up}, dialed)
+	assert.NoError(t, dial())
+	assert.Equal(t, []string{up}, dialed)
+
+	// No endpoint available, which opens the breakers.
+	server.ForceStop(context.Background())
+	assert.Error(t, dial())
+	assert.Equal(t, []string{up, down}, dialed)
+	assert.Equal(t, BreakerOpen, f.Breaker(down).State())
+	assert.Error(t, dial())
+	assert.Equal(t, []string{up}, dialed)
+	assert.Equal(t, BreakerOpen, f.Breaker(up).State())
+	assert.Error(t, dial())
+	assert.Empty(t, dialed, "all endpoints skipped")
+
+	// Probed again after the open timeout, which doubles for
+	// the endpoint that is still down.
+	server = &NonBlockingGRPCServer{Endpoint: up}
+	require.NoError(t, server.Start(context.Background()))
+	now = now.Add(time.Second)
+	assert.NoError(t, dial())
+	assert.Equal(t, []string{down, up}, dialed)
+	assert.Equal(t, BreakerClosed, f.Breaker(up).State())
+	assert.Equal(t, BreakerOpen, f.Breaker(down).State())
+	assert.Equal(t, now.Add(2*time.Second), f.Breaker(down).retryAt())
+}
@@ -12,7 +12,6 @@ import (
 	"crypto/x509"
 	"io/ioutil"
 	"net"
-	"strings"
 	"time"
 
 	// "github.com/grpc-ecosystem/go-grpc-middleware"
@@ -38,20 +37,25 @@ func GRPCDialer(endpoint string, t time.Duration) (net.Conn, error) {
 }
 
 // ChooseDialOpts sets certain default options for the given endpoint,
-// then adds the ones given as additional parameters. For unix://
-// endpoints it activates the custom dialer and disables security.
+// then adds the ones given as additional parameters. For unix:// and
+// tcp:// endpoints it activates the custom dialer.
 func ChooseDialOpts(endpoint string, opts ...grpc.DialOption) []grpc.DialOption {
+	return ChooseDialOptsWithInterceptors(endpoint, nil, opts...)
+}
+
+// ChooseDialOptsWithInterceptors is like ChooseDialOpts, but also
+// installs additional client interceptors. They get invoked before
+// the builtin request ID propagation and logging, so each attempt of
+// a retried call gets logged separately.
+func ChooseDialOptsWithInterceptors(endpoint string, interceptors []grpc.UnaryClientInterceptor, opts ...grpc.DialOption) []grpc.DialOption {
 	result := []grpc.DialOption{}
 
-	if strings.HasPrefix(endpoint, "unix://") {
+	if _, _, err := ParseEndpoint(endpoint); err == nil {
 		result = append(result,
 			grpc.WithDialer(GRPCDialer),
 		)
 	}
 
-	// TODO: kubernetes-csi uses grpc.WithBackoffMaxDelay(time.Second),
-	// should we do the same?
-
 	// Tracing of outgoing calls, including remote and local logging.
 	formatter := StripSecretsFormatter{}
 	// interceptor := grpc_middleware.ChainUnaryClient(
@@ -59,7 +63,7 @@ func ChooseDialOpts(endpoint string, opts ...grpc.DialOption) []grpc.DialOption
 	// 		opentracing.GlobalTracer(),
 	// 		otgrpc.SpanDecorator(TraceGRPCPayload(formatter))),
 	// 	LogGRPCClient(formatter))
-	interceptor := LogGRPCClient(formatter)
+	interceptor := ChainUnaryClient(append(append([]grpc.UnaryClientInterceptor{}, interceptors...), RequestIDClient, LogGRPCClient(formatter))...)
 	opts = append(opts, grpc.WithUnaryInterceptor(interceptor))
 
 	result = append(result, opts...)
@@ -73,19 +77,21 @@ func ChooseDialOpts(endpoint string, opts ...grpc.DialOption) []grpc.DialOption
 //
 // caFile must be the full file name. keyFile can either be the .crt
 // file (foo.crt, implies foo.key) or the base name (foo for foo.crt
-// and foo.key).
-func LoadTLSConfig(caFile, key, peerName string) (*tls.Config, error) {
-	var base string
-	if strings.HasSuffix(key, ".key") || strings.HasSuffix(key, ".crt") {
-		base = key[0 : len(key)-4]
-	} else {
-		base = key
+// and foo.key). Those two files are reloaded when they change, see
+// CertManager.
+//
+// The options enable additional checks, see WithSPIFFEIDs.
+func LoadTLSConfig(caFile, key, peerName string, options ...TLSOption) (*tls.Config, error) {
+	var o tlsOptions
+	for _, option := range options {
+		if err := option(&o); err != nil {
+			return nil, err
+		}
 	}
-	crtFile := base + ".crt"
-	keyFile := base + ".key"
-	certificate, err := tls.LoadX509KeyPair(crtFile, keyFile)
+
+	certManager, err := NewCertManager(key, DefaultCertCheckInterval)
 	if err != nil {
-		return nil, errors.Wrapf(err, "load X509 key pair for key=%q", key)
+		return nil, err
 	}
 
 	certPool := x509.NewCertPool()
@@ -111,25 +117,61 @@ func LoadTLSConfig(caFile, key, peerName string) (*tls.Config, error) {
 				len(verifiedChains[0]) == 0 {
 				return errors.New("no valid certificate")
 			}
-			commonName := verifiedChains[0][0].Subject.CommonName
-			if commonName != peerName {
-				return errors.Errorf("expected CN %q, got %q", peerName, commonName)
+			name := PeerName(verifiedChains[0][0])
+			if name != peerName {
+				return errors.Errorf("expected CN %q, got %q", peerName, name)
 			}
 			return nil
 		},
 
-		Certificates: []tls.Certificate{certificate},
-		RootCAs:      certPool,
-		ClientCAs:    certPool,
-		ClientAuth:   tls.RequireAndVerifyClientCert,
+		GetCertificate:       certManager.GetCertificate,
+		GetClientCertificate: certManager.GetClientCertificate,
+		RootCAs:              certPool,
+		ClientCAs:            certPool,
+		ClientAuth:           tls.RequireAndVerifyClientCert,
+	}
+	if len(o.spiffeIDs) > 0 {
+		checkName := tlsConfig.VerifyPeerCertificate
+		tlsConfig.VerifyPeerCertificate = func(rawCerts [][]byte, verifiedChains [][]*x509.Certificate) error {
+			// Only a server gets verified chains from
+			// crypto/tls, because it checks client
+			// certificates against ClientCAs. A client
+			// skips that check (see InsecureSkipVerify
+			// below) and thus must be verifying a server.
+			usage := x509.ExtKeyUsageServerAuth
+			if len(verifiedChains) > 0 {
+				usage = x509.ExtKeyUsageClientAuth
+			}
+			chains, err := verifySPIFFE(rawCerts, certPool, usage, o.spiffeIDs)
+			if err != nil {
+				return err
+			}
+			return checkName(rawCerts, chains)
+		}
+		// Server certificates are identified by their SPIFFE ID,
+		// not the host name.
+		tlsConfig.InsecureSkipVerify = true
 	}
 	return tlsConfig, nil
 }
 
+// PeerName returns the name that identifies the owner of a
+// certificate: the common name or, if that is empty, the first DNS
+// name from the subject alternative names.
+func PeerName(cert *x509.Certificate) string {
+	if cert.Subject.CommonName != "" {
+		return cert.Subject.CommonName
+	}
+	if len(cert.DNSNames) > 0 {
+		return cert.DNSNames[0]
+	}
+	return ""
+}
+
 // LoadTLS is identical to LoadTLSConfig except that it returns
 // the TransportCredentials for a gRPC client or server.
-func LoadTLS(caFile, key, peerName string) (credentials.TransportCredentials, error) {
-	tlsConfig, err := LoadTLSConfig(caFile, key, peerName)
+func LoadTLS(caFile, key, peerName string, options ...TLSOption) (credentials.TransportCredentials, error) {
+	tlsConfig, err := LoadTLSConfig(caFile, key, peerName, options...)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,26 @@
+/*
+Copyright (C) 2018 Intel Corporation.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package oimcommon
+
+import (
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPeerName(t *testing.T) {
+	assert.Equal(t, "host.host-0", PeerName(&x509.Certificate{
+		Subject:  pkix.Name{CommonName: "host.host-0"},
+		DNSNames: []string{"foo.example.com"},
+	}))
+	assert.Equal(t, "foo.example.com", PeerName(&x509.Certificate{
+		DNSNames: []string{"foo.example.com", "bar.example.com"},
+	}))
+	assert.Equal(t, "", PeerName(&x509.Certificate{}))
+}
@@ -0,0 +1,132 @@
+/*
+Copyright (C) 2018 Intel Corporation.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package oimcommon
+
+import (
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/intel/oim/pkg/log"
+	"github.com/intel/oim/pkg/log/level"
+)
+
+// DefaultLogDedupWindow is the recommended window for
+// NewDedupLogger.
+const DefaultLogDedupWindow = 10 * time.Second
+
+// NewDedupLogger wraps a logger such that identical warnings and
+// errors are only logged once per window. When the window ends, a
+// single additional line with the same message and a "repeated"
+// count summarizes what was suppressed. Messages below level.Warn and
+// fatal errors are always passed through.
+//
+// Loggers created with With share the state of their parent, so
+// the same message logged with different fields (for example,
+// request IDs) is also collapsed. A window <= 0 disables the
+// deduplication and returns the original logger.
+func NewDedupLogger(logger log.Logger, window time.Duration) log.Logger {
+	if window <= 0 {
+		return logger
+	}
+	return newDedupLogger(logger, &dedupState{
+		window:  window,
+		entries: map[string]*dedupEntry{},
+	})
+}
+
+func newDedupLogger(logger log.Logger, state *dedupState) *dedupLogger {
+	dl := &dedupLogger{
+		logger: logger,
+		state:  state,
+	}
+	dl.LoggerBase.Init(dl)
+	return dl
+}
+
+type dedupLogger struct {
+	log.LoggerBase
+	logger log.Logger
+	state  *dedupState
+}
+
+type dedupState struct {
+	mutex   sync.Mutex
+	window  time.Duration
+	entries map[string]*dedupEntry
+}
+
+// dedupEntry exists for each message that was logged during the
+// current window.
+type dedupEntry struct {
+	repeated int
+}
+
+// suppress returns true if the message was already logged in the
+// current window. Otherwise it starts a new window which calls
+// flush at the end if there were repetitions.
+func (ds *dedupState) suppress(threshold log.Threshold, msg string, flush func(repeated int)) bool {
+	if threshold < level.Warn || threshold >= level.Fatal {
+		return false
+	}
+	key := threshold.String() + "\x00" + msg
+
+	ds.mutex.Lock()
+	defer ds.mutex.Unlock()
+	if entry, ok := ds.entries[key]; ok {
+		entry.repeated++
+		return true
+	}
+	ds.entries[key] = &dedupEntry{}
+	time.AfterFunc(ds.window, func() {
+		ds.mutex.Lock()
+		entry := ds.entries[key]
+		delete(ds.entries, key)
+		ds.mutex.Unlock()
+		if entry.repeated > 0 {
+			flush(entry.repeated)
+		}
+	})
+	return false
+}
+
+func (dl *dedupLogger) Output(threshold log.Threshold, args ...interface{}) {
+	msg := fmt.Sprint(args...)
+	if dl.state.suppress(threshold, msg, func(repeated int) {
+		dl.logger.Outputw(threshold, msg, "repeated", strconv.Itoa(repeated))
+	}) {
+		return
+	}
+	dl.logger.Output(threshold, args...)
+}
+
+func (dl *dedupLogger) Outputf(threshold log.Threshold, format string, args ...interface{}) {
+	msg := fmt.Sprintf(format, args...)
+	if dl.state.suppress(threshold, msg, func(repeated int) {
+		dl.logger.Outputw(threshold, msg, "repeated", strconv.Itoa(repeated))
+	}) {
+		return
+	}
+	dl.logger.Outputf(threshold, format, args...)
+}
+
+func (dl *dedupLogger) Outputw(threshold log.Threshold, msg string, keysAndValues ...interface{}) {
+	if dl.state.suppress(threshold, msg+"\x00"+fmt.Sprint(keysAndValues...), func(repeated int) {
+		kvs := append(keysAndValues[:len(keysAndValues):len(keysAndValues)], "repeated", strconv.Itoa(repeated))
+		dl.logger.Outputw(threshold, msg, kvs...)
+	}) {
+		return
+	}
+	dl.logger.Outputw(threshold, msg, keysAndValues...)
+}
+
+// With adds fields to the underlying logger while keeping the
+// deduplication state.
+func (dl *dedupLogger) With(keysAndValues ...interface{}) log.Logger {
+	return newDedupLogger(dl.logger.With(keysAndValues...), dl.state)
+}
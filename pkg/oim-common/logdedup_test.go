@@ -0,0 +1,74 @@
+/*
+Copyright (C) 2018 Intel Corporation.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package oimcommon
+
+import (
+	"bytes"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/intel/oim/pkg/log"
+	"github.com/intel/oim/pkg/log/level"
+)
+
+// syncBuffer protects a buffer against concurrent writes from the
+// timers of the dedup logger.
+type syncBuffer struct {
+	mutex  sync.Mutex
+	buffer bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	return b.buffer.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	return b.buffer.String()
+}
+
+func TestDedupLogger(t *testing.T) {
+	var b syncBuffer
+	logger := NewDedupLogger(log.NewSimpleLogger(log.SimpleConfig{
+		Level:  level.Debug,
+		Output: &b,
+	}), 100*time.Millisecond)
+
+	for _, id := range []string{"a", "b", "c", "d", "e"} {
+		logger.Errorw("dial failed", "error", "connection refused")
+		logger.With("requestid", id).Warnf("retry %d", 1)
+		logger.Info("progress")
+	}
+	logger.Errorw("dial failed", "error", "timeout")
+	assert.Equal(t, `ERROR dial failed | error: connection refused
+WARN retry 1 | requestid: a
+INFO progress
+INFO progress
+INFO progress
+INFO progress
+INFO progress
+ERROR dial failed | error: timeout
+`, b.String(), "within window")
+
+	time.Sleep(300 * time.Millisecond)
+	output := b.String()
+	assert.Contains(t, output, "ERROR dial failed | error: connection refused repeated: 4\n", "summary")
+	assert.Contains(t, output, "WARN retry 1 | requestid: a repeated: 4\n", "summary")
+	assert.NotContains(t, output, "timeout repeated", "no summary without repetition")
+
+	// A new window starts after the summary.
+	logger.Errorw("dial failed", "error", "connection refused")
+	assert.Contains(t, b.String()[len(output):], "ERROR dial failed | error: connection refused\n", "new window")
+
+	assert.Equal(t, logger, NewDedupLogger(logger, 0), "disabled")
+}
@@ -0,0 +1,100 @@
+/*
+Copyright (C) 2018 Intel Corporation.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package oimcommon
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+// GRPCMetrics maintains the same Prometheus metrics for all OIM gRPC
+// servers and clients: number of calls by method and status code
+// and call duration by method.
+type GRPCMetrics struct {
+	serverRequests *prometheus.CounterVec
+	serverDuration *prometheus.HistogramVec
+	clientRequests *prometheus.CounterVec
+	clientDuration *prometheus.HistogramVec
+}
+
+// NewGRPCMetrics creates the metrics and registers them, together
+// with PanicsTotal and BreakerStates.
+func NewGRPCMetrics(registerer prometheus.Registerer) (*GRPCMetrics, error) {
+	requests := func(side string) *prometheus.CounterVec {
+		return prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "oim",
+			Subsystem: "grpc_" + side,
+			Name:      "requests_total",
+			Help:      "Total number of gRPC calls by method and status code.",
+		}, []string{"method", "code"})
+	}
+	duration := func(side string) *prometheus.HistogramVec {
+		return prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: "oim",
+			Subsystem: "grpc_" + side,
+			Name:      "request_duration_seconds",
+			Help:      "Duration of gRPC calls by method.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"method"})
+	}
+	m := &GRPCMetrics{
+		serverRequests: requests("server"),
+		serverDuration: duration("server"),
+		clientRequests: requests("client"),
+		clientDuration: duration("client"),
+	}
+	for _, collector := range []prometheus.Collector{m.serverRequests, m.serverDuration, m.clientRequests, m.clientDuration, PanicsTotal, BreakerStates} {
+		if err := registerer.Register(collector); err != nil {
+			return nil, err
+		}
+	}
+	return m, nil
+}
+
+func observe(requests *prometheus.CounterVec, duration *prometheus.HistogramVec, method string, start time.Time, err error) {
+	duration.WithLabelValues(method).Observe(time.Since(start).Seconds())
+	requests.WithLabelValues(method, status.Code(err).String()).Inc()
+}
+
+// UnaryServer is a gRPC interceptor which measures server calls.
+func (m *GRPCMetrics) UnaryServer(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	start := time.Now()
+	resp, err := handler(ctx, req)
+	observe(m.serverRequests, m.serverDuration, info.FullMethod, start, err)
+	return resp, err
+}
+
+// StreamServer is the same as UnaryServer for streaming calls. The
+// duration is the lifetime of the stream.
+func (m *GRPCMetrics) StreamServer(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	start := time.Now()
+	err := handler(srv, ss)
+	observe(m.serverRequests, m.serverDuration, info.FullMethod, start, err)
+	return err
+}
+
+// UnaryClient is a gRPC interceptor which measures client calls.
+func (m *GRPCMetrics) UnaryClient(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	start := time.Now()
+	err := invoker(ctx, method, req, reply, cc, opts...)
+	observe(m.clientRequests, m.clientDuration, method, start, err)
+	return err
+}
+
+// ServeMetrics serves the metrics of the default Prometheus registry
+// via HTTP under /metrics at the listen address (for example, :9100).
+// It only returns when serving fails.
+func ServeMetrics(address string) error {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", prometheus.Handler())
+	return http.ListenAndServe(address, mux)
+}
@@ -0,0 +1,78 @@
+/*
+Copyright (C) 2018 Intel Corporation.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package oimcommon
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestGRPCMetrics(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	m, err := NewGRPCMetrics(registry)
+	require.NoError(t, err)
+	_, err = NewGRPCMetrics(registry)
+	assert.Error(t, err, "registered twice")
+
+	value := func(name string, labels map[string]string) float64 {
+		families, err := registry.Gather()
+		require.NoError(t, err)
+		for _, family := range families {
+			if family.GetName() != name {
+				continue
+			}
+		metrics:
+			for _, metric := range family.GetMetric() {
+				for _, label := range metric.GetLabel() {
+					if labels[label.GetName()] != label.GetValue() {
+						continue metrics
+					}
+				}
+				switch {
+				case metric.Counter != nil:
+					return metric.GetCounter().GetValue()
+				case metric.Histogram != nil:
+					return float64(metric.GetHistogram().GetSampleCount())
+				}
+			}
+		}
+		return -1
+	}
+
+	ctx := context.Background()
+	info := &grpc.UnaryServerInfo{FullMethod: "/test/Unary"}
+	for _, code := range []codes.Code{codes.OK, codes.OK, codes.NotFound} {
+		_, err := m.UnaryServer(ctx, nil, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+			return nil, status.Error(code, "")
+		})
+		assert.Equal(t, code, status.Code(err))
+	}
+	assert.Equal(t, 2.0, value("oim_grpc_server_requests_total", map[string]string{"method": "/test/Unary", "code": "OK"}))
+	assert.Equal(t, 1.0, value("oim_grpc_server_requests_total", map[string]string{"method": "/test/Unary", "code": "NotFound"}))
+	assert.Equal(t, 3.0, value("oim_grpc_server_request_duration_seconds", map[string]string{"method": "/test/Unary"}))
+
+	streamInfo := &grpc.StreamServerInfo{FullMethod: "/test/Stream"}
+	err = m.StreamServer(nil, nil, streamInfo, func(srv interface{}, stream grpc.ServerStream) error {
+		return status.Error(codes.Canceled, "")
+	})
+	assert.Equal(t, codes.Canceled, status.Code(err))
+	assert.Equal(t, 1.0, value("oim_grpc_server_requests_total", map[string]string{"method": "/test/Stream", "code": "Canceled"}))
+
+	err = m.UnaryClient(ctx, "/test/Unary", nil, nil, nil, func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		return status.Error(codes.Unavailable, "")
+	})
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+	assert.Equal(t, 1.0, value("oim_grpc_client_requests_total", map[string]string{"method": "/test/Unary", "code": "Unavailable"}))
+	assert.Equal(t, 1.0, value("oim_grpc_client_request_duration_seconds", map[string]string{"method": "/test/Unary"}))
+}
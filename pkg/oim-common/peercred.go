@@ -0,0 +1,130 @@
+/*
+Copyright (C) 2018 Intel Corporation.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package oimcommon
+
+import (
+	"context"
+	"net"
+
+	"github.com/pkg/errors"
+	"golang.org/x/sys/unix"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/peer"
+
+	"github.com/intel/oim/pkg/log"
+)
+
+// PeerCred identifies the process on the other end of a Unix domain
+// socket, as reported by the kernel (SO_PEERCRED) when the
+// connection was established.
+type PeerCred struct {
+	UID uint32
+	GID uint32
+	PID int32
+}
+
+type peerCredKeyType struct{}
+
+var peerCredKey peerCredKeyType
+
+// WithPeerCred returns a context which carries the peer credentials.
+func WithPeerCred(ctx context.Context, cred PeerCred) context.Context {
+	return context.WithValue(ctx, peerCredKey, cred)
+}
+
+// PeerCredFromContext returns the credentials of the client which
+// invoked the current gRPC call. They are only available for calls
+// received via a Unix domain socket by a server which uses
+// PeerCredListener and PeerCredServer, like NonBlockingGRPCServer
+// does.
+func PeerCredFromContext(ctx context.Context) (PeerCred, bool) {
+	cred, ok := ctx.Value(peerCredKey).(PeerCred)
+	return cred, ok
+}
+
+// peerCredAddr is the remote address of connections accepted by
+// PeerCredListener. gRPC makes that address available via
+// peer.FromContext, also when TLS is used on top of the connection.
+type peerCredAddr struct {
+	net.Addr
+	cred PeerCred
+}
+
+type peerCredConn struct {
+	net.Conn
+	addr peerCredAddr
+}
+
+func (c *peerCredConn) RemoteAddr() net.Addr {
+	return c.addr
+}
+
+type peerCredListener struct {
+	net.Listener
+}
+
+// PeerCredListener wraps a listener such that the credentials of
+// Unix domain socket clients are retrieved for each new connection.
+// Other connections are passed through unmodified.
+func PeerCredListener(listener net.Listener) net.Listener {
+	return peerCredListener{listener}
+}
+
+func (l peerCredListener) Accept() (net.Conn, error) {
+	for {
+		conn, err := l.Listener.Accept()
+		if err != nil {
+			return conn, err
+		}
+		unixConn, ok := conn.(*net.UnixConn)
+		if !ok {
+			return conn, nil
+		}
+		cred, err := getPeerCred(unixConn)
+		if err != nil {
+			// Returning an error would stop the gRPC server,
+			// so only this connection gets rejected.
+			log.L().Errorw("rejecting Unix domain socket connection", "error", err)
+			conn.Close()
+			continue
+		}
+		return &peerCredConn{
+			Conn: conn,
+			addr: peerCredAddr{Addr: conn.RemoteAddr(), cred: cred},
+		}, nil
+	}
+}
+
+func getPeerCred(conn *net.UnixConn) (PeerCred, error) {
+	raw, err := conn.SyscallConn()
+	if err != nil {
+		return PeerCred{}, errors.Wrap(err, "get raw connection")
+	}
+	var ucred *unix.Ucred
+	var credErr error
+	if err := raw.Control(func(fd uintptr) {
+		ucred, credErr = unix.GetsockoptUcred(int(fd), unix.SOL_SOCKET, unix.SO_PEERCRED)
+	}); err != nil {
+		return PeerCred{}, errors.Wrap(err, "access socket")
+	}
+	if credErr != nil {
+		return PeerCred{}, errors.Wrap(credErr, "get SO_PEERCRED")
+	}
+	return PeerCred{UID: ucred.Uid, GID: ucred.Gid, PID: ucred.Pid}, nil
+}
+
+// PeerCredServer is a gRPC interceptor which stores the credentials
+// found by PeerCredListener in the context of the call, for use with
+// PeerCredFromContext.
+func PeerCredServer(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	if p, ok := peer.FromContext(ctx); ok {
+		if addr, ok := p.Addr.(peerCredAddr); ok {
+			ctx = WithPeerCred(ctx, addr.cred)
+		}
+	}
+	return handler(ctx, req)
+}
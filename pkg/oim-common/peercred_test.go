@@ -0,0 +1,81 @@
+/*
+Copyright (C) 2018 Intel Corporation.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package oimcommon
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+)
+
+// peerCredHealth records the peer credentials of Check calls.
+type peerCredHealth struct {
+	cred PeerCred
+	ok   bool
+}
+
+func (h *peerCredHealth) Check(ctx context.Context, req *grpc_health_v1.HealthCheckRequest) (*grpc_health_v1.HealthCheckResponse, error) {
+	h.cred, h.ok = PeerCredFromContext(ctx)
+	return &grpc_health_v1.HealthCheckResponse{Status: grpc_health_v1.HealthCheckResponse_SERVING}, nil
+}
+
+func (h *peerCredHealth) Watch(req *grpc_health_v1.HealthCheckRequest, stream grpc_health_v1.Health_WatchServer) error {
+	return status.Error(codes.Unimplemented, "")
+}
+
+func TestPeerCred(t *testing.T) {
+	ctx := context.Background()
+	tmp, err := ioutil.TempDir("", "peercred")
+	require.NoError(t, err)
+	defer os.RemoveAll(tmp)
+
+	check := func(endpoint string) *peerCredHealth {
+		health := &peerCredHealth{}
+		server := &NonBlockingGRPCServer{Endpoint: endpoint}
+		require.NoError(t, server.Start(ctx, func(s *grpc.Server) {
+			grpc_health_v1.RegisterHealthServer(s, health)
+		}))
+		defer func() {
+			server.ForceStop(ctx)
+			server.Wait(ctx)
+		}()
+		target := server.Addr().String()
+		if server.Addr().Network() == "unix" {
+			target = "unix://" + target
+		}
+		conn, err := Dial(ctx, target, WithGRPCOptions(grpc.WithBlock()))
+		require.NoError(t, err)
+		defer conn.Close()
+		_, err = grpc_health_v1.NewHealthClient(conn).Check(ctx, &grpc_health_v1.HealthCheckRequest{})
+		require.NoError(t, err)
+		return health
+	}
+
+	health := check("unix://" + filepath.Join(tmp, "server.sock"))
+	if assert.True(t, health.ok, "Unix domain socket") {
+		assert.Equal(t, PeerCred{
+			UID: uint32(os.Getuid()),
+			GID: uint32(os.Getgid()),
+			PID: int32(os.Getpid()),
+		}, health.cred)
+	}
+
+	health = check("tcp://127.0.0.1:0")
+	assert.False(t, health.ok, "TCP")
+
+	_, ok := PeerCredFromContext(ctx)
+	assert.False(t, ok, "plain context")
+}
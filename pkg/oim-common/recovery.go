@@ -0,0 +1,62 @@
+/*
+Copyright (C) 2018 Intel Corporation.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package oimcommon
+
+import (
+	"context"
+	"runtime/debug"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/intel/oim/pkg/log"
+)
+
+// PanicsTotal counts the panics caught by RecoverPanics and
+// RecoverStreamPanics, by gRPC method. Components which export
+// metrics must register it themselves.
+var PanicsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Namespace: "oim",
+	Subsystem: "grpc",
+	Name:      "panics_total",
+	Help:      "Total number of panics in gRPC handlers by method.",
+}, []string{"method"})
+
+// recoverPanic turns a panic into an Internal error. Must be called
+// via defer.
+func recoverPanic(ctx context.Context, method string, err *error) {
+	r := recover()
+	if r == nil {
+		return
+	}
+	log.FromContext(ctx).Errorw("panic in gRPC handler",
+		"method", method,
+		"panic", r,
+		"stack", string(debug.Stack()),
+	)
+	PanicsTotal.WithLabelValues(method).Inc()
+	// The panic value itself is not returned because it
+	// might contain sensitive information.
+	*err = status.Errorf(codes.Internal, "internal error in %s", method)
+}
+
+// RecoverPanics is a gRPC interceptor which catches panics in the
+// handler, so that they fail only the current call instead of the
+// entire server. It should be the outermost interceptor.
+func RecoverPanics(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
+	defer recoverPanic(ctx, info.FullMethod, &err)
+	return handler(ctx, req)
+}
+
+// RecoverStreamPanics is the same as RecoverPanics for streaming
+// calls.
+func RecoverStreamPanics(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+	defer recoverPanic(ss.Context(), info.FullMethod, &err)
+	return handler(srv, ss)
+}
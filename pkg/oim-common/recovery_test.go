@@ -0,0 +1,77 @@
+/*
+Copyright (C) 2018 Intel Corporation.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package oimcommon
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type fakeServerStream struct {
+	grpc.ServerStream
+}
+
+func (f fakeServerStream) Context() context.Context {
+	return context.Background()
+}
+
+func TestRecoverPanics(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	require.NoError(t, registry.Register(PanicsTotal))
+	panics := func(method string) float64 {
+		families, err := registry.Gather()
+		require.NoError(t, err)
+		for _, family := range families {
+			for _, metric := range family.GetMetric() {
+				for _, label := range metric.GetLabel() {
+					if label.GetName() == "method" && label.GetValue() == method {
+						return metric.GetCounter().GetValue()
+					}
+				}
+			}
+		}
+		return 0
+	}
+
+	ctx := context.Background()
+	unaryInfo := &grpc.UnaryServerInfo{FullMethod: "/test/Unary"}
+	resp, err := RecoverPanics(ctx, nil, unaryInfo, func(ctx context.Context, req interface{}) (interface{}, error) {
+		panic("boom")
+	})
+	assert.Nil(t, resp)
+	assert.Equal(t, codes.Internal, status.Code(err), "unary panic: %s", err)
+	assert.NotContains(t, err.Error(), "boom", "panic value not returned")
+	assert.Equal(t, float64(1), panics("/test/Unary"))
+
+	resp, err = RecoverPanics(ctx, nil, unaryInfo, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return "ok", status.Error(codes.NotFound, "no such thing")
+	})
+	assert.Equal(t, "ok", resp)
+	assert.Equal(t, codes.NotFound, status.Code(err), "normal error passed through")
+	assert.Equal(t, float64(1), panics("/test/Unary"))
+
+	streamInfo := &grpc.StreamServerInfo{FullMethod: "/test/Stream"}
+	err = RecoverStreamPanics(nil, fakeServerStream{}, streamInfo, func(srv interface{}, stream grpc.ServerStream) error {
+		var m map[string]string
+		m["nil map"] = "panics"
+		return nil
+	})
+	assert.Equal(t, codes.Internal, status.Code(err), "stream panic: %s", err)
+	assert.Equal(t, float64(1), panics("/test/Stream"))
+
+	err = RecoverStreamPanics(nil, fakeServerStream{}, streamInfo, func(srv interface{}, stream grpc.ServerStream) error {
+		return nil
+	})
+	assert.NoError(t, err)
+}
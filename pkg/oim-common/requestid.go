@@ -0,0 +1,79 @@
+/*
+Copyright (C) 2018 Intel Corporation.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package oimcommon
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/intel/oim/pkg/log"
+)
+
+// RequestIDMetadata is the gRPC metadata key for the request ID.
+// It correlates all calls (CSI driver -> registry -> controller ->
+// SPDK) which belong to the same operation.
+const RequestIDMetadata = "x-request-id"
+
+type requestIDKeyType struct{}
+
+var requestIDKey requestIDKeyType
+
+// WithRequestID returns a context which carries the request ID and
+// a logger which adds it to all log entries as "requestid".
+func WithRequestID(ctx context.Context, id string) context.Context {
+	ctx = context.WithValue(ctx, requestIDKey, id)
+	return log.With(ctx, "requestid", id)
+}
+
+// RequestID returns the ID stored in the context, empty if none.
+func RequestID(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey).(string)
+	return id
+}
+
+// NewRequestID returns a random ID.
+func NewRequestID() string {
+	var id [8]byte
+	if _, err := rand.Read(id[:]); err != nil {
+		// Should never happen. An ID that is not unique
+		// is better than none.
+		return "unknown"
+	}
+	return hex.EncodeToString(id[:])
+}
+
+// RequestIDServer is a gRPC interceptor which takes the request ID
+// from the incoming metadata, or generates a new one if the caller
+// did not send one, and stores it in the context of the call.
+func RequestIDServer(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	id := ""
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if ids := md.Get(RequestIDMetadata); len(ids) > 0 {
+			id = ids[0]
+		}
+	}
+	if id == "" {
+		id = NewRequestID()
+	}
+	return handler(WithRequestID(ctx, id), req)
+}
+
+// RequestIDClient is a gRPC interceptor which sends the request ID
+// from the context (if any) to the server.
+func RequestIDClient(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	if id := RequestID(ctx); id != "" {
+		md, _ := metadata.FromOutgoingContext(ctx)
+		if len(md.Get(RequestIDMetadata)) == 0 {
+			ctx = metadata.AppendToOutgoingContext(ctx, RequestIDMetadata, id)
+		}
+	}
+	return invoker(ctx, method, req, reply, cc, opts...)
+}
@@ -0,0 +1,56 @@
+/*
+Copyright (C) 2018 Intel Corporation.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package oimcommon
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestRequestID(t *testing.T) {
+	info := &grpc.UnaryServerInfo{FullMethod: "/test/Unary"}
+	serverID := func(ctx context.Context) string {
+		var id string
+		_, err := RequestIDServer(ctx, nil, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+			id = RequestID(ctx)
+			return nil, nil
+		})
+		assert.NoError(t, err)
+		return id
+	}
+	clientID := func(ctx context.Context) []string {
+		var ids []string
+		err := RequestIDClient(ctx, "/test/Unary", nil, nil, nil, func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+			md, _ := metadata.FromOutgoingContext(ctx)
+			ids = md.Get(RequestIDMetadata)
+			return nil
+		})
+		assert.NoError(t, err)
+		return ids
+	}
+
+	// Generated on ingress when missing.
+	ctx := context.Background()
+	id := serverID(ctx)
+	assert.Len(t, id, 16, "generated ID")
+	assert.NotEqual(t, id, serverID(ctx), "unique IDs")
+
+	// Taken from the incoming metadata.
+	incoming := metadata.NewIncomingContext(ctx, metadata.Pairs(RequestIDMetadata, "abc"))
+	assert.Equal(t, "abc", serverID(incoming))
+
+	// Propagated on outgoing calls, without overriding an explicit ID.
+	assert.Empty(t, clientID(ctx), "no ID")
+	withID := WithRequestID(ctx, "abc")
+	assert.Equal(t, []string{"abc"}, clientID(withID))
+	explicit := metadata.AppendToOutgoingContext(withID, RequestIDMetadata, "xyz")
+	assert.Equal(t, []string{"xyz"}, clientID(explicit))
+}
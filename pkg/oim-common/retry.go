@@ -0,0 +1,129 @@
+/*
+Copyright (C) 2018 Intel Corporation.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package oimcommon
+
+import (
+	"context"
+	"math/rand"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/intel/oim/pkg/log"
+)
+
+const (
+	// DefaultRetryMinBackoff is the default delay before the
+	// first retry of a failed call.
+	DefaultRetryMinBackoff = 100 * time.Millisecond
+
+	// DefaultRetryMaxBackoff is the default upper limit for the
+	// delay between retries.
+	DefaultRetryMaxBackoff = 5 * time.Second
+
+	// DefaultRetryMaxAttempts is the default number of attempts
+	// for a call, including the first one.
+	DefaultRetryMaxAttempts = 5
+)
+
+type retry struct {
+	minBackoff  time.Duration
+	maxBackoff  time.Duration
+	maxAttempts int
+	sleep       func(ctx context.Context, delay time.Duration) error
+}
+
+// RetryOption configures RetryUnaryClient.
+type RetryOption func(r *retry)
+
+// WithRetryBackoff sets the initial and maximum delay between
+// attempts. The delay doubles after each failed attempt.
+func WithRetryBackoff(min, max time.Duration) RetryOption {
+	return func(r *retry) {
+		r.minBackoff = min
+		r.maxBackoff = max
+	}
+}
+
+// WithRetryMaxAttempts limits how often a call is tried.
+func WithRetryMaxAttempts(attempts int) RetryOption {
+	return func(r *retry) {
+		r.maxAttempts = attempts
+	}
+}
+
+// RetryUnaryClient returns a gRPC client interceptor which retries
+// calls that failed with Unavailable or DeadlineExceeded, for
+// example because the remote side is restarting. Between attempts
+// it waits with exponential backoff and jitter. It gives up when
+// the next attempt would start after the deadline of the call's
+// context. All other errors are returned immediately.
+//
+// Only idempotent calls may be retried like this.
+func RetryUnaryClient(options ...RetryOption) grpc.UnaryClientInterceptor {
+	r := &retry{
+		minBackoff:  DefaultRetryMinBackoff,
+		maxBackoff:  DefaultRetryMaxBackoff,
+		maxAttempts: DefaultRetryMaxAttempts,
+		sleep:       sleep,
+	}
+	for _, op := range options {
+		op(r)
+	}
+	return r.intercept
+}
+
+func (r *retry) intercept(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	backoff := r.minBackoff
+	for attempt := 1; ; attempt++ {
+		err := invoker(ctx, method, req, reply, cc, opts...)
+		if err == nil || !retryable(err) || attempt >= r.maxAttempts || ctx.Err() != nil {
+			return err
+		}
+		// Random delay in the range [backoff/2, backoff).
+		delay := backoff/2 + time.Duration(rand.Int63n(int64(backoff/2)+1))
+		if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < delay {
+			return err
+		}
+		log.FromContext(ctx).Infow("retrying gRPC call",
+			"method", method,
+			"attempt", attempt,
+			"delay", delay,
+			"error", err,
+		)
+		if r.sleep(ctx, delay) != nil {
+			return err
+		}
+		backoff *= 2
+		if backoff > r.maxBackoff {
+			backoff = r.maxBackoff
+		}
+	}
+}
+
+// retryable is true for errors which indicate that the remote side
+// was temporarily unreachable or too slow.
+func retryable(err error) bool {
+	switch status.Code(err) {
+	case codes.Unavailable, codes.DeadlineExceeded:
+		return true
+	}
+	return false
+}
+
+func sleep(ctx context.Context, delay time.Duration) error {
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
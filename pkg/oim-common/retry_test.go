@@ -0,0 +1,146 @@
+/*
+Copyright (C) 2018 Intel Corporation.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package oimcommon
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestRetry(t *testing.T) {
+	for i, tc := range []struct {
+		results  []codes.Code
+		attempts int
+		code     codes.Code
+	}{
+		{[]codes.Code{codes.OK}, 1, codes.OK},
+		{[]codes.Code{codes.Unavailable, codes.DeadlineExceeded, codes.OK}, 3, codes.OK},
+		{[]codes.Code{codes.NotFound, codes.OK}, 1, codes.NotFound},
+		{[]codes.Code{codes.Unavailable, codes.InvalidArgument, codes.OK}, 2, codes.InvalidArgument},
+		{[]codes.Code{codes.Unavailable, codes.Unavailable, codes.Unavailable, codes.Unavailable, codes.Unavailable}, 4, codes.Unavailable},
+	} {
+		var delays []time.Duration
+		r := &retry{
+			minBackoff:  time.Second,
+			maxBackoff:  3 * time.Second,
+			maxAttempts: 4,
+			sleep: func(ctx context.Context, delay time.Duration) error {
+				delays = append(delays, delay)
+				return nil
+			},
+		}
+		attempts := 0
+		invoker := func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+			code := tc.results[attempts]
+			attempts++
+			if code == codes.OK {
+				return nil
+			}
+			return status.Error(code, "failed")
+		}
+		err := r.intercept(context.Background(), "/test", nil, nil, nil, invoker)
+		assert.Equal(t, tc.code, status.Code(err), "%d: result", i)
+		assert.Equal(t, tc.attempts, attempts, "%d: attempts", i)
+
+		// Exponential backoff with jitter, limited by the maximum.
+		assert.Len(t, delays, tc.attempts-1, "%d: delays", i)
+		backoff := r.minBackoff
+		for e, delay := range delays {
+			assert.True(t, delay >= backoff/2 && delay <= backoff, "%d: delay #%d %s not in [%s, %s]", i, e, delay, backoff/2, backoff)
+			backoff *= 2
+			if backoff > r.maxBackoff {
+				backoff = r.maxBackoff
+			}
+		}
+	}
+
+	// No retry when the next attempt would start after the
+	// deadline or when the context gets canceled.
+	r := RetryUnaryClient(WithRetryBackoff(time.Second, time.Second))
+	attempts := 0
+	unavailable := func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		attempts++
+		return status.Error(codes.Unavailable, "down")
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	err := r(ctx, "/test", nil, nil, nil, unavailable)
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+	assert.Equal(t, 1, attempts, "attempts with deadline")
+	assert.True(t, time.Since(start) < 100*time.Millisecond, "returned before deadline")
+
+	attempts = 0
+	ctx, cancel = context.WithCancel(context.Background())
+	time.AfterFunc(100*time.Millisecond, cancel)
+	err = r(ctx, "/test", nil, nil, nil, unavailable)
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+	assert.Equal(t, 1, attempts, "attempts with canceled context")
+}
+
+func TestChainUnaryClient(t *testing.T) {
+	var calls []string
+	interceptor := func(name string) grpc.UnaryClientInterceptor {
+		return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+			calls = append(calls, name)
+			return invoker(ctx, method, req, reply, cc, opts...)
+		}
+	}
+	invoker := func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		calls = append(calls, "invoker")
+		return nil
+	}
+	err := ChainUnaryClient(interceptor("a"), interceptor("b"))(context.Background(), "/test", nil, nil, nil, invoker)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"a", "b", "invoker"}, calls)
+}
+
+func TestRetryWithCircuitBreaker(t *testing.T) {
+	// The breaker must be outside of the retry loop, otherwise
+	// a single call would already trip it.
+	now := time.Now()
+	cb := &CircuitBreaker{
+		Threshold: 2,
+		now:       func() time.Time { return now },
+	}
+	r := &retry{
+		minBackoff:  time.Millisecond,
+		maxBackoff:  time.Millisecond,
+		maxAttempts: DefaultBreakerThreshold,
+		sleep: func(ctx context.Context, delay time.Duration) error {
+			return nil
+		},
+	}
+	interceptor := ChainUnaryClient(cb.UnaryClient, r.intercept)
+	attempts := 0
+	unavailable := func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		attempts++
+		return status.Error(codes.Unavailable, "down")
+	}
+
+	err := interceptor(context.Background(), "/test", nil, nil, nil, unavailable)
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+	assert.Equal(t, r.maxAttempts, attempts, "attempts of first call")
+	assert.Equal(t, BreakerClosed, cb.State(), "after first call")
+	assert.Equal(t, 1, cb.consecutiveFailures(), "failures after first call")
+
+	err = interceptor(context.Background(), "/test", nil, nil, nil, unavailable)
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+	assert.Equal(t, BreakerOpen, cb.State(), "after second call")
+
+	// An open breaker fails the call without retrying.
+	attempts = 0
+	err = interceptor(context.Background(), "/test", nil, nil, nil, unavailable)
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+	assert.Equal(t, 0, attempts, "attempts while open")
+}
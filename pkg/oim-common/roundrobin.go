@@ -0,0 +1,72 @@
+/*
+Copyright (C) 2018 Intel Corporation.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package oimcommon
+
+import (
+	"net"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/balancer/roundrobin"
+	"google.golang.org/grpc/resolver"
+)
+
+// RoundRobinScheme is the gRPC target scheme for a static list of
+// endpoints, see RoundRobinTarget.
+const RoundRobinScheme = "oim-roundrobin"
+
+func init() {
+	resolver.Register(roundRobinBuilder{})
+}
+
+// RoundRobinTarget turns a comma-separated list of endpoints into a
+// gRPC target which resolves to all of them. Each endpoint may be in
+// the format defined for ParseEndpoint or <host>:<port>.
+func RoundRobinTarget(endpoints string) string {
+	return RoundRobinScheme + ":///" + endpoints
+}
+
+// roundRobinBuilder implements the resolver for RoundRobinScheme.
+// The list of addresses is static, so the resolver has nothing to do
+// after passing it to gRPC.
+type roundRobinBuilder struct{}
+
+func (roundRobinBuilder) Build(target resolver.Target, cc resolver.ClientConn, opts resolver.BuildOption) (resolver.Resolver, error) {
+	var addresses []resolver.Address
+	for _, endpoint := range strings.Split(target.Endpoint, ",") {
+		if endpoint != "" {
+			addresses = append(addresses, resolver.Address{Addr: endpoint})
+		}
+	}
+	if len(addresses) == 0 {
+		return nil, errors.Errorf("no endpoints in %q", target.Endpoint)
+	}
+	cc.NewAddress(addresses)
+	return roundRobinBuilder{}, nil
+}
+
+func (roundRobinBuilder) Scheme() string {
+	return RoundRobinScheme
+}
+
+func (roundRobinBuilder) ResolveNow(resolver.ResolveNowOption) {}
+
+func (roundRobinBuilder) Close() {}
+
+// roundRobinBalancer is the name of the gRPC balancer which sends
+// each call to the next endpoint with a working connection.
+const roundRobinBalancer = roundrobin.Name
+
+// roundRobinDialer connects to the individual addresses produced by
+// the resolver.
+func roundRobinDialer(address string, timeout time.Duration) (net.Conn, error) {
+	if _, _, err := ParseEndpoint(address); err == nil {
+		return GRPCDialer(address, timeout)
+	}
+	return net.DialTimeout("tcp", address, timeout)
+}
@@ -0,0 +1,113 @@
+/*
+Copyright (C) 2018 Intel Corporation.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package oimcommon
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+)
+
+// countingHealth counts Check calls.
+type countingHealth struct {
+	calls int64
+}
+
+func (h *countingHealth) Check(ctx context.Context, req *grpc_health_v1.HealthCheckRequest) (*grpc_health_v1.HealthCheckResponse, error) {
+	atomic.AddInt64(&h.calls, 1)
+	return &grpc_health_v1.HealthCheckResponse{Status: grpc_health_v1.HealthCheckResponse_SERVING}, nil
+}
+
+func (h *countingHealth) Watch(req *grpc_health_v1.HealthCheckRequest, stream grpc_health_v1.Health_WatchServer) error {
+	return status.Error(codes.Unimplemented, "")
+}
+
+func (h *countingHealth) count() int64 {
+	return atomic.LoadInt64(&h.calls)
+}
+
+func TestRoundRobin(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	tmp, err := ioutil.TempDir("", "roundrobin")
+	require.NoError(t, err)
+	defer os.RemoveAll(tmp)
+
+	var servers []*NonBlockingGRPCServer
+	var healths []*countingHealth
+	start := func(endpoint string) string {
+		health := &countingHealth{}
+		server := &NonBlockingGRPCServer{Endpoint: endpoint}
+		require.NoError(t, server.Start(ctx, func(s *grpc.Server) {
+			grpc_health_v1.RegisterHealthServer(s, health)
+		}))
+		servers = append(servers, server)
+		healths = append(healths, health)
+		return server.Addr().String()
+	}
+	defer func() {
+		for _, server := range servers {
+			server.ForceStop(ctx)
+			server.Wait(ctx)
+		}
+	}()
+	endpoints := "unix://" + filepath.Join(tmp, "a.sock") + "," +
+		"unix://" + filepath.Join(tmp, "b.sock") + "," +
+		start("tcp://127.0.0.1:0")
+	start("unix://" + filepath.Join(tmp, "a.sock"))
+	start("unix://" + filepath.Join(tmp, "b.sock"))
+
+	conn, err := Dial(ctx, endpoints, WithRoundRobin(), WithGRPCOptions(grpc.WithBlock()))
+	require.NoError(t, err)
+	defer conn.Close()
+	client := grpc_health_v1.NewHealthClient(conn)
+	check := func() error {
+		_, err := client.Check(ctx, &grpc_health_v1.HealthCheckRequest{})
+		return err
+	}
+
+	// Connections get established in the background, eventually
+	// all servers are used.
+	for healths[0].count() == 0 || healths[1].count() == 0 || healths[2].count() == 0 {
+		require.NoError(t, check())
+		require.NoError(t, ctx.Err(), "not all endpoints used: %d %d %d", healths[0].count(), healths[1].count(), healths[2].count())
+	}
+
+	// A broken endpoint is skipped. Calls which were already
+	// sent to it may fail.
+	servers[0].ForceStop(ctx)
+	for failed := 0; ; {
+		if err := check(); err != nil {
+			failed++
+			require.True(t, failed < 10, "too many failures: %s", err)
+			continue
+		}
+		break
+	}
+	stopped := healths[0].count()
+	before := healths[1].count() + healths[2].count()
+	for i := 0; i < 10; i++ {
+		assert.NoError(t, check())
+	}
+	assert.Equal(t, stopped, healths[0].count(), "stopped server")
+	assert.Equal(t, before+10, healths[1].count()+healths[2].count(), "remaining servers")
+	assert.NotEqual(t, 0, healths[1].count(), "second server")
+
+	_, err = Dial(ctx, ",", WithRoundRobin())
+	assert.Error(t, err, "no endpoints")
+}
@@ -43,8 +43,19 @@ func ParseEndpoint(ep string) (string, string, error) {
 type NonBlockingGRPCServer struct {
 	Endpoint      string
 	ServerOptions []grpc.ServerOption
-	wg            sync.WaitGroup
-	server        *grpc.Server
+	// UnaryInterceptors are invoked in the order in which they
+	// are listed, after the builtin panic recovery, request ID,
+	// peer credentials and logging interceptors. Streaming calls only get panic recovery
+	// and metrics.
+	UnaryInterceptors []grpc.UnaryServerInterceptor
+	// Metrics, if set, gets updated for all calls.
+	Metrics *GRPCMetrics
+	// MaxMsgSize limits the size of messages that are sent or
+	// received, DefaultMaxMsgSize if zero.
+	MaxMsgSize int
+
+	wg     sync.WaitGroup
+	server *grpc.Server
 
 	addr net.Addr
 }
@@ -60,10 +71,13 @@ func (s *NonBlockingGRPCServer) Start(ctx context.Context, services ...RegisterS
 		return errors.Wrap(err, "parse endpoint")
 	}
 
+	// ParseEndpoint accepts the scheme in any case.
+	proto = strings.ToLower(proto)
 	if proto == "unix" {
+		// unix://csi/csi.sock is treated like unix:///csi/csi.sock.
 		addr = "/" + addr
-		if err := os.Remove(addr); err != nil && !os.IsNotExist(err) {
-			return errors.Wrap(err, "remove Unix socket")
+		if err := removeStaleSocket(addr); err != nil {
+			return err
 		}
 	}
 
@@ -71,6 +85,16 @@ func (s *NonBlockingGRPCServer) Start(ctx context.Context, services ...RegisterS
 	if err != nil {
 		return err
 	}
+	if proto == "unix" {
+		// Only the owner (usually root) and its group may
+		// connect, regardless of the umask.
+		if err := os.Chmod(addr, UnixSocketMode); err != nil {
+			listener.Close()
+			return errors.Wrap(err, "set Unix socket permissions")
+		}
+		// Enables PeerCredFromContext in handlers.
+		listener = PeerCredListener(listener)
+	}
 	s.addr = listener.Addr()
 
 	logger := log.FromContext(ctx)
@@ -81,9 +105,24 @@ func (s *NonBlockingGRPCServer) Start(ctx context.Context, services ...RegisterS
 	// 		opentracing.GlobalTracer(),
 	// 		otgrpc.SpanDecorator(TraceGRPCPayload(formatter))),
 	// 	LogGRPCServer(logger, formatter))
-	interceptor := LogGRPCServer(logger, formatter)
+	interceptors := []grpc.UnaryServerInterceptor{RecoverPanics}
+	streamInterceptors := []grpc.StreamServerInterceptor{RecoverStreamPanics}
+	if s.Metrics != nil {
+		interceptors = append(interceptors, s.Metrics.UnaryServer)
+		streamInterceptors = append(streamInterceptors, s.Metrics.StreamServer)
+	}
+	interceptors = append(interceptors, RequestIDServer, PeerCredServer, LogGRPCServer(logger, formatter))
+	interceptors = append(interceptors, s.UnaryInterceptors...)
+	maxMsgSize := s.MaxMsgSize
+	if maxMsgSize == 0 {
+		maxMsgSize = DefaultMaxMsgSize
+	}
 	opts := []grpc.ServerOption{
-		grpc.UnaryInterceptor(interceptor),
+		grpc.UnaryInterceptor(ChainUnaryServer(interceptors...)),
+		grpc.StreamInterceptor(ChainStreamServer(streamInterceptors...)),
+		grpc.KeepaliveEnforcementPolicy(KeepaliveEnforcementPolicy),
+		grpc.MaxRecvMsgSize(maxMsgSize),
+		grpc.MaxSendMsgSize(maxMsgSize),
 	}
 	opts = append(opts, s.ServerOptions...)
 	server := grpc.NewServer(opts...)
@@ -105,6 +144,36 @@ func (s *NonBlockingGRPCServer) Start(ctx context.Context, services ...RegisterS
 	return nil
 }
 
+// UnixSocketMode are the permissions of Unix domain sockets created
+// by NonBlockingGRPCServer.
+const UnixSocketMode os.FileMode = 0660
+
+// removeStaleSocket removes a Unix domain socket that was left behind
+// by a previous instance, for example after a crash, because
+// otherwise listening fails with "address already in use". A socket
+// on which some other process still accepts connections is not
+// removed and neither is anything that is not a socket.
+func removeStaleSocket(path string) error {
+	info, err := os.Lstat(path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return errors.Wrap(err, "check Unix socket")
+	}
+	if info.Mode()&os.ModeSocket == 0 {
+		return errors.Errorf("%s exists and is not a Unix domain socket", path)
+	}
+	if conn, err := net.Dial("unix", path); err == nil {
+		conn.Close()
+		return errors.Errorf("Unix domain socket %s is still in use", path)
+	}
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return errors.Wrap(err, "remove stale Unix socket")
+	}
+	return nil
+}
+
 // Addr returns the address on which the server is listening, nil if none.
 // Can be used to find the actual port when using tcp://:0 as endpoint.
 func (s *NonBlockingGRPCServer) Addr() net.Addr {
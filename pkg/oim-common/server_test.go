@@ -7,9 +7,16 @@ SPDX-License-Identifier: Apache-2.0
 package oimcommon
 
 import (
+	"context"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
 )
 
 func TestParseEndpoint(t *testing.T) {
@@ -64,3 +71,61 @@ func TestParseEndpoint(t *testing.T) {
 	_, _, err = ParseEndpoint("")
 	assert.NotNil(t, err)
 }
+
+func TestServerEndpoint(t *testing.T) {
+	ctx := context.Background()
+	tmp, err := ioutil.TempDir("", "server")
+	require.NoError(t, err)
+	defer os.RemoveAll(tmp)
+
+	connect := func(endpoint string) {
+		conn, err := grpc.Dial(endpoint, ChooseDialOpts(endpoint, grpc.WithBlock(), grpc.WithInsecure())...)
+		if assert.NoError(t, err, "connect to %s", endpoint) {
+			conn.Close()
+		}
+	}
+
+	// TCP with automatically chosen port.
+	server := &NonBlockingGRPCServer{Endpoint: "tcp://127.0.0.1:0"}
+	require.NoError(t, server.Start(ctx))
+	connect(server.Addr().String())
+	server.ForceStop(ctx)
+	server.Wait(ctx)
+
+	// Unix domain socket, scheme in upper case.
+	path := filepath.Join(tmp, "server.sock")
+	server = &NonBlockingGRPCServer{Endpoint: "UNIX://" + path}
+	require.NoError(t, server.Start(ctx))
+	info, err := os.Stat(path)
+	if assert.NoError(t, err) {
+		assert.Equal(t, UnixSocketMode, info.Mode().Perm(), "socket permissions")
+	}
+	connect("unix://" + path)
+
+	// The socket is in use.
+	second := &NonBlockingGRPCServer{Endpoint: "unix://" + path}
+	assert.Error(t, second.Start(ctx), "socket in use")
+	server.ForceStop(ctx)
+	server.Wait(ctx)
+
+	// A stale socket gets replaced.
+	listener, err := net.Listen("unix", path)
+	require.NoError(t, err)
+	listener.(*net.UnixListener).SetUnlinkOnClose(false)
+	listener.Close()
+	_, err = os.Stat(path)
+	require.NoError(t, err, "stale socket")
+	server = &NonBlockingGRPCServer{Endpoint: "unix://" + path}
+	require.NoError(t, server.Start(ctx), "replace stale socket")
+	connect("unix://" + path)
+	server.ForceStop(ctx)
+	server.Wait(ctx)
+
+	// Other files are left alone.
+	file := filepath.Join(tmp, "file")
+	require.NoError(t, ioutil.WriteFile(file, nil, 0644))
+	server = &NonBlockingGRPCServer{Endpoint: "unix://" + file}
+	assert.Error(t, server.Start(ctx), "regular file")
+	_, err = os.Stat(file)
+	assert.NoError(t, err, "regular file still exists")
+}
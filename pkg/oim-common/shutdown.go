@@ -0,0 +1,156 @@
+/*
+Copyright (C) 2018 Intel Corporation.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package oimcommon
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/intel/oim/pkg/log"
+)
+
+// DefaultShutdownTimeout is how long RunServer waits for pending
+// calls, background workers and cleanup functions after being asked
+// to shut down.
+const DefaultShutdownTimeout = 30 * time.Second
+
+type runOptions struct {
+	signals  []os.Signal
+	timeout  time.Duration
+	workers  []func(ctx context.Context)
+	cleanups []func(ctx context.Context) error
+}
+
+// RunOption is the parameter type for the optional arguments of
+// RunServer.
+type RunOption func(*runOptions)
+
+// WithSignals replaces the default signals (SIGINT, SIGTERM) which
+// trigger a shutdown.
+func WithSignals(signals ...os.Signal) RunOption {
+	return func(o *runOptions) {
+		o.signals = signals
+	}
+}
+
+// WithShutdownTimeout replaces DefaultShutdownTimeout.
+func WithShutdownTimeout(timeout time.Duration) RunOption {
+	return func(o *runOptions) {
+		o.timeout = timeout
+	}
+}
+
+// WithWorker runs the function in a goroutine while the server is
+// running. The context gets canceled when shutting down and the
+// worker must return soon after that.
+func WithWorker(worker func(ctx context.Context)) RunOption {
+	return func(o *runOptions) {
+		o.workers = append(o.workers, worker)
+	}
+}
+
+// WithCleanup registers a function that gets called after the server
+// and all workers have stopped. Cleanup functions are called in the
+// reverse order of registration with a context that expires at the
+// end of the shutdown timeout. Errors are logged.
+func WithCleanup(cleanup func(ctx context.Context) error) RunOption {
+	return func(o *runOptions) {
+		o.cleanups = append(o.cleanups, cleanup)
+	}
+}
+
+// RunServer starts the server and blocks until the context is
+// canceled, a signal is received or the server fails. Then it shuts
+// everything down in a consistent way:
+// - cancels the context of the workers,
+// - stops the server, allowing pending calls to complete,
+// - waits for the workers,
+// - calls the cleanup functions.
+//
+// The first two steps happen in parallel. Anything still running
+// after the shutdown timeout is abandoned, except for the server,
+// which gets stopped forcefully.
+func RunServer(ctx context.Context, server *NonBlockingGRPCServer, services []RegisterService, options ...RunOption) error {
+	o := runOptions{
+		signals: []os.Signal{syscall.SIGINT, syscall.SIGTERM},
+		timeout: DefaultShutdownTimeout,
+	}
+	for _, option := range options {
+		option(&o)
+	}
+	logger := log.FromContext(ctx)
+
+	// Registered before starting, so that there is no window
+	// where a signal kills the process.
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, o.signals...)
+	defer signal.Stop(signals)
+
+	if err := server.Start(ctx, services...); err != nil {
+		return err
+	}
+	serverDone := make(chan struct{})
+	go func() {
+		server.Wait(ctx)
+		close(serverDone)
+	}()
+
+	workerCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	var workers sync.WaitGroup
+	for _, worker := range o.workers {
+		workers.Add(1)
+		go func(worker func(ctx context.Context)) {
+			defer workers.Done()
+			worker(workerCtx)
+		}(worker)
+	}
+
+	select {
+	case sig := <-signals:
+		logger.Infow("shutting down", "signal", sig)
+	case <-ctx.Done():
+		logger.Infow("shutting down", "reason", ctx.Err())
+	case <-serverDone:
+		logger.Infow("shutting down", "reason", "server stopped")
+	}
+
+	// Runs the cleanups without the canceled parent context.
+	shutdownCtx, shutdownCancel := context.WithTimeout(log.WithLogger(context.Background(), logger), o.timeout)
+	defer shutdownCancel()
+	cancel()
+	go server.Stop(ctx)
+	select {
+	case <-serverDone:
+	case <-shutdownCtx.Done():
+		logger.Warnw("forcing server shutdown", "timeout", o.timeout)
+		server.ForceStop(ctx)
+		<-serverDone
+	}
+
+	workersDone := make(chan struct{})
+	go func() {
+		workers.Wait()
+		close(workersDone)
+	}()
+	select {
+	case <-workersDone:
+	case <-shutdownCtx.Done():
+		logger.Warnw("background workers did not stop in time", "timeout", o.timeout)
+	}
+
+	for i := len(o.cleanups) - 1; i >= 0; i-- {
+		if err := o.cleanups[i](shutdownCtx); err != nil {
+			logger.Errorw("cleanup during shutdown", "error", err)
+		}
+	}
+	return nil
+}
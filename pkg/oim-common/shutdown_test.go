@@ -0,0 +1,66 @@
+/*
+Copyright (C) 2018 Intel Corporation.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package oimcommon
+
+import (
+	"context"
+	"errors"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRunServer(t *testing.T) {
+	run := func(ctx context.Context, options ...RunOption) ([]string, error) {
+		var events []string
+		server := &NonBlockingGRPCServer{Endpoint: "tcp://127.0.0.1:0"}
+		options = append(options,
+			WithShutdownTimeout(10*time.Second),
+			WithWorker(func(ctx context.Context) {
+				<-ctx.Done()
+				// Delay to check that RunServer waits.
+				time.Sleep(10 * time.Millisecond)
+				events = append(events, "worker stopped")
+			}),
+			WithCleanup(func(ctx context.Context) error {
+				_, ok := ctx.Deadline()
+				assert.True(t, ok, "cleanup deadline")
+				assert.NoError(t, ctx.Err(), "cleanup context")
+				events = append(events, "second cleanup")
+				return errors.New("ignored")
+			}),
+			WithCleanup(func(ctx context.Context) error {
+				events = append(events, "first cleanup")
+				return nil
+			}),
+		)
+		err := RunServer(ctx, server, nil, options...)
+		assert.Nil(t, server.Addr(), "server stopped")
+		return events, err
+	}
+	expected := []string{"worker stopped", "first cleanup", "second cleanup"}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	events, err := run(ctx)
+	assert.NoError(t, err, "canceled")
+	assert.Equal(t, expected, events, "canceled")
+
+	go func() {
+		time.Sleep(100 * time.Millisecond)
+		syscall.Kill(syscall.Getpid(), syscall.SIGUSR1) // nolint: errcheck
+	}()
+	events, err = run(context.Background(), WithSignals(syscall.SIGUSR1))
+	assert.NoError(t, err, "signal")
+	assert.Equal(t, expected, events, "signal")
+
+	// Startup errors are returned immediately.
+	err = RunServer(context.Background(), &NonBlockingGRPCServer{Endpoint: "no-such-endpoint"}, nil)
+	assert.Error(t, err, "invalid endpoint")
+}
@@ -0,0 +1,159 @@
+/*
+Copyright (C) 2018 Intel Corporation.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package oimcommon
+
+import (
+	"context"
+	"crypto/x509"
+	"net/url"
+	"path"
+	"strings"
+
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+)
+
+// SPIFFEScheme is the URI scheme of SPIFFE IDs.
+const SPIFFEScheme = "spiffe"
+
+type tlsOptions struct {
+	spiffeIDs []string
+}
+
+// TLSOption is the parameter type for the optional arguments of
+// LoadTLSConfig and LoadTLS.
+type TLSOption func(*tlsOptions) error
+
+// WithSPIFFEIDs enables verification of SPIFFE identities (SVIDs):
+// the peer certificate must contain exactly one spiffe:// URI
+// subject alternative name and that ID must match one of the
+// patterns. A pattern is either a trust domain
+// (spiffe://example.org), which allows all IDs in that domain, or a
+// full ID which may contain wildcards as in path.Match
+// (spiffe://example.org/oim/controller/*).
+//
+// SVIDs typically have neither a common name nor DNS names, so the
+// peer name should be empty. Server certificates then get checked
+// only against the CA and the patterns.
+func WithSPIFFEIDs(patterns ...string) TLSOption {
+	return func(o *tlsOptions) error {
+		for _, pattern := range patterns {
+			u, err := url.Parse(pattern)
+			if err != nil {
+				return errors.Wrapf(err, "SPIFFE ID pattern %q", pattern)
+			}
+			if u.Scheme != SPIFFEScheme || u.Host == "" {
+				return errors.Errorf("SPIFFE ID pattern %q: must start with %s://<trust domain>", pattern, SPIFFEScheme)
+			}
+			if _, err := path.Match(pattern, ""); err != nil {
+				return errors.Wrapf(err, "SPIFFE ID pattern %q", pattern)
+			}
+		}
+		o.spiffeIDs = append(o.spiffeIDs, patterns...)
+		return nil
+	}
+}
+
+// SPIFFEID returns the SPIFFE ID stored in the certificate.
+func SPIFFEID(cert *x509.Certificate) (string, error) {
+	var ids []*url.URL
+	for _, u := range cert.URIs {
+		if strings.EqualFold(u.Scheme, SPIFFEScheme) {
+			ids = append(ids, u)
+		}
+	}
+	switch len(ids) {
+	case 0:
+		return "", errors.New("certificate has no SPIFFE ID")
+	case 1:
+		return ids[0].String(), nil
+	default:
+		return "", errors.Errorf("certificate has %d SPIFFE IDs, expected one", len(ids))
+	}
+}
+
+// matchSPIFFEID checks the ID against the patterns from WithSPIFFEIDs.
+func matchSPIFFEID(id string, patterns []string) error {
+	u, err := url.Parse(id)
+	if err != nil {
+		return errors.Wrapf(err, "SPIFFE ID %q", id)
+	}
+	for _, pattern := range patterns {
+		p, _ := url.Parse(pattern)
+		if strings.Trim(p.Path, "/") == "" {
+			// Trust domain.
+			if strings.EqualFold(u.Host, p.Host) {
+				return nil
+			}
+			continue
+		}
+		if ok, _ := path.Match(pattern, id); ok {
+			return nil
+		}
+	}
+	return errors.Errorf("SPIFFE ID %q not allowed, expected one of %q", id, patterns)
+}
+
+// verifySPIFFE checks the certificate chain against the CA pool and
+// the usage (x509.ExtKeyUsageServerAuth for a server,
+// x509.ExtKeyUsageClientAuth for a client) and the leaf certificate
+// against the patterns and returns the verified chains. The chain
+// must be verified here because the builtin verification is disabled
+// when acting as client, as it would insist on a host name.
+func verifySPIFFE(rawCerts [][]byte, roots *x509.CertPool, usage x509.ExtKeyUsage, patterns []string) ([][]*x509.Certificate, error) {
+	if len(rawCerts) == 0 {
+		return nil, errors.New("no peer certificate")
+	}
+	certs := make([]*x509.Certificate, 0, len(rawCerts))
+	for _, raw := range rawCerts {
+		cert, err := x509.ParseCertificate(raw)
+		if err != nil {
+			return nil, errors.Wrap(err, "parse peer certificate")
+		}
+		certs = append(certs, cert)
+	}
+	intermediates := x509.NewCertPool()
+	for _, cert := range certs[1:] {
+		intermediates.AddCert(cert)
+	}
+	chains, err := certs[0].Verify(x509.VerifyOptions{
+		Roots:         roots,
+		Intermediates: intermediates,
+		KeyUsages:     []x509.ExtKeyUsage{usage},
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "verify peer certificate")
+	}
+	id, err := SPIFFEID(certs[0])
+	if err != nil {
+		return nil, err
+	}
+	if err := matchSPIFFEID(id, patterns); err != nil {
+		return nil, err
+	}
+	return chains, nil
+}
+
+// PeerSPIFFEID returns the SPIFFE ID of the client or server on the
+// other end of the gRPC connection. Only meaningful when the
+// connection was established with WithSPIFFEIDs, because otherwise
+// the ID was not checked.
+func PeerSPIFFEID(ctx context.Context) (string, error) {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return "", errors.New("no peer information")
+	}
+	tlsInfo, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok {
+		return "", errors.New("no TLS info")
+	}
+	if len(tlsInfo.State.PeerCertificates) == 0 {
+		return "", errors.New("no peer certificate")
+	}
+	return SPIFFEID(tlsInfo.State.PeerCertificates[0])
+}
@@ -0,0 +1,196 @@
+/*
+Copyright (C) 2018 Intel Corporation.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package oimcommon
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"io"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+)
+
+// writeSVID creates a certificate without names except for the
+// SPIFFE ID and writes it to <base>.key and <base>.crt. The
+// certificate can be used by clients and servers unless the usages
+// are given explicitly.
+func (ca *testCA) writeSVID(t *testing.T, base string, id string, usages ...x509.ExtKeyUsage) {
+	if len(usages) == 0 {
+		usages = []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth}
+	}
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	u, err := url.Parse(id)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1000),
+		URIs:         []*url.URL{u},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  usages,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, ca.cert, &key.PublicKey, ca.key)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+	require.NoError(t, ioutil.WriteFile(base+".key", pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0600))
+	require.NoError(t, ioutil.WriteFile(base+".crt", pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0600))
+}
+
+func TestMatchSPIFFEID(t *testing.T) {
+	for _, c := range []struct {
+		id       string
+		patterns []string
+		ok       bool
+	}{
+		{"spiffe://example.org/oim/controller/host-0", []string{"spiffe://example.org"}, true},
+		{"spiffe://example.org/oim/controller/host-0", []string{"spiffe://example.org/"}, true},
+		{"spiffe://example.org/oim/controller/host-0", []string{"spiffe://example.com"}, false},
+		{"spiffe://example.org/oim/controller/host-0", []string{"spiffe://example.org/oim/controller/*"}, true},
+		{"spiffe://example.org/oim/controller/host-0", []string{"spiffe://example.org/oim/registry"}, false},
+		{"spiffe://example.org/oim/controller/host-0", []string{"spiffe://example.org/oim/registry", "spiffe://example.org/oim/controller/host-0"}, true},
+		{"spiffe://example.org/oim/controller/host-0/x", []string{"spiffe://example.org/oim/controller/*"}, false},
+	} {
+		err := matchSPIFFEID(c.id, c.patterns)
+		if c.ok {
+			assert.NoError(t, err, "%s %v", c.id, c.patterns)
+		} else {
+			assert.Error(t, err, "%s %v", c.id, c.patterns)
+		}
+	}
+
+	for _, pattern := range []string{"https://example.org", "spiffe:///oim", "spiffe://example.org/[", "%zz"} {
+		var o tlsOptions
+		assert.Error(t, WithSPIFFEIDs(pattern)(&o), pattern)
+	}
+}
+
+func TestSPIFFETLS(t *testing.T) {
+	tmp, err := ioutil.TempDir("", "spiffe")
+	require.NoError(t, err)
+	defer os.RemoveAll(tmp)
+
+	ca := newTestCA(t)
+	caFile := filepath.Join(tmp, "ca.crt")
+	require.NoError(t, ioutil.WriteFile(caFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: ca.cert.Raw}), 0600))
+	server := filepath.Join(tmp, "server")
+	ca.writeSVID(t, server, "spiffe://example.org/oim/registry")
+	client := filepath.Join(tmp, "client")
+	ca.writeSVID(t, client, "spiffe://example.org/oim/controller/host-0")
+
+	listen := func(key string) net.Listener {
+		serverConfig, err := LoadTLSConfig(caFile, key, "", WithSPIFFEIDs("spiffe://example.org/oim/controller/*"))
+		require.NoError(t, err)
+		listener, err := tls.Listen("tcp", "127.0.0.1:0", serverConfig)
+		require.NoError(t, err)
+		go func() {
+			for {
+				conn, err := listener.Accept()
+				if err != nil {
+					return
+				}
+				go func() {
+					defer conn.Close()
+					conn.(*tls.Conn).Handshake() // nolint: errcheck
+				}()
+			}
+		}()
+		return listener
+	}
+	listener := listen(server)
+	defer listener.Close()
+
+	handshakeWith := func(listener net.Listener, key string, options ...TLSOption) (*tls.Conn, error) {
+		config, err := LoadTLSConfig(caFile, key, "", options...)
+		require.NoError(t, err)
+		conn, err := tls.Dial("tcp", listener.Addr().String(), config)
+		if err != nil {
+			return nil, err
+		}
+		// The server's verdict about the client only shows
+		// up when reading.
+		if _, err := conn.Read(make([]byte, 1)); err != nil && err != io.EOF {
+			conn.Close()
+			return nil, err
+		}
+		return conn, nil
+	}
+	handshake := func(key string, options ...TLSOption) (*tls.Conn, error) {
+		return handshakeWith(listener, key, options...)
+	}
+
+	conn, err := handshake(client, WithSPIFFEIDs("spiffe://example.org/oim/registry"))
+	if assert.NoError(t, err, "allowed") {
+		id, err := SPIFFEID(conn.ConnectionState().PeerCertificates[0])
+		assert.NoError(t, err)
+		assert.Equal(t, "spiffe://example.org/oim/registry", id)
+		conn.Close()
+	}
+
+	_, err = handshake(client, WithSPIFFEIDs("spiffe://example.com"))
+	if assert.Error(t, err, "server not allowed") {
+		assert.Contains(t, err.Error(), `SPIFFE ID "spiffe://example.org/oim/registry" not allowed`)
+	}
+
+	other := filepath.Join(tmp, "other")
+	ca.writeSVID(t, other, "spiffe://example.org/oim/csi-driver")
+	_, err = handshake(other, WithSPIFFEIDs("spiffe://example.org"))
+	assert.Error(t, err, "client not allowed")
+
+	// A certificate signed by some other CA is rejected even
+	// though the SPIFFE ID matches.
+	untrusted := filepath.Join(tmp, "untrusted")
+	newTestCA(t).writeSVID(t, untrusted, "spiffe://example.org/oim/controller/host-1")
+	_, err = handshake(untrusted, WithSPIFFEIDs("spiffe://example.org"))
+	assert.Error(t, err, "untrusted client")
+
+	// Certificates must be meant for the role in which they are
+	// used.
+	serverOnly := filepath.Join(tmp, "server-only")
+	ca.writeSVID(t, serverOnly, "spiffe://example.org/oim/controller/host-2", x509.ExtKeyUsageServerAuth)
+	_, err = handshake(serverOnly, WithSPIFFEIDs("spiffe://example.org"))
+	assert.Error(t, err, "client with server certificate")
+	clientOnly := filepath.Join(tmp, "client-only")
+	ca.writeSVID(t, clientOnly, "spiffe://example.org/oim/registry", x509.ExtKeyUsageClientAuth)
+	clientOnlyListener := listen(clientOnly)
+	defer clientOnlyListener.Close()
+	_, err = handshakeWith(clientOnlyListener, client, WithSPIFFEIDs("spiffe://example.org"))
+	if assert.Error(t, err, "server with client certificate") {
+		assert.Contains(t, err.Error(), "verify peer certificate")
+	}
+
+	// Authorization code can get the ID of the caller.
+	cert, err := tls.LoadX509KeyPair(client+".crt", client+".key")
+	require.NoError(t, err)
+	leaf, err := x509.ParseCertificate(cert.Certificate[0])
+	require.NoError(t, err)
+	ctx := peer.NewContext(context.Background(), &peer.Peer{
+		AuthInfo: credentials.TLSInfo{State: tls.ConnectionState{PeerCertificates: []*x509.Certificate{leaf}}},
+	})
+	id, err := PeerSPIFFEID(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, "spiffe://example.org/oim/controller/host-0", id)
+	_, err = PeerSPIFFEID(context.Background())
+	assert.Error(t, err, "no peer")
+}
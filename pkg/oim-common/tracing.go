@@ -22,6 +22,7 @@ import (
 
 	"github.com/container-storage-interface/spec/lib/go/csi/v0"
 	"github.com/intel/oim/pkg/log"
+	"github.com/intel/oim/pkg/spec/oim/v0"
 	"github.com/kubernetes-csi/csi-lib-utils/protosanitizer"
 )
 
@@ -60,9 +61,30 @@ var _ = csi.CreateVolumeRequest{
 }
 
 // Sprint currently strips messages for CSI 0.3. It needs to be updated
-// when migrating to CSI 1.0.
+// when migrating to CSI 1.0. The OIM spec does not follow the CSI
+// naming convention for secrets, so OIM messages with secrets get
+// stripped explicitly.
 func (s StripSecretsFormatter) Sprint(payload interface{}) string {
-	return protosanitizer.StripSecretsCSI03(payload).String()
+	return protosanitizer.StripSecretsCSI03(stripOIMSecrets(payload)).String()
+}
+
+// strippedSecret is what protosanitizer uses instead of the secret.
+const strippedSecret = "***stripped***"
+
+// stripOIMSecrets returns a copy of the payload without secrets
+// if it contains any, otherwise the original payload.
+func stripOIMSecrets(payload interface{}) interface{} {
+	switch req := payload.(type) {
+	case *oim.MapVolumeRequest:
+		if ceph := req.GetCeph(); ceph != nil && ceph.Secret != "" {
+			stripped := *req
+			strippedCeph := *ceph
+			strippedCeph.Secret = strippedSecret
+			stripped.Params = &oim.MapVolumeRequest_Ceph{Ceph: &strippedCeph}
+			return &stripped
+		}
+	}
+	return payload
 }
 
 // NullPayloadFormatter just produces "nil" or "<filtered>".
@@ -95,6 +117,9 @@ func (d *delayedFormatter) String() string {
 // be included in those data structures. Failed method calls
 // are printed at the "Error" level.
 //
+// The request ID set by RequestIDServer, if invoked earlier, gets
+// added to all log entries.
+//
 // If this interceptor is invoked after the otgrpc.OpenTracingServerInterceptor,
 // then it will install a logger which adds log events to the span in
 // addition to passing them on to the original logger.
@@ -105,6 +130,10 @@ func LogGRPCServer(logger log.Logger, formatter PayloadFormatter) grpc.UnaryServ
 	}
 
 	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		logger := logger
+		if id := RequestID(ctx); id != "" {
+			logger = logger.With("requestid", id)
+		}
 		ctx = logGRPCPre(ctx, logger, formatter, "received", info.FullMethod, req)
 		innerCtx := ctx
 		// if sp := opentracing.SpanFromContext(ctx); sp != nil {
@@ -118,6 +147,52 @@ func LogGRPCServer(logger log.Logger, formatter PayloadFormatter) grpc.UnaryServ
 	}
 }
 
+// ChainUnaryServer combines several interceptors into one. The
+// first interceptor is the outermost one, i.e. it gets invoked first
+// and then calls the next one.
+func ChainUnaryServer(interceptors ...grpc.UnaryServerInterceptor) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		chained := handler
+		for i := len(interceptors) - 1; i >= 0; i-- {
+			interceptor, next := interceptors[i], chained
+			chained = func(ctx context.Context, req interface{}) (interface{}, error) {
+				return interceptor(ctx, req, info, next)
+			}
+		}
+		return chained(ctx, req)
+	}
+}
+
+// ChainStreamServer is the same as ChainUnaryServer for streaming
+// calls.
+func ChainStreamServer(interceptors ...grpc.StreamServerInterceptor) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		chained := handler
+		for i := len(interceptors) - 1; i >= 0; i-- {
+			interceptor, next := interceptors[i], chained
+			chained = func(srv interface{}, ss grpc.ServerStream) error {
+				return interceptor(srv, ss, info, next)
+			}
+		}
+		return chained(srv, ss)
+	}
+}
+
+// ChainUnaryClient combines several client interceptors into one,
+// with the same order as in ChainUnaryServer.
+func ChainUnaryClient(interceptors ...grpc.UnaryClientInterceptor) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		chained := invoker
+		for i := len(interceptors) - 1; i >= 0; i-- {
+			interceptor, next := interceptors[i], chained
+			chained = func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+				return interceptor(ctx, method, req, reply, cc, next, opts...)
+			}
+		}
+		return chained(ctx, method, req, reply, cc, opts...)
+	}
+}
+
 // LogGRPCClient does the same as LogGRPCServer, only on the client side.
 // There is no need for a logger because that gets passed in.
 func LogGRPCClient(formatter PayloadFormatter) grpc.UnaryClientInterceptor {
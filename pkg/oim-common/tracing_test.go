@@ -0,0 +1,73 @@
+/*
+Copyright (C) 2018 Intel Corporation.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package oimcommon
+
+import (
+	"context"
+	"testing"
+
+	"github.com/container-storage-interface/spec/lib/go/csi/v0"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+
+	"github.com/intel/oim/pkg/spec/oim/v0"
+)
+
+func TestChainUnaryServer(t *testing.T) {
+	var calls []string
+	interceptor := func(name string) grpc.UnaryServerInterceptor {
+		return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+			calls = append(calls, name)
+			return handler(ctx, req.(string)+name)
+		}
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		calls = append(calls, "handler")
+		return req, nil
+	}
+
+	chained := ChainUnaryServer(interceptor("a"), interceptor("b"))
+	resp, err := chained(context.Background(), "req-", &grpc.UnaryServerInfo{}, handler)
+	assert.NoError(t, err)
+	assert.Equal(t, "req-ab", resp)
+	assert.Equal(t, []string{"a", "b", "handler"}, calls)
+
+	calls = nil
+	resp, err = ChainUnaryServer()(context.Background(), "req-", &grpc.UnaryServerInfo{}, handler)
+	assert.NoError(t, err)
+	assert.Equal(t, "req-", resp)
+	assert.Equal(t, []string{"handler"}, calls)
+}
+
+func TestStripSecrets(t *testing.T) {
+	formatter := StripSecretsFormatter{}
+
+	createVolume := &csi.CreateVolumeRequest{
+		Name:                    "vol",
+		ControllerCreateSecrets: map[string]string{"key": "create-secret"},
+	}
+	assert.NotContains(t, formatter.Sprint(createVolume), "create-secret")
+
+	mapVolume := &oim.MapVolumeRequest{
+		VolumeId: "vol",
+		Params: &oim.MapVolumeRequest_Ceph{
+			Ceph: &oim.CephParams{
+				UserId: "admin",
+				Secret: "ceph-secret",
+			},
+		},
+	}
+	out := formatter.Sprint(mapVolume)
+	assert.NotContains(t, out, "ceph-secret")
+	assert.Contains(t, out, "admin")
+	assert.Contains(t, out, strippedSecret)
+	// The original request must not be modified.
+	assert.Equal(t, "ceph-secret", mapVolume.GetCeph().GetSecret())
+
+	mapVolume.Params = &oim.MapVolumeRequest_Malloc{Malloc: &oim.MallocParams{}}
+	assert.NotContains(t, formatter.Sprint(mapVolume), strippedSecret)
+}
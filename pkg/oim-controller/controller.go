@@ -8,11 +8,14 @@ package oimcontroller
 
 import (
 	"context"
+	"path/filepath"
+	"sort"
 	"sync"
 	"time"
 
 	"github.com/pkg/errors"
 
+	"github.com/prometheus/client_golang/prometheus"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials"
@@ -22,6 +25,7 @@ import (
 	"github.com/intel/oim/pkg/oim-common"
 	"github.com/intel/oim/pkg/spdk"
 	"github.com/intel/oim/pkg/spec/oim/v0"
+	"github.com/intel/oim/pkg/version"
 	"k8s.io/kubernetes/pkg/util/keymutex" // TODO: move to k8s.io/utils (https://github.com/kubernetes/utils/issues/62)
 )
 
@@ -30,12 +34,14 @@ type Controller struct {
 	creds           credentials.TransportCredentials
 	registryAddress string
 	registryDelay   time.Duration
+	registryTTL     time.Duration
 	controllerID    string
 	controllerAddr  string
 	spdkPath        string
 	SPDK            *spdk.Client
 	vhostSCSI       string
 	vhostDev        *oim.PCIAddress
+	metrics         *oimcommon.GRPCMetrics
 
 	wg   sync.WaitGroup
 	stop chan<- interface{}
@@ -50,21 +56,30 @@ var (
 	volumeMutex = keymutex.NewHashed(-1)
 )
 
+// MaxSCSITargets is the number of SCSI targets that MapVolume uses
+// in the VHost SCSI controller, one per volume.
+// TODO: we don't know the SPDK limit for targets. 8 is just the default.
+const MaxSCSITargets = 8
+
+// deregisterTimeout limits how long Stop waits for the registry
+// when removing the controller's address.
+const deregisterTimeout = 10 * time.Second
+
 // MapVolume ensures that there is a BDev for the volume and makes it
 // available as block device.
 func (c *Controller) MapVolume(ctx context.Context, in *oim.MapVolumeRequest) (*oim.MapVolumeReply, error) {
 	volumeID := in.GetVolumeId()
 	if volumeID == "" {
-		return nil, errors.New("empty volume ID")
+		return nil, status.Error(codes.InvalidArgument, "empty volume ID")
 	}
 	if c.SPDK == nil {
-		return nil, errors.New("not connected to SPDK")
+		return nil, status.Error(codes.FailedPrecondition, "not connected to SPDK")
 	}
 	if c.vhostSCSI == "" {
-		return nil, errors.New("no VHost SCSI controller configured")
+		return nil, status.Error(codes.FailedPrecondition, "no VHost SCSI controller configured")
 	}
 	if c.vhostDev == nil {
-		return nil, errors.New("no PCI BDF configured")
+		return nil, status.Error(codes.FailedPrecondition, "no PCI BDF configured")
 	}
 
 	// Serialize by volume.
@@ -77,15 +92,15 @@ func (c *Controller) MapVolume(ctx context.Context, in *oim.MapVolumeRequest) (*
 		// wasn't found.
 		switch x := in.Params.(type) {
 		case *oim.MapVolumeRequest_Malloc:
-			return nil, errors.Errorf("no existing MallocBDev with name %s found", volumeID)
+			return nil, status.Errorf(codes.NotFound, "no existing MallocBDev with name %s found", volumeID)
 		case *oim.MapVolumeRequest_Ceph:
 			if err := c.mapCeph(ctx, volumeID, x.Ceph); err != nil {
 				return nil, err
 			}
 		case nil:
-			return nil, errors.New("missing volume parameters")
+			return nil, status.Error(codes.InvalidArgument, "missing volume parameters")
 		default:
-			return nil, errors.Errorf("unsupported params type %T", x)
+			return nil, status.Errorf(codes.InvalidArgument, "unsupported params type %T", x)
 		}
 	} else {
 		// BDev with the intended name already exists. Assume that it is the right one.
@@ -124,11 +139,14 @@ func (c *Controller) MapVolume(ctx context.Context, in *oim.MapVolumeRequest) (*
 		}
 	}
 
+	if c.mappedTargets(controllers) >= MaxSCSITargets {
+		return nil, status.Errorf(codes.ResourceExhausted, "all %d SCSI targets of %s in use", MaxSCSITargets, c.vhostSCSI)
+	}
+
 	// Create a new SCSI target with a LUN connected to this BDev. We iterate over all available
 	// targets and attempt to use them.
-	// TODO: we don't know the SPDK limit for targets. 8 is just the default.
 	// TODO: let vhost pick an unused one (https://github.com/spdk/spdk/issues/328)
-	for target := uint32(0); target < 8; target++ {
+	for target := uint32(0); target < MaxSCSITargets; target++ {
 		args := spdk.AddVHostSCSILUNArgs{
 			Controller:    c.vhostSCSI,
 			SCSITargetNum: target,
@@ -159,10 +177,10 @@ func (c *Controller) MapVolume(ctx context.Context, in *oim.MapVolumeRequest) (*
 func (c *Controller) UnmapVolume(ctx context.Context, in *oim.UnmapVolumeRequest) (*oim.UnmapVolumeReply, error) {
 	volumeID := in.GetVolumeId()
 	if volumeID == "" {
-		return nil, errors.New("empty volume ID")
+		return nil, status.Error(codes.InvalidArgument, "empty volume ID")
 	}
 	if c.SPDK == nil {
-		return nil, errors.New("not connected to SPDK")
+		return nil, status.Error(codes.FailedPrecondition, "not connected to SPDK")
 	}
 
 	// Serialize by volume.
@@ -215,10 +233,10 @@ func (c *Controller) UnmapVolume(ctx context.Context, in *oim.UnmapVolumeRequest
 func (c *Controller) ProvisionMallocBDev(ctx context.Context, in *oim.ProvisionMallocBDevRequest) (*oim.ProvisionMallocBDevReply, error) {
 	bdevName := in.GetBdevName()
 	if bdevName == "" {
-		return nil, errors.New("empty BDev name")
+		return nil, status.Error(codes.InvalidArgument, "empty BDev name")
 	}
 	if c.SPDK == nil {
-		return nil, errors.New("not connected to SPDK")
+		return nil, status.Error(codes.FailedPrecondition, "not connected to SPDK")
 	}
 
 	// Serialize by BDev.
@@ -226,6 +244,9 @@ func (c *Controller) ProvisionMallocBDev(ctx context.Context, in *oim.ProvisionM
 	defer volumeMutex.UnlockKey(bdevName)
 
 	size := in.Size_
+	if size < 0 || size%512 != 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "BDev size %d is not a positive multiple of 512", size)
+	}
 	if size != 0 {
 		bdevs, err := spdk.GetBDevs(ctx, c.SPDK, spdk.GetBDevsArgs{Name: bdevName})
 		if err != nil || len(bdevs) != 1 {
@@ -238,7 +259,7 @@ func (c *Controller) ProvisionMallocBDev(ctx context.Context, in *oim.ProvisionM
 			}
 			// TODO: detect already existing BDev of the same name (https://github.com/spdk/spdk/issues/319)
 			if _, err := spdk.ConstructMallocBDev(ctx, c.SPDK, args); err != nil {
-				return nil, errors.Wrap(err, "ConstructMallocBDev")
+				return nil, oimcommon.MallocBDevError(err, codes.Internal, "ConstructMallocBDev")
 			}
 		} else {
 			// Check that the BDev has the right size.
@@ -259,10 +280,10 @@ func (c *Controller) ProvisionMallocBDev(ctx context.Context, in *oim.ProvisionM
 func (c *Controller) CheckMallocBDev(ctx context.Context, in *oim.CheckMallocBDevRequest) (*oim.CheckMallocBDevReply, error) {
 	bdevName := in.GetBdevName()
 	if bdevName == "" {
-		return nil, errors.New("empty BDev name")
+		return nil, status.Error(codes.InvalidArgument, "empty BDev name")
 	}
 	if c.SPDK == nil {
-		return nil, errors.New("not connected to SPDK")
+		return nil, status.Error(codes.FailedPrecondition, "not connected to SPDK")
 	}
 
 	// Serialize by BDev.
@@ -277,9 +298,75 @@ func (c *Controller) CheckMallocBDev(ctx context.Context, in *oim.CheckMallocBDe
 	return nil, status.Error(codes.NotFound, "")
 }
 
+// GetNodeInfo returns how many volumes can be mapped.
+func (c *Controller) GetNodeInfo(ctx context.Context, in *oim.GetNodeInfoRequest) (*oim.GetNodeInfoReply, error) {
+	if c.SPDK == nil {
+		return nil, status.Error(codes.FailedPrecondition, "not connected to SPDK")
+	}
+	if c.vhostSCSI == "" {
+		return nil, status.Error(codes.FailedPrecondition, "no VHost SCSI controller configured")
+	}
+
+	controllers, err := spdk.GetVHostControllers(ctx, c.SPDK)
+	if err != nil {
+		return nil, errors.Wrap(err, "GetVHostControllers")
+	}
+	return &oim.GetNodeInfoReply{
+		MaxVolumes:    MaxSCSITargets,
+		MappedVolumes: int64(c.mappedTargets(controllers)),
+	}, nil
+}
+
+// GetVersion returns information about the build of the controller.
+func (c *Controller) GetVersion(ctx context.Context, in *oim.GetVersionRequest) (*oim.GetVersionReply, error) {
+	return version.Get(), nil
+}
+
+// mappedTargets counts the SCSI targets in our VHost SCSI controller.
+func (c *Controller) mappedTargets(controllers []spdk.Controller) int {
+	count := 0
+	for _, controller := range controllers {
+		// The controller might have been configured with the
+		// path of its socket instead of just the name.
+		if controller.Controller != filepath.Base(c.vhostSCSI) {
+			continue
+		}
+		if scsi, ok := controller.BackendSpecific["scsi"].(spdk.SCSIControllerSpecific); ok {
+			count += len(scsi)
+		}
+	}
+	return count
+}
+
+// ListMallocBDevs returns all local Malloc BDevs.
+func (c *Controller) ListMallocBDevs(ctx context.Context, in *oim.ListMallocBDevsRequest) (*oim.ListMallocBDevsReply, error) {
+	if c.SPDK == nil {
+		return nil, status.Error(codes.FailedPrecondition, "not connected to SPDK")
+	}
+
+	bdevs, err := spdk.GetBDevs(ctx, c.SPDK, spdk.GetBDevsArgs{})
+	if err != nil {
+		return nil, errors.Wrap(err, "GetBDevs")
+	}
+	reply := &oim.ListMallocBDevsReply{}
+	for _, bdev := range bdevs {
+		if bdev.ProductName != "Malloc disk" {
+			continue
+		}
+		reply.Bdevs = append(reply.Bdevs, &oim.MallocBDev{
+			BdevName: bdev.Name,
+			Size_:    bdev.NumBlocks * bdev.BlockSize,
+		})
+	}
+	sort.Slice(reply.Bdevs, func(i, j int) bool {
+		return reply.Bdevs[i].BdevName < reply.Bdevs[j].BdevName
+	})
+	return reply, nil
+}
+
 func (c *Controller) mapCeph(ctx context.Context, volumeID string, cephParams *oim.CephParams) error {
 	if c.SPDK == nil {
-		return errors.New("not connected to SPDK")
+		return status.Error(codes.FailedPrecondition, "not connected to SPDK")
 	}
 	request := spdk.ConstructRBDBDevArgs{
 		BlockSize: 512,
@@ -299,6 +386,19 @@ func (c *Controller) mapCeph(ctx context.Context, volumeID string, cephParams *o
 // Option is what New accepts to reconfigure the resulting controller.
 type Option func(c *Controller) error
 
+// WithMetrics enables Prometheus metrics for gRPC calls and
+// registers them with the given registerer.
+func WithMetrics(registerer prometheus.Registerer) Option {
+	return func(c *Controller) error {
+		metrics, err := oimcommon.NewGRPCMetrics(registerer)
+		if err != nil {
+			return err
+		}
+		c.metrics = metrics
+		return nil
+	}
+}
+
 // WithRegistry sets the OIM registry end point for the optional
 // self-registrarion. It takes a gRPC dial string.
 func WithRegistry(address string) Option {
@@ -316,6 +416,17 @@ func WithRegistryDelay(delay time.Duration) Option {
 	}
 }
 
+// WithRegistryTTL enables expiration of the self-registration. The
+// controller then renews its entry with each self-registration call,
+// so the TTL must be larger than the registry delay. The registry
+// only supports whole seconds.
+func WithRegistryTTL(ttl time.Duration) Option {
+	return func(c *Controller) error {
+		c.registryTTL = ttl
+		return nil
+	}
+}
+
 // WithCreds sets the secret key and CA used by the controller for
 // mutual TLS.
 func WithCreds(creds credentials.TransportCredentials) Option {
@@ -400,6 +511,13 @@ func New(options ...Option) (*Controller, error) {
 		return nil, errors.New("need both controller ID and external controller address for registering  with the OIM registry")
 	}
 
+	if c.registryTTL < 0 || c.registryTTL%time.Second != 0 {
+		return nil, errors.Errorf("registry TTL %s must be a whole number of seconds", c.registryTTL)
+	}
+	if c.registryTTL != 0 && c.registryTTL <= c.registryDelay {
+		return nil, errors.Errorf("registry TTL %s must be larger than the registry delay %s", c.registryTTL, c.registryDelay)
+	}
+
 	if c.creds == nil {
 		return nil, errors.New("transport credentials missing")
 	}
@@ -424,19 +542,32 @@ func (c *Controller) Start() error {
 		// Register for the first time immediately.
 		again := time.After(0 * time.Second)
 		done := make(chan bool)
+		registered := false
+		pending := false
 		for {
 			select {
 			case <-stop:
+				// Abort a pending registration and wait for it,
+				// because it might still succeed.
+				cancel()
+				if pending {
+					registered = <-done || registered
+				}
+				if registered {
+					c.deregister()
+				}
 				return
-			case <-done:
+			case registered = <-done:
+				pending = false
 				// TODO (?): exponential backoff when registry is down
 				again = time.After(c.registryDelay)
 			case <-again:
 				// Run at most one call at a time by re-arming
 				// the time only after we are done.
+				renew := registered
+				pending = true
 				go func() {
-					c.register(ctx)
-					done <- true
+					done <- c.register(ctx, renew)
 				}()
 			}
 		}
@@ -445,29 +576,92 @@ func (c *Controller) Start() error {
 	return nil
 }
 
-func (c *Controller) register(ctx context.Context) {
+// register sets the controller's address in the registry, or just
+// renews the existing entry if it has a TTL and was set before.
+// It returns true if the entry was set or renewed.
+func (c *Controller) register(ctx context.Context, renew bool) bool {
 	// Dial anew, because a) when the registry is down
 	// and our address uses Unix domain sockets, dialing
 	// will fail permanently and b) we don't want to keep
 	// a permanent connection from each controller to
 	// the registry.
 	log.L().Infof("Registering OIM controller %s at address %s with OIM registry %s", c.controllerID, c.controllerAddr, c.registryAddress)
-	opts := oimcommon.ChooseDialOpts(c.registryAddress, grpc.WithTransportCredentials(c.creds))
-	conn, err := grpc.DialContext(ctx, c.registryAddress, opts...)
+	conn, err := c.dialRegistry(ctx)
 	if err != nil {
 		log.L().Infow("connecting to OIM registry", "error", err)
+		return false
 	}
 	defer conn.Close()
 	registry := oim.NewRegistryClient(conn)
-	registry.SetValue(ctx, &oim.SetValueRequest{
+	path := c.controllerID + "/" + oimcommon.RegistryAddress
+	if renew && c.registryTTL > 0 {
+		_, err := registry.Heartbeat(ctx, &oim.HeartbeatRequest{
+			Path: path,
+		})
+		if err == nil {
+			return true
+		}
+		// Probably expired, set it again.
+		log.L().Infow("renewing registration", "error", err)
+	}
+	reply, err := registry.SetValue(ctx, &oim.SetValueRequest{
 		Value: &oim.Value{
-			Path:  c.controllerID + "/" + oimcommon.RegistryAddress,
+			Path:  path,
 			Value: c.controllerAddr,
 		},
+		TtlSeconds: int64(c.registryTTL / time.Second),
 	})
+	if err != nil {
+		log.L().Infow("registering with OIM registry", "error", err)
+		return false
+	}
+	if reply.PreviousValue != "" && reply.PreviousValue != c.controllerAddr {
+		log.L().Warnw("replaced different address in OIM registry", "path", path, "previous", reply.PreviousValue)
+	}
+	return true
+}
+
+// deregister removes the controller's address from the registry.
+// Failures are only logged, the entry then expires (if it has
+// a TTL) or gets replaced when the controller starts again.
+func (c *Controller) deregister() {
+	ctx, cancel := context.WithTimeout(context.Background(), deregisterTimeout)
+	defer cancel()
+	conn, err := c.dialRegistry(ctx)
+	if err != nil {
+		log.L().Infow("connecting to OIM registry", "error", err)
+		return
+	}
+	defer conn.Close()
+	registry := oim.NewRegistryClient(conn)
+	path := c.controllerID + "/" + oimcommon.RegistryAddress
+	reply, err := registry.SetValue(ctx, &oim.SetValueRequest{
+		Value: &oim.Value{
+			Path: path,
+		},
+	})
+	switch {
+	case err != nil:
+		log.L().Infow("deregistering from OIM registry", "error", err)
+	case !reply.Existed:
+		log.L().Infow("already deregistered from OIM registry", "path", path)
+	case reply.PreviousValue != c.controllerAddr:
+		log.L().Warnw("removed different address from OIM registry", "path", path, "previous", reply.PreviousValue)
+	default:
+		log.L().Infow("deregistered from OIM registry", "path", path)
+	}
 }
 
-// Stop ends the interaction with the OIM Registry, if one was configured.
+func (c *Controller) dialRegistry(ctx context.Context) (*grpc.ClientConn, error) {
+	options := []oimcommon.DialOption{oimcommon.WithCredentials(c.creds)}
+	if c.metrics != nil {
+		options = append(options, oimcommon.WithMetrics(c.metrics))
+	}
+	return oimcommon.Dial(ctx, c.registryAddress, options...)
+}
+
+// Stop ends the interaction with the OIM Registry, if one was configured,
+// and removes the controller's address from it.
 func (c *Controller) Stop() {
 	if c.stop != nil {
 		close(c.stop)
@@ -477,7 +671,9 @@ func (c *Controller) Stop() {
 
 // Server returns a new gRPC server listening on the given endpoint.
 func (c *Controller) Server(endpoint string) (*oimcommon.NonBlockingGRPCServer, func(*grpc.Server)) {
-	return Server(endpoint, c, c.creds)
+	server, service := Server(endpoint, c, c.creds)
+	server.Metrics = c.metrics
+	return server, service
 }
 
 // Server configures an arbitrary OIM controller implementation as a gRPC server.
@@ -490,6 +686,9 @@ func Server(endpoint string, c oim.ControllerServer, creds credentials.Transport
 		ServerOptions: []grpc.ServerOption{
 			grpc.Creds(creds),
 		},
+		UnaryInterceptors: []grpc.UnaryServerInterceptor{
+			oimcommon.TranslateErrors,
+		},
 	}
 	return server, service
 }
@@ -13,7 +13,9 @@ import (
 	"os"
 	"time"
 
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/status"
 
 	"github.com/intel/oim/pkg/log"
 	"github.com/intel/oim/pkg/log/level"
@@ -66,7 +68,7 @@ var _ = Describe("OIM Controller", func() {
 			tlsConfig, err := oimcommon.LoadTLSConfig(os.ExpandEnv("${TEST_WORK}/ca/ca.crt"), os.ExpandEnv("${TEST_WORK}/ca/component.registry.key"), "")
 			Expect(err).NotTo(HaveOccurred())
 			db = oimregistry.NewMemRegistryDB()
-			registry, err = oimregistry.New(oimregistry.DB(db), oimregistry.TLS(tlsConfig))
+			registry, err = oimregistry.New(oimregistry.DB(db), oimregistry.TLS(tlsConfig), oimregistry.MinTTL(time.Second))
 			Expect(err).NotTo(HaveOccurred())
 			server, service := registry.Server("tcp4://:0")
 			registryServer = server
@@ -126,6 +128,59 @@ var _ = Describe("OIM Controller", func() {
 			Eventually(getDB, 120*time.Second).Should(Equal(map[string]string{controllerID + "/" + oimcommon.RegistryAddress: addr}))
 		})
 
+		It("should renew until stopped", func() {
+			addr := "foo://bar"
+			controllerID := "host-0"
+			c, err := oimcontroller.New(
+				oimcontroller.WithRegistry(registryAddress),
+				oimcontroller.WithCreds(controllerCreds),
+				oimcontroller.WithControllerID(controllerID),
+				oimcontroller.WithControllerAddress(addr),
+				oimcontroller.WithRegistryDelay(1*time.Second),
+				oimcontroller.WithRegistryTTL(3*time.Second),
+			)
+			Expect(err).NotTo(HaveOccurred())
+			err = c.Start()
+			Expect(err).NotTo(HaveOccurred())
+
+			Eventually(getDB, 1*time.Second).Should(Equal(map[string]string{controllerID + "/" + oimcommon.RegistryAddress: addr}))
+			Consistently(getDB, 5*time.Second).Should(Equal(map[string]string{controllerID + "/" + oimcommon.RegistryAddress: addr}))
+			c.Stop()
+			Eventually(getDB, 5*time.Second).Should(Equal(map[string]string{}))
+		})
+
+		It("should deregister when stopped", func() {
+			addr := "foo://bar"
+			controllerID := "host-0"
+			c, err := oimcontroller.New(
+				oimcontroller.WithRegistry(registryAddress),
+				oimcontroller.WithCreds(controllerCreds),
+				oimcontroller.WithControllerID(controllerID),
+				oimcontroller.WithControllerAddress(addr),
+			)
+			Expect(err).NotTo(HaveOccurred())
+			err = c.Start()
+			Expect(err).NotTo(HaveOccurred())
+
+			Eventually(getDB, 1*time.Second).Should(Equal(map[string]string{controllerID + "/" + oimcommon.RegistryAddress: addr}))
+			c.Stop()
+			Expect(getDB()).To(BeEmpty())
+		})
+
+		It("should reject invalid TTL", func() {
+			for _, ttl := range []time.Duration{500 * time.Millisecond, 1500 * time.Millisecond, 1 * time.Second, -1 * time.Second} {
+				_, err := oimcontroller.New(
+					oimcontroller.WithRegistry(registryAddress),
+					oimcontroller.WithCreds(controllerCreds),
+					oimcontroller.WithControllerID("host-0"),
+					oimcontroller.WithControllerAddress("foo://bar"),
+					oimcontroller.WithRegistryDelay(1*time.Second),
+					oimcontroller.WithRegistryTTL(ttl),
+				)
+				Expect(err).To(HaveOccurred(), "TTL %s", ttl)
+			}
+		})
+
 		It("should really stop", func() {
 			addr := "foo://bar"
 			controllerID := "host-0"
@@ -275,6 +330,68 @@ var _ = Describe("OIM Controller", func() {
 			Expect(err).NotTo(HaveOccurred())
 		})
 
+		It("should run out of memory for Malloc BDevs", func() {
+			_, err := c.ProvisionMallocBDev(context.Background(), &oim.ProvisionMallocBDevRequest{
+				BdevName: bdevName + "-huge",
+				Size_:    1024 * 1024 * 1024 * 1024,
+			})
+			Expect(status.Code(err)).To(Equal(codes.ResourceExhausted))
+
+			_, err = c.ProvisionMallocBDev(context.Background(), &oim.ProvisionMallocBDevRequest{
+				BdevName: bdevName + "-odd",
+				Size_:    1000,
+			})
+			Expect(status.Code(err)).To(Equal(codes.InvalidArgument))
+		})
+
+		It("should list Malloc BDevs", func() {
+			_, err := c.ProvisionMallocBDev(context.Background(), &bdevArgs)
+			Expect(err).NotTo(HaveOccurred())
+			bdevArgs2 := oim.ProvisionMallocBDevRequest{
+				BdevName: bdevName + "2",
+				Size_:    1 * 1024 * 1024,
+			}
+			_, err = c.ProvisionMallocBDev(context.Background(), &bdevArgs2)
+			Expect(err).NotTo(HaveOccurred())
+
+			reply, err := c.ListMallocBDevs(context.Background(), &oim.ListMallocBDevsRequest{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(reply.GetBdevs()).To(Equal([]*oim.MallocBDev{
+				{BdevName: bdevArgs.BdevName, Size_: bdevArgs.Size_},
+				{BdevName: bdevArgs2.BdevName, Size_: bdevArgs2.Size_},
+			}))
+		})
+
+		It("should run out of SCSI targets", func() {
+			ctx := context.Background()
+			for i := 0; i <= oimcontroller.MaxSCSITargets; i++ {
+				name := fmt.Sprintf("%s-%d", volumeID, i)
+				_, err := c.ProvisionMallocBDev(ctx, &oim.ProvisionMallocBDevRequest{
+					BdevName: name,
+					Size_:    1 * 1024 * 1024,
+				})
+				Expect(err).NotTo(HaveOccurred())
+				_, err = c.MapVolume(ctx, &oim.MapVolumeRequest{
+					VolumeId: name,
+					Params: &oim.MapVolumeRequest_Malloc{
+						Malloc: &oim.MallocParams{},
+					},
+				})
+				if i < oimcontroller.MaxSCSITargets {
+					Expect(err).NotTo(HaveOccurred())
+				} else {
+					Expect(status.Code(err)).To(Equal(codes.ResourceExhausted))
+				}
+			}
+
+			info, err := c.GetNodeInfo(ctx, &oim.GetNodeInfoRequest{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(info).To(Equal(&oim.GetNodeInfoReply{
+				MaxVolumes:    oimcontroller.MaxSCSITargets,
+				MappedVolumes: oimcontroller.MaxSCSITargets,
+			}))
+		})
+
 		It("should work without QEMU", func() {
 			var err error
 			ctx := context.Background()
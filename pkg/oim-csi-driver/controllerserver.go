@@ -9,6 +9,8 @@ package oimcsidriver
 import (
 	"context"
 	"fmt"
+	"sort"
+	"strconv"
 
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -17,12 +19,16 @@ import (
 
 	"google.golang.org/grpc/metadata"
 
+	"github.com/intel/oim/pkg/oim-common"
 	"github.com/intel/oim/pkg/spdk"
 	"github.com/intel/oim/pkg/spec/oim/v0"
 )
 
 const (
 	maxStorageCapacity = tib
+
+	// blockSize is used for all Malloc BDevs.
+	blockSize int64 = 512
 )
 
 func (od *oimDriver) CreateVolume(ctx context.Context, req *csi.CreateVolumeRequest) (*csi.CreateVolumeResponse, error) {
@@ -33,22 +39,91 @@ func (od *oimDriver) CreateVolume(ctx context.Context, req *csi.CreateVolumeRequ
 	if req.GetVolumeCapabilities() == nil {
 		return nil, status.Error(codes.InvalidArgument, "Volume Capabilities missing in request")
 	}
+	for _, cap := range req.GetVolumeCapabilities() {
+		if err := checkAccessMode(cap.GetAccessMode().GetMode()); err != nil {
+			return nil, err
+		}
+	}
+	if !od.satisfiesTopology(req.GetAccessibilityRequirements()) {
+		return nil, status.Errorf(codes.ResourceExhausted, "volumes can only be created on node %q", od.nodeID)
+	}
+	capacity, err := volumeSize(req.GetCapacityRange(), od.minVolumeSize)
+	if err != nil {
+		return nil, err
+	}
+	attributes, err := validateParameters(req.GetParameters())
+	if err != nil {
+		return nil, err
+	}
 
 	// Serialize operations per volume by name.
 	name := req.GetName()
 	if name == "" {
 		return nil, status.Error(codes.InvalidArgument, "empty name")
 	}
-	volumeNameMutex.LockKey(name)
-	defer volumeNameMutex.UnlockKey(name)
+	unlock, err := volumeNameMutex.lock(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
 
+	var response *csi.CreateVolumeResponse
 	if od.vhostEndpoint != "" {
-		return od.createVolumeSPDK(ctx, req)
+		response, err = od.createVolumeSPDK(ctx, req, capacity, attributes)
+	} else {
+		response, err = od.createVolumeOIM(ctx, req, capacity, attributes)
+	}
+	if err != nil {
+		return nil, err
 	}
-	return od.createVolumeOIM(ctx, req)
+	od.volumeParameters.set(name, attributes)
+	return response, nil
 }
 
-func (od *oimDriver) createVolumeSPDK(ctx context.Context, req *csi.CreateVolumeRequest) (*csi.CreateVolumeResponse, error) {
+// volumeSize determines the size of a new volume: the required size,
+// with a default of 1MiB when the size is not specified, raised to the
+// minimum size, and then rounded up to full blocks. A limit below the
+// minimum size cannot be satisfied.
+func volumeSize(capacityRange *csi.CapacityRange, minSize int64) (int64, error) {
+	required := capacityRange.GetRequiredBytes()
+	limit := capacityRange.GetLimitBytes()
+	if required < 0 || limit < 0 || limit != 0 && limit < required {
+		return 0, status.Errorf(codes.InvalidArgument, "invalid capacity range: required %d, limit %d", required, limit)
+	}
+	if required >= maxStorageCapacity {
+		return 0, status.Errorf(codes.OutOfRange, "Requested capacity %d exceeds maximum allowed %d", required, maxStorageCapacity)
+	}
+
+	capacity := required
+	if capacity == 0 {
+		capacity = mib
+	}
+	if capacity < minSize {
+		capacity = minSize
+	}
+	if limit != 0 && minSize > limit {
+		return 0, status.Errorf(codes.OutOfRange, "capacity limit %d is smaller than the minimum volume size %d", limit, minSize)
+	}
+	if limit != 0 && capacity > limit {
+		// Only the default size can be larger, the limit is
+		// still at least the required size.
+		capacity = limit
+	}
+	capacity = (capacity + blockSize - 1) / blockSize * blockSize
+	if limit != 0 && capacity > limit {
+		return 0, status.Errorf(codes.OutOfRange, "capacity limit %d is not a multiple of the block size %d", limit, blockSize)
+	}
+	return capacity, nil
+}
+
+// compatibleSize checks whether an existing volume can be used for
+// a request with the given capacity range.
+func compatibleSize(size int64, capacityRange *csi.CapacityRange) bool {
+	limit := capacityRange.GetLimitBytes()
+	return size >= capacityRange.GetRequiredBytes() && (limit == 0 || size <= limit)
+}
+
+func (od *oimDriver) createVolumeSPDK(ctx context.Context, req *csi.CreateVolumeRequest, capacity int64, attributes map[string]string) (*csi.CreateVolumeResponse, error) {
 	// Connect to SPDK.
 	client, err := spdk.New(od.vhostEndpoint)
 	if err != nil {
@@ -62,16 +137,20 @@ func (od *oimDriver) createVolumeSPDK(ctx context.Context, req *csi.CreateVolume
 	if err == nil && len(bdevs) == 1 {
 		bdev := bdevs[0]
 		// Since err is nil, it means the volume with the same name already exists
-		// need to check if the size of exisiting volume is the same as in new
+		// need to check if the size of exisiting volume is compatible with the new
 		// request
 		volSize := bdev.BlockSize * bdev.NumBlocks
-		if volSize >= int64(req.GetCapacityRange().GetRequiredBytes()) {
+		if bdev.ProductName == "Malloc disk" && compatibleSize(volSize, req.GetCapacityRange()) {
+			if !od.volumeParameters.matches(req.GetName(), attributes) {
+				return nil, status.Error(codes.AlreadyExists, fmt.Sprintf("Volume with the same name: %s but with different parameters already exist", req.GetName()))
+			}
 			// exisiting volume is compatible with new request and should be reused.
 			return &csi.CreateVolumeResponse{
 				Volume: &csi.Volume{
-					Id:            req.GetName(),
-					CapacityBytes: int64(volSize),
-					Attributes:    req.GetParameters(),
+					Id:                 req.GetName(),
+					CapacityBytes:      volSize,
+					Attributes:         attributes,
+					AccessibleTopology: od.accessibleTopology(),
 				},
 			}, nil
 		}
@@ -83,59 +162,56 @@ func (od *oimDriver) createVolumeSPDK(ctx context.Context, req *csi.CreateVolume
 		return nil, status.Error(codes.FailedPrecondition, fmt.Sprintf("Failed to get BDevs from SPDK: %s", err))
 	}
 
-	// Check for maximum available capacity
-	capacity := int64(req.GetCapacityRange().GetRequiredBytes())
-	if capacity >= maxStorageCapacity {
-		return nil, status.Errorf(codes.OutOfRange, "Requested capacity %d exceeds maximum allowed %d", capacity, maxStorageCapacity)
-	}
-
-	// If capacity is unset, round up to minimum size (1MB?).
-	if capacity == 0 {
-		capacity = mib
-	}
-
 	// Create new Malloc bdev.
 	args := spdk.ConstructMallocBDevArgs{ConstructBDevArgs: spdk.ConstructBDevArgs{
-		NumBlocks: capacity / 512,
-		BlockSize: 512,
+		NumBlocks: capacity / blockSize,
+		BlockSize: blockSize,
 		Name:      req.GetName(),
 	}}
 	_, err = spdk.ConstructMallocBDev(ctx, client, args)
 	if err != nil {
-		return nil, status.Error(codes.FailedPrecondition, fmt.Sprintf("Failed to create SPDK Malloc BDev: %s", err))
+		return nil, oimcommon.MallocBDevError(err, codes.FailedPrecondition, "Failed to create SPDK Malloc BDev")
 	}
 	return &csi.CreateVolumeResponse{
 		Volume: &csi.Volume{
 			// We use the unique name also as ID.
-			Id:            req.GetName(),
-			CapacityBytes: req.GetCapacityRange().GetRequiredBytes(),
-			Attributes:    req.GetParameters(),
+			Id:                 req.GetName(),
+			CapacityBytes:      capacity,
+			Attributes:         attributes,
+			AccessibleTopology: od.accessibleTopology(),
 		},
 	}, nil
 }
 
-func (od *oimDriver) createVolumeOIM(ctx context.Context, req *csi.CreateVolumeRequest) (*csi.CreateVolumeResponse, error) {
-	// Check for maximum available capacity
-	capacity := int64(req.GetCapacityRange().GetRequiredBytes())
-	if capacity >= maxStorageCapacity {
-		return nil, status.Errorf(codes.OutOfRange, "Requested capacity %d exceeds maximum allowed %d", capacity, maxStorageCapacity)
-	}
-
-	// If capacity is unset, round up to minimum size (1MB?).
-	if capacity == 0 {
-		capacity = mib
+func (od *oimDriver) createVolumeOIM(ctx context.Context, req *csi.CreateVolumeRequest, capacity int64, attributes map[string]string) (*csi.CreateVolumeResponse, error) {
+	// The OIM controller accepts an existing BDev only if it has
+	// exactly the same size, but the size computed for a retry may
+	// be different (different capacity range, different minimum
+	// size). Therefore an existing BDev is checked here like in
+	// createVolumeSPDK.
+	bdev, err := od.findBDevOIM(ctx, req.GetName())
+	if err != nil {
+		return nil, err
 	}
-
-	if err := od.provisionOIM(ctx, req.GetName(), capacity); err != nil {
+	if bdev != nil {
+		if !compatibleSize(bdev.GetSize_(), req.GetCapacityRange()) {
+			return nil, status.Error(codes.AlreadyExists, fmt.Sprintf("Volume with the same name: %s but with different size already exist", req.GetName()))
+		}
+		if !od.volumeParameters.matches(req.GetName(), attributes) {
+			return nil, status.Error(codes.AlreadyExists, fmt.Sprintf("Volume with the same name: %s but with different parameters already exist", req.GetName()))
+		}
+		capacity = bdev.GetSize_()
+	} else if err := od.provisionOIM(ctx, req.GetName(), capacity); err != nil {
 		return nil, err
 	}
 
 	return &csi.CreateVolumeResponse{
 		Volume: &csi.Volume{
 			// We use the unique name also as ID.
-			Id:            req.GetName(),
-			CapacityBytes: req.GetCapacityRange().GetRequiredBytes(),
-			Attributes:    req.GetParameters(),
+			Id:                 req.GetName(),
+			CapacityBytes:      capacity,
+			Attributes:         attributes,
+			AccessibleTopology: od.accessibleTopology(),
 		},
 	}, nil
 }
@@ -151,13 +227,23 @@ func (od *oimDriver) DeleteVolume(ctx context.Context, req *csi.DeleteVolumeRequ
 	if name == "" {
 		return nil, status.Error(codes.InvalidArgument, "empty volume ID")
 	}
-	volumeNameMutex.LockKey(name)
-	defer volumeNameMutex.UnlockKey(name)
+	unlock, err := volumeNameMutex.lock(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
 
+	var response *csi.DeleteVolumeResponse
 	if od.vhostEndpoint != "" {
-		return od.deleteVolumeSPDK(ctx, req)
+		response, err = od.deleteVolumeSPDK(ctx, req)
+	} else {
+		response, err = od.deleteVolumeOIM(ctx, req)
+	}
+	if err != nil {
+		return nil, err
 	}
-	return od.deleteVolumeOIM(ctx, req)
+	od.volumeParameters.remove(name)
+	return response, nil
 }
 
 func (od *oimDriver) deleteVolumeSPDK(ctx context.Context, req *csi.DeleteVolumeRequest) (*csi.DeleteVolumeResponse, error) {
@@ -172,7 +258,7 @@ func (od *oimDriver) deleteVolumeSPDK(ctx context.Context, req *csi.DeleteVolume
 	// TODO: proper detection of "bdev not found" (https://github.com/spdk/spdk/issues/319).
 	volumeID := req.VolumeId
 	if err := spdk.DeleteBDev(ctx, client, spdk.DeleteBDevArgs{Name: volumeID}); err != nil && !spdk.IsJSONError(err, spdk.ERROR_INVALID_PARAMS) {
-		return nil, status.Error(codes.FailedPrecondition, fmt.Sprintf("Failed to delete SPDK Malloc BDev %s: %s", volumeID, err))
+		return nil, oimcommon.GRPCError(err, codes.FailedPrecondition, "Failed to delete SPDK Malloc BDev %s", volumeID)
 	}
 	return &csi.DeleteVolumeResponse{}, nil
 }
@@ -185,6 +271,29 @@ func (od *oimDriver) deleteVolumeOIM(ctx context.Context, req *csi.DeleteVolumeR
 
 }
 
+// findBDevOIM returns the Malloc BDev with the given name, nil if
+// there is none.
+func (od *oimDriver) findBDevOIM(ctx context.Context, bdevName string) (*oim.MallocBDev, error) {
+	// Connect to OIM controller through OIM registry.
+	conn, err := od.DialRegistry(ctx)
+	if err != nil {
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	}
+	defer conn.Close()
+	controllerClient := oim.NewControllerClient(conn)
+	ctx = metadata.AppendToOutgoingContext(ctx, "controllerid", od.oimControllerID)
+	reply, err := controllerClient.ListMallocBDevs(ctx, &oim.ListMallocBDevsRequest{})
+	if err != nil {
+		return nil, err
+	}
+	for _, bdev := range reply.GetBdevs() {
+		if bdev.GetBdevName() == bdevName {
+			return bdev, nil
+		}
+	}
+	return nil, nil
+}
+
 func (od *oimDriver) provisionOIM(ctx context.Context, bdevName string, size int64) error {
 	// Connect to OIM controller through OIM registry.
 	conn, err := od.DialRegistry(ctx)
@@ -201,12 +310,158 @@ func (od *oimDriver) provisionOIM(ctx context.Context, bdevName string, size int
 	return err
 }
 
+// Keys in the publish info returned by ControllerPublishVolume.
+const (
+	publishInfoPCIAddress = "pciAddress"
+	publishInfoSCSITarget = "scsiTarget"
+	publishInfoSCSILUN    = "scsiLUN"
+)
+
+// controllerPublishes is true if volumes get mapped by
+// ControllerPublishVolume. That is only supported when using
+// Malloc BDevs via the OIM controller.
+func (od *oimDriver) controllerPublishes() bool {
+	return od.registry != nil && od.emulate == nil
+}
+
 func (od *oimDriver) ControllerPublishVolume(ctx context.Context, req *csi.ControllerPublishVolumeRequest) (*csi.ControllerPublishVolumeResponse, error) {
-	return nil, status.Error(codes.Unimplemented, "")
+	if !od.controllerPublishes() {
+		return nil, status.Error(codes.Unimplemented, "")
+	}
+
+	// Check arguments
+	if len(req.GetVolumeId()) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "Volume ID missing in request")
+	}
+	if len(req.GetNodeId()) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "Node ID missing in request")
+	}
+	if req.GetVolumeCapability() == nil {
+		return nil, status.Error(codes.InvalidArgument, "Volume capability missing in request")
+	}
+	if err := checkAccessMode(req.GetVolumeCapability().GetAccessMode().GetMode()); err != nil {
+		return nil, err
+	}
+	if req.GetNodeId() != od.nodeID {
+		// The volume only exists in the SPDK instance of our node.
+		return nil, status.Errorf(codes.FailedPrecondition, "volume %s can only be published on node %q", req.GetVolumeId(), od.nodeID)
+	}
+
+	// Volume ID is the same as the volume name in CreateVolume. Serialize by that.
+	name := req.GetVolumeId()
+	unlock, err := volumeNameMutex.lock(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	// MapVolume would fail with a less obvious error.
+	if err := od.checkVolumeExistsOIM(ctx, name); err != nil {
+		return nil, err
+	}
+
+	// Connect to OIM controller through OIM registry.
+	conn, err := od.DialRegistry(ctx)
+	if err != nil {
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	}
+	defer conn.Close()
+	controllerClient := oim.NewControllerClient(conn)
+	ctx = metadata.AppendToOutgoingContext(ctx, "controllerid", od.oimControllerID)
+
+	// MapVolume is idempotent, publishing again returns the same result.
+	reply, err := controllerClient.MapVolume(ctx, &oim.MapVolumeRequest{
+		VolumeId: name,
+		Params: &oim.MapVolumeRequest_Malloc{
+			Malloc: &oim.MallocParams{},
+		},
+	})
+	if err != nil {
+		return nil, oimcommon.GRPCError(err, codes.FailedPrecondition, "MapVolume for %s failed", name)
+	}
+	return &csi.ControllerPublishVolumeResponse{
+		PublishInfo: publishInfo(reply),
+	}, nil
 }
 
 func (od *oimDriver) ControllerUnpublishVolume(ctx context.Context, req *csi.ControllerUnpublishVolumeRequest) (*csi.ControllerUnpublishVolumeResponse, error) {
-	return nil, status.Error(codes.Unimplemented, "")
+	if !od.controllerPublishes() {
+		return nil, status.Error(codes.Unimplemented, "")
+	}
+
+	// Check arguments
+	if len(req.GetVolumeId()) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "Volume ID missing in request")
+	}
+	if req.GetNodeId() != "" && req.GetNodeId() != od.nodeID {
+		// The attacher of some other node must not complete the
+		// detach, because then the volume would never get
+		// unmapped here.
+		return nil, status.Errorf(codes.FailedPrecondition, "volume %s can only be unpublished on node %q", req.GetVolumeId(), od.nodeID)
+	}
+
+	name := req.GetVolumeId()
+	unlock, err := volumeNameMutex.lock(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	// Connect to OIM controller through OIM registry.
+	conn, err := od.DialRegistry(ctx)
+	if err != nil {
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	}
+	defer conn.Close()
+	controllerClient := oim.NewControllerClient(conn)
+	ctx = metadata.AppendToOutgoingContext(ctx, "controllerid", od.oimControllerID)
+	if _, err := controllerClient.UnmapVolume(ctx, &oim.UnmapVolumeRequest{
+		VolumeId: name,
+	}); err != nil {
+		return nil, oimcommon.GRPCError(err, codes.FailedPrecondition, "UnmapVolume for %s failed", name)
+	}
+	return &csi.ControllerUnpublishVolumeResponse{}, nil
+}
+
+// publishInfo stores the result of MapVolume for NodePublishVolume.
+func publishInfo(reply *oim.MapVolumeReply) map[string]string {
+	info := map[string]string{
+		publishInfoPCIAddress: oimcommon.PrettyPCIAddress(reply.GetPciAddress()),
+	}
+	if scsiDisk := reply.GetScsiDisk(); scsiDisk != nil {
+		info[publishInfoSCSITarget] = strconv.FormatUint(uint64(scsiDisk.GetTarget()), 10)
+		info[publishInfoSCSILUN] = strconv.FormatUint(uint64(scsiDisk.GetLun()), 10)
+	}
+	return info
+}
+
+// parsePublishInfo is the reverse of publishInfo. It returns nil if
+// the volume was not published by ControllerPublishVolume.
+func parsePublishInfo(info map[string]string) (*oim.MapVolumeReply, error) {
+	pciAddress, ok := info[publishInfoPCIAddress]
+	if !ok {
+		return nil, nil
+	}
+	reply := &oim.MapVolumeReply{}
+	if pciAddress != oimcommon.PrettyPCIAddress(nil) {
+		p, err := oimcommon.ParseBDFString(pciAddress)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("publish info: %s", err))
+		}
+		reply.PciAddress = p
+	}
+	if target, ok := info[publishInfoSCSITarget]; ok {
+		t, err := strconv.ParseUint(target, 10, 32)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("publish info: SCSI target: %s", err))
+		}
+		l, err := strconv.ParseUint(info[publishInfoSCSILUN], 10, 32)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("publish info: SCSI LUN: %s", err))
+		}
+		reply.ScsiDisk = &oim.SCSIDisk{Target: uint32(t), Lun: uint32(l)}
+	}
+	return reply, nil
 }
 
 func (od *oimDriver) ValidateVolumeCapabilities(ctx context.Context, req *csi.ValidateVolumeCapabilitiesRequest) (*csi.ValidateVolumeCapabilitiesResponse, error) {
@@ -224,11 +479,13 @@ func (od *oimDriver) ValidateVolumeCapabilities(ctx context.Context, req *csi.Va
 	if name == "" {
 		return nil, status.Error(codes.InvalidArgument, "empty volume ID")
 	}
-	volumeNameMutex.LockKey(name)
-	defer volumeNameMutex.UnlockKey(name)
+	unlock, err := volumeNameMutex.lock(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
 
 	// Check that volume exists.
-	var err error
 	if od.vhostEndpoint != "" {
 		err = od.checkVolumeExistsSPDK(ctx, req.GetVolumeId())
 	} else {
@@ -239,13 +496,51 @@ func (od *oimDriver) ValidateVolumeCapabilities(ctx context.Context, req *csi.Va
 	}
 
 	for _, cap := range req.VolumeCapabilities {
-		if cap.GetAccessMode().GetMode() != csi.VolumeCapability_AccessMode_SINGLE_NODE_WRITER {
-			return &csi.ValidateVolumeCapabilitiesResponse{Supported: false, Message: ""}, nil
+		if message := checkVolumeCapability(cap); message != "" {
+			return &csi.ValidateVolumeCapabilitiesResponse{Supported: false, Message: message}, nil
+		}
+	}
+	for _, topology := range req.GetAccessibleTopology() {
+		if actual := od.topology(); actual != nil && !matchesTopology(actual, topology) {
+			return &csi.ValidateVolumeCapabilitiesResponse{Supported: false, Message: fmt.Sprintf("volume is only accessible on node %q", od.nodeID)}, nil
 		}
 	}
 	return &csi.ValidateVolumeCapabilitiesResponse{Supported: true, Message: ""}, nil
 }
 
+// checkAccessMode rejects access modes which involve more than one
+// node: volumes are local to the node where they were created.
+func checkAccessMode(mode csi.VolumeCapability_AccessMode_Mode) error {
+	switch mode {
+	case csi.VolumeCapability_AccessMode_SINGLE_NODE_WRITER,
+		csi.VolumeCapability_AccessMode_SINGLE_NODE_READER_ONLY:
+		return nil
+	}
+	return status.Errorf(codes.InvalidArgument, "access mode %s not supported, only %s and %s",
+		mode,
+		csi.VolumeCapability_AccessMode_SINGLE_NODE_WRITER,
+		csi.VolumeCapability_AccessMode_SINGLE_NODE_READER_ONLY)
+}
+
+// checkVolumeCapability returns an explanation why the capability
+// is not supported, an empty string if it is.
+func checkVolumeCapability(cap *csi.VolumeCapability) string {
+	if err := checkAccessMode(cap.GetAccessMode().GetMode()); err != nil {
+		return status.Convert(err).Message()
+	}
+	// Volumes can be used with a file system or as raw block device.
+	if mount := cap.GetMount(); mount != nil {
+		if err := checkFsType(mount.GetFsType()); err != nil {
+			return status.Convert(err).Message()
+		}
+		return ""
+	}
+	if cap.GetBlock() == nil {
+		return "unknown access type"
+	}
+	return ""
+}
+
 func (od *oimDriver) checkVolumeExistsSPDK(ctx context.Context, volumeID string) error {
 	// Connect to SPDK.
 	client, err := spdk.New(od.vhostEndpoint)
@@ -279,7 +574,107 @@ func (od *oimDriver) checkVolumeExistsOIM(ctx context.Context, volumeID string)
 }
 
 func (od *oimDriver) ListVolumes(ctx context.Context, req *csi.ListVolumesRequest) (*csi.ListVolumesResponse, error) {
-	return nil, status.Error(codes.Unimplemented, "")
+	// Check before contacting the backend.
+	if req.GetMaxEntries() < 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid max entries %d", req.GetMaxEntries())
+	}
+	var volumes []*csi.Volume
+	var err error
+	if od.vhostEndpoint != "" {
+		volumes, err = od.listVolumesSPDK(ctx)
+	} else {
+		volumes, err = od.listVolumesOIM(ctx)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return paginateVolumes(volumes, req.GetStartingToken(), req.GetMaxEntries())
+}
+
+func (od *oimDriver) listVolumesSPDK(ctx context.Context) ([]*csi.Volume, error) {
+	// Connect to SPDK.
+	client, err := spdk.New(od.vhostEndpoint)
+	if err != nil {
+		return nil, status.Error(codes.FailedPrecondition, fmt.Sprintf("Failed to connect to SPDK: %s", err))
+	}
+	defer client.Close()
+
+	bdevs, err := spdk.GetBDevs(ctx, client, spdk.GetBDevsArgs{})
+	if err != nil {
+		return nil, status.Error(codes.FailedPrecondition, fmt.Sprintf("Failed to get BDevs from SPDK: %s", err))
+	}
+	var volumes []*csi.Volume
+	for _, bdev := range bdevs {
+		if bdev.ProductName != "Malloc disk" || isEphemeralBDev(bdev.Name) {
+			continue
+		}
+		volumes = append(volumes, &csi.Volume{
+			Id:                 bdev.Name,
+			CapacityBytes:      bdev.BlockSize * bdev.NumBlocks,
+			AccessibleTopology: od.accessibleTopology(),
+		})
+	}
+	sort.Slice(volumes, func(i, j int) bool {
+		return volumes[i].Id < volumes[j].Id
+	})
+	return volumes, nil
+}
+
+func (od *oimDriver) listVolumesOIM(ctx context.Context) ([]*csi.Volume, error) {
+	// Connect to OIM controller through OIM registry.
+	conn, err := od.DialRegistry(ctx)
+	if err != nil {
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	}
+	defer conn.Close()
+	controllerClient := oim.NewControllerClient(conn)
+	ctx = metadata.AppendToOutgoingContext(ctx, "controllerid", od.oimControllerID)
+	reply, err := controllerClient.ListMallocBDevs(ctx, &oim.ListMallocBDevsRequest{})
+	if err != nil {
+		return nil, err
+	}
+	var volumes []*csi.Volume
+	for _, bdev := range reply.GetBdevs() {
+		if isEphemeralBDev(bdev.GetBdevName()) {
+			continue
+		}
+		volumes = append(volumes, &csi.Volume{
+			Id:                 bdev.GetBdevName(),
+			CapacityBytes:      bdev.GetSize_(),
+			AccessibleTopology: od.accessibleTopology(),
+		})
+	}
+	return volumes, nil
+}
+
+// paginateVolumes returns at most maxEntries volumes (all when zero),
+// starting at the offset encoded in the token. The token is simply
+// the decimal index of the next volume in the sorted list.
+func paginateVolumes(volumes []*csi.Volume, startingToken string, maxEntries int32) (*csi.ListVolumesResponse, error) {
+	if maxEntries < 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid max entries %d", maxEntries)
+	}
+	start := 0
+	if startingToken != "" {
+		var err error
+		start, err = strconv.Atoi(startingToken)
+		if err != nil || start < 0 || start > len(volumes) {
+			return nil, status.Errorf(codes.Aborted, "invalid starting token %q", startingToken)
+		}
+	}
+	end := len(volumes)
+	if maxEntries > 0 && start+int(maxEntries) < end {
+		end = start + int(maxEntries)
+	}
+
+	response := &csi.ListVolumesResponse{}
+	for _, volume := range volumes[start:end] {
+		response.Entries = append(response.Entries, &csi.ListVolumesResponse_Entry{Volume: volume})
+	}
+	if end < len(volumes) {
+		response.NextToken = strconv.Itoa(end)
+	}
+	return response, nil
 }
 
 func (od *oimDriver) GetCapacity(ctx context.Context, req *csi.GetCapacityRequest) (*csi.GetCapacityResponse, error) {
@@ -0,0 +1,157 @@
+/*
+Copyright 2018 Intel Corporation.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package oimcsidriver
+
+import (
+	"context"
+	"testing"
+
+	"github.com/container-storage-interface/spec/lib/go/csi/v0"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/intel/oim/pkg/spec/oim/v0"
+)
+
+func TestPaginateVolumes(t *testing.T) {
+	volumes := []*csi.Volume{{Id: "a"}, {Id: "b"}, {Id: "c"}}
+	ids := func(response *csi.ListVolumesResponse) []string {
+		var ids []string
+		for _, entry := range response.GetEntries() {
+			ids = append(ids, entry.GetVolume().GetId())
+		}
+		return ids
+	}
+
+	response, err := paginateVolumes(volumes, "", 0)
+	if assert.NoError(t, err) {
+		assert.Equal(t, []string{"a", "b", "c"}, ids(response))
+		assert.Empty(t, response.GetNextToken())
+	}
+
+	response, err = paginateVolumes(volumes, "", 2)
+	if assert.NoError(t, err) {
+		assert.Equal(t, []string{"a", "b"}, ids(response))
+		assert.Equal(t, "2", response.GetNextToken())
+	}
+	response, err = paginateVolumes(volumes, response.GetNextToken(), 2)
+	if assert.NoError(t, err) {
+		assert.Equal(t, []string{"c"}, ids(response))
+		assert.Empty(t, response.GetNextToken())
+	}
+
+	response, err = paginateVolumes(nil, "", 5)
+	if assert.NoError(t, err) {
+		assert.Empty(t, ids(response))
+	}
+
+	for _, token := range []string{"x", "-1", "4"} {
+		_, err = paginateVolumes(volumes, token, 0)
+		assert.Equal(t, codes.Aborted, status.Code(err), "token %q", token)
+	}
+	_, err = paginateVolumes(volumes, "", -1)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestVolumeSize(t *testing.T) {
+	for i, tc := range []struct {
+		capacityRange *csi.CapacityRange
+		minSize       int64
+		size          int64
+		code          codes.Code
+	}{
+		{nil, 0, mib, codes.OK},
+		{&csi.CapacityRange{RequiredBytes: 1}, 0, 512, codes.OK},
+		{&csi.CapacityRange{RequiredBytes: 1024}, 0, 1024, codes.OK},
+		{&csi.CapacityRange{LimitBytes: 4096}, 0, 4096, codes.OK},
+		{&csi.CapacityRange{RequiredBytes: 1, LimitBytes: 100}, 0, 0, codes.OutOfRange},
+		{&csi.CapacityRange{RequiredBytes: tib}, 0, 0, codes.OutOfRange},
+		{&csi.CapacityRange{RequiredBytes: 2048, LimitBytes: 1024}, 0, 0, codes.InvalidArgument},
+		{&csi.CapacityRange{RequiredBytes: -1}, 0, 0, codes.InvalidArgument},
+
+		// Minimum size, must not exceed limit_bytes.
+		{nil, 4 * mib, 4 * mib, codes.OK},
+		{&csi.CapacityRange{RequiredBytes: 1000}, 2000, 2048, codes.OK},
+		{&csi.CapacityRange{RequiredBytes: 2 * mib}, mib, 2 * mib, codes.OK},
+		{&csi.CapacityRange{RequiredBytes: 1000, LimitBytes: 2 * mib}, mib, mib, codes.OK},
+		{&csi.CapacityRange{LimitBytes: mib}, mib, mib, codes.OK},
+		{&csi.CapacityRange{RequiredBytes: 1000, LimitBytes: 4096}, mib, 0, codes.OutOfRange},
+		{&csi.CapacityRange{LimitBytes: 4096}, mib, 0, codes.OutOfRange},
+	} {
+		size, err := volumeSize(tc.capacityRange, tc.minSize)
+		assert.Equal(t, tc.code, status.Code(err), "%d: %s", i, err)
+		assert.Equal(t, tc.size, size, "%d", i)
+		if err == nil {
+			assert.True(t, compatibleSize(size, tc.capacityRange), "%d: compatible", i)
+		}
+	}
+	assert.False(t, compatibleSize(mib, &csi.CapacityRange{RequiredBytes: 2 * mib}))
+	assert.False(t, compatibleSize(2*mib, &csi.CapacityRange{LimitBytes: mib}))
+}
+
+func TestCheckVolumeCapability(t *testing.T) {
+	singleWriter := &csi.VolumeCapability_AccessMode{Mode: csi.VolumeCapability_AccessMode_SINGLE_NODE_WRITER}
+	singleReader := &csi.VolumeCapability_AccessMode{Mode: csi.VolumeCapability_AccessMode_SINGLE_NODE_READER_ONLY}
+	multiWriter := &csi.VolumeCapability_AccessMode{Mode: csi.VolumeCapability_AccessMode_MULTI_NODE_MULTI_WRITER}
+	mount := func(fsType string) *csi.VolumeCapability_Mount {
+		return &csi.VolumeCapability_Mount{Mount: &csi.VolumeCapability_MountVolume{FsType: fsType}}
+	}
+	block := &csi.VolumeCapability_Block{Block: &csi.VolumeCapability_BlockVolume{}}
+
+	for i, tc := range []struct {
+		cap     *csi.VolumeCapability
+		message string
+	}{
+		{&csi.VolumeCapability{AccessMode: singleWriter, AccessType: mount("")}, ""},
+		{&csi.VolumeCapability{AccessMode: singleWriter, AccessType: mount("xfs")}, ""},
+		{&csi.VolumeCapability{AccessMode: singleWriter, AccessType: block}, ""},
+		{&csi.VolumeCapability{AccessMode: singleReader, AccessType: block}, ""},
+		{&csi.VolumeCapability{AccessMode: singleWriter, AccessType: mount("nfs")}, `unsupported file system type "nfs"`},
+		{&csi.VolumeCapability{AccessMode: singleWriter}, "unknown access type"},
+		{&csi.VolumeCapability{AccessMode: multiWriter, AccessType: block}, "access mode MULTI_NODE_MULTI_WRITER not supported, only SINGLE_NODE_WRITER and SINGLE_NODE_READER_ONLY"},
+		{&csi.VolumeCapability{AccessType: block}, "access mode UNKNOWN not supported, only SINGLE_NODE_WRITER and SINGLE_NODE_READER_ONLY"},
+	} {
+		assert.Equal(t, tc.message, checkVolumeCapability(tc.cap), "%d", i)
+	}
+}
+
+func TestCreateVolumeAccessMode(t *testing.T) {
+	od := &oimDriver{}
+	_, err := od.CreateVolume(context.Background(), &csi.CreateVolumeRequest{
+		Name: "volume",
+		VolumeCapabilities: []*csi.VolumeCapability{{
+			AccessMode: &csi.VolumeCapability_AccessMode{Mode: csi.VolumeCapability_AccessMode_MULTI_NODE_READER_ONLY},
+		}},
+	})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	assert.Contains(t, status.Convert(err).Message(), "only SINGLE_NODE_WRITER and SINGLE_NODE_READER_ONLY")
+}
+
+func TestPublishInfo(t *testing.T) {
+	for i, reply := range []*oim.MapVolumeReply{
+		{},
+		{PciAddress: &oim.PCIAddress{Domain: 0, Bus: 8, Device: 7, Function: 1}},
+		{PciAddress: &oim.PCIAddress{Domain: 0xFFFF, Bus: 8, Device: 0xFFFF, Function: 0xFFFF}},
+		{ScsiDisk: &oim.SCSIDisk{Target: 3, Lun: 1}},
+	} {
+		info := publishInfo(reply)
+		parsed, err := parsePublishInfo(info)
+		if assert.NoError(t, err, "%d: %v", i, info) {
+			assert.Equal(t, reply, parsed, "%d: %v", i, info)
+		}
+	}
+
+	parsed, err := parsePublishInfo(nil)
+	assert.NoError(t, err)
+	assert.Nil(t, parsed, "not published")
+
+	_, err = parsePublishInfo(map[string]string{publishInfoPCIAddress: "foo"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	_, err = parsePublishInfo(map[string]string{publishInfoPCIAddress: ":.", publishInfoSCSITarget: "1"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
@@ -0,0 +1,118 @@
+/*
+Copyright (C) 2018 Intel Corporation.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package oimcsidriver
+
+import (
+	"context"
+	"strings"
+
+	"github.com/container-storage-interface/spec/lib/go/csi/v0"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+const (
+	// EphemeralAttribute is set to "true" by kubelet for inline
+	// ephemeral volumes. Such volumes are created by
+	// NodePublishVolume and deleted by NodeUnpublishVolume.
+	EphemeralAttribute = "csi.storage.k8s.io/ephemeral"
+
+	// EphemeralSizeAttribute is the volume attribute which
+	// defines the size of an ephemeral volume as a Kubernetes
+	// quantity, for example "100Mi". The default is 1MiB.
+	EphemeralSizeAttribute = "size"
+
+	// ephemeralPrefix is prepended to the volume ID to get the
+	// name of the Malloc BDev for an ephemeral volume. This
+	// distinguishes them from normal volumes in NodeUnpublishVolume,
+	// which does not get the volume attributes.
+	ephemeralPrefix = "ephemeral-"
+)
+
+// isEphemeral checks whether NodePublishVolume is meant to create
+// an ephemeral volume.
+func isEphemeral(req *csi.NodePublishVolumeRequest) bool {
+	return req.GetVolumeAttributes()[EphemeralAttribute] == "true"
+}
+
+// ephemeralBDevName returns the name of the Malloc BDev which
+// backs an ephemeral volume.
+func ephemeralBDevName(volumeID string) string {
+	return ephemeralPrefix + volumeID
+}
+
+// isEphemeralBDev is true for BDevs which back an ephemeral volume.
+// Those are not listed by ListVolumes.
+func isEphemeralBDev(bdevName string) bool {
+	return strings.HasPrefix(bdevName, ephemeralPrefix)
+}
+
+// ephemeralSize determines the size of an ephemeral volume from its
+// attributes, with the same limits as in CreateVolume.
+func ephemeralSize(attributes map[string]string, minSize int64) (int64, error) {
+	value, ok := attributes[EphemeralSizeAttribute]
+	if !ok {
+		return volumeSize(nil, minSize)
+	}
+	quantity, err := resource.ParseQuantity(value)
+	if err != nil {
+		return 0, status.Errorf(codes.InvalidArgument, "invalid %s attribute %q: %s", EphemeralSizeAttribute, value, err)
+	}
+	size, ok := quantity.AsInt64()
+	if !ok || size <= 0 {
+		return 0, status.Errorf(codes.InvalidArgument, "invalid %s attribute %q: must be a positive number of bytes", EphemeralSizeAttribute, value)
+	}
+	// The size is also the limit: the volume must not become
+	// larger than requested.
+	return volumeSize(&csi.CapacityRange{RequiredBytes: size, LimitBytes: size}, minSize)
+}
+
+// createEphemeral creates the Malloc BDev for an ephemeral volume,
+// unless it already exists with the same size.
+func (od *oimDriver) createEphemeral(ctx context.Context, bdevName string, size int64) error {
+	if od.vhostEndpoint != "" {
+		_, err := od.createVolumeSPDK(ctx, &csi.CreateVolumeRequest{
+			Name:          bdevName,
+			CapacityRange: &csi.CapacityRange{RequiredBytes: size, LimitBytes: size},
+		}, size, nil)
+		return err
+	}
+	return od.provisionOIM(ctx, bdevName, size)
+}
+
+// deleteEphemeral removes the Malloc BDev of an ephemeral volume.
+// It must not be in use anymore.
+func (od *oimDriver) deleteEphemeral(ctx context.Context, bdevName string) error {
+	if od.vhostEndpoint != "" {
+		_, err := od.deleteVolumeSPDK(ctx, &csi.DeleteVolumeRequest{VolumeId: bdevName})
+		return err
+	}
+	return od.provisionOIM(ctx, bdevName, 0)
+}
+
+// ephemeralExists checks whether the volume is an ephemeral volume
+// created by NodePublishVolume.
+func (od *oimDriver) ephemeralExists(ctx context.Context, volumeID string) (bool, error) {
+	if od.emulate != nil {
+		return false, nil
+	}
+	var err error
+	if od.vhostEndpoint != "" {
+		err = od.checkVolumeExistsSPDK(ctx, ephemeralBDevName(volumeID))
+	} else {
+		err = od.checkVolumeExistsOIM(ctx, ephemeralBDevName(volumeID))
+	}
+	switch status.Code(err) {
+	case codes.OK:
+		return true, nil
+	case codes.NotFound:
+		return false, nil
+	default:
+		return false, err
+	}
+}
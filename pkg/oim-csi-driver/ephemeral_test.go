@@ -0,0 +1,43 @@
+/*
+Copyright 2018 Intel Corporation.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package oimcsidriver
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestEphemeralSize(t *testing.T) {
+	for i, tc := range []struct {
+		size string
+		set  bool
+		want int64
+		code codes.Code
+	}{
+		{"", false, mib, codes.OK},
+		{"1024", true, 1024, codes.OK},
+		{"100Mi", true, 100 * mib, codes.OK},
+		{"1k", true, 1000, codes.OutOfRange},
+		{"2T", true, 0, codes.OutOfRange},
+		{"0", true, 0, codes.InvalidArgument},
+		{"-1Mi", true, 0, codes.InvalidArgument},
+		{"foo", true, 0, codes.InvalidArgument},
+	} {
+		attributes := map[string]string{EphemeralAttribute: "true"}
+		if tc.set {
+			attributes[EphemeralSizeAttribute] = tc.size
+		}
+		size, err := ephemeralSize(attributes, 0)
+		assert.Equal(t, tc.code, status.Code(err), "%d: %s", i, err)
+		if err == nil {
+			assert.Equal(t, tc.want, size, "%d", i)
+		}
+	}
+}
@@ -10,12 +10,19 @@ import (
 	"context"
 
 	"github.com/container-storage-interface/spec/lib/go/csi/v0"
+
+	"github.com/intel/oim/pkg/version"
 )
 
 func (od *oimDriver) GetPluginInfo(ctx context.Context, req *csi.GetPluginInfoRequest) (*csi.GetPluginInfoResponse, error) {
 	return &csi.GetPluginInfoResponse{
 		Name:          od.driverName,
 		VendorVersion: od.version,
+		// The vendor version is configurable, the build is not.
+		Manifest: map[string]string{
+			"commit":     version.Commit,
+			"build-date": version.BuildDate,
+		},
 	}, nil
 }
 
@@ -24,15 +31,25 @@ func (od *oimDriver) Probe(ctx context.Context, req *csi.ProbeRequest) (*csi.Pro
 }
 
 func (od *oimDriver) GetPluginCapabilities(ctx context.Context, req *csi.GetPluginCapabilitiesRequest) (*csi.GetPluginCapabilitiesResponse, error) {
-	return &csi.GetPluginCapabilitiesResponse{
-		Capabilities: []*csi.PluginCapability{
-			{
-				Type: &csi.PluginCapability_Service_{
-					Service: &csi.PluginCapability_Service{
-						Type: csi.PluginCapability_Service_CONTROLLER_SERVICE,
-					},
+	capabilities := []*csi.PluginCapability{
+		{
+			Type: &csi.PluginCapability_Service_{
+				Service: &csi.PluginCapability_Service{
+					Type: csi.PluginCapability_Service_CONTROLLER_SERVICE,
 				},
 			},
 		},
+	}
+	if od.topology() != nil {
+		capabilities = append(capabilities, &csi.PluginCapability{
+			Type: &csi.PluginCapability_Service_{
+				Service: &csi.PluginCapability_Service{
+					Type: csi.PluginCapability_Service_ACCESSIBILITY_CONSTRAINTS,
+				},
+			},
+		})
+	}
+	return &csi.GetPluginCapabilitiesResponse{
+		Capabilities: capabilities,
 	}, nil
 }
@@ -0,0 +1,107 @@
+/*
+Copyright 2018 Intel Corporation.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package oimcsidriver
+
+import (
+	"context"
+	"testing"
+
+	"github.com/container-storage-interface/spec/lib/go/csi/v0"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/intel/oim/pkg/version"
+)
+
+// TestCapabilities checks that exactly the advertised controller
+// operations are implemented. All calls use empty requests, which
+// must be rejected before the backend gets contacted.
+func TestCapabilities(t *testing.T) {
+	ctx := context.Background()
+	d, err := New(WithDriverName("oim-test"),
+		WithDriverVersion("1.2.3"),
+		WithNodeID("host-0"),
+		WithOIMRegistryAddress("unix:///no/such/registry"),
+		WithRegistryCreds("ca.crt", "host.key"),
+		WithOIMControllerID("host-0"))
+	require.NoError(t, err)
+	od := d.(*oimDriver)
+
+	info, err := od.GetPluginInfo(ctx, &csi.GetPluginInfoRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, "oim-test", info.GetName())
+	assert.Equal(t, "1.2.3", info.GetVendorVersion())
+	assert.Equal(t, map[string]string{"commit": version.Commit, "build-date": version.BuildDate}, info.GetManifest())
+
+	pluginCaps, err := od.GetPluginCapabilities(ctx, &csi.GetPluginCapabilitiesRequest{})
+	require.NoError(t, err)
+	var services []csi.PluginCapability_Service_Type
+	for _, cap := range pluginCaps.GetCapabilities() {
+		services = append(services, cap.GetService().GetType())
+	}
+	assert.Equal(t, []csi.PluginCapability_Service_Type{
+		csi.PluginCapability_Service_CONTROLLER_SERVICE,
+		csi.PluginCapability_Service_ACCESSIBILITY_CONSTRAINTS,
+	}, services)
+
+	controllerCaps, err := od.ControllerGetCapabilities(ctx, &csi.ControllerGetCapabilitiesRequest{})
+	require.NoError(t, err)
+	advertised := map[csi.ControllerServiceCapability_RPC_Type]bool{}
+	for _, cap := range controllerCaps.GetCapabilities() {
+		advertised[cap.GetRpc().GetType()] = true
+	}
+
+	calls := map[csi.ControllerServiceCapability_RPC_Type][]func() error{
+		csi.ControllerServiceCapability_RPC_CREATE_DELETE_VOLUME: {
+			func() error { _, err := od.CreateVolume(ctx, &csi.CreateVolumeRequest{}); return err },
+			func() error { _, err := od.DeleteVolume(ctx, &csi.DeleteVolumeRequest{}); return err },
+		},
+		csi.ControllerServiceCapability_RPC_PUBLISH_UNPUBLISH_VOLUME: {
+			func() error {
+				_, err := od.ControllerPublishVolume(ctx, &csi.ControllerPublishVolumeRequest{})
+				return err
+			},
+			func() error {
+				_, err := od.ControllerUnpublishVolume(ctx, &csi.ControllerUnpublishVolumeRequest{})
+				return err
+			},
+		},
+		csi.ControllerServiceCapability_RPC_LIST_VOLUMES: {
+			func() error { _, err := od.ListVolumes(ctx, &csi.ListVolumesRequest{MaxEntries: -1}); return err },
+		},
+		csi.ControllerServiceCapability_RPC_GET_CAPACITY: {
+			func() error { _, err := od.GetCapacity(ctx, &csi.GetCapacityRequest{}); return err },
+		},
+		csi.ControllerServiceCapability_RPC_CREATE_DELETE_SNAPSHOT: {
+			func() error { _, err := od.CreateSnapshot(ctx, &csi.CreateSnapshotRequest{}); return err },
+			func() error { _, err := od.DeleteSnapshot(ctx, &csi.DeleteSnapshotRequest{}); return err },
+		},
+		csi.ControllerServiceCapability_RPC_LIST_SNAPSHOTS: {
+			func() error { _, err := od.ListSnapshots(ctx, &csi.ListSnapshotsRequest{}); return err },
+		},
+	}
+	for capType := range advertised {
+		assert.Contains(t, calls, capType, "advertised capability not covered by test")
+	}
+	for capType, funcs := range calls {
+		for i, call := range funcs {
+			code := status.Code(call())
+			if advertised[capType] {
+				assert.NotEqual(t, codes.Unimplemented, code, "%s #%d advertised, but not implemented", capType, i)
+				assert.NotEqual(t, codes.OK, code, "%s #%d should reject empty request", capType, i)
+			} else {
+				assert.Equal(t, codes.Unimplemented, code, "%s #%d implemented, but not advertised", capType, i)
+			}
+		}
+	}
+
+	nodeCaps, err := od.NodeGetCapabilities(ctx, &csi.NodeGetCapabilitiesRequest{})
+	require.NoError(t, err)
+	assert.Empty(t, nodeCaps.GetCapabilities(), "node capabilities")
+}
@@ -9,6 +9,7 @@ package oimcsidriver
 import (
 	"context"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"os"
 	"path/filepath"
@@ -20,6 +21,7 @@ import (
 
 	"github.com/container-storage-interface/spec/lib/go/csi/v0"
 	"github.com/pkg/errors"
+	"golang.org/x/sys/unix"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
@@ -42,22 +44,47 @@ func (od *oimDriver) NodeGetId(ctx context.Context, req *csi.NodeGetIdRequest) (
 
 func (od *oimDriver) NodeGetInfo(ctx context.Context, req *csi.NodeGetInfoRequest) (*csi.NodeGetInfoResponse, error) {
 	return &csi.NodeGetInfoResponse{
-		NodeId: od.nodeID,
+		NodeId:             od.nodeID,
+		MaxVolumesPerNode:  od.maxVolumesPerNode(ctx),
+		AccessibleTopology: od.topology(),
 	}, nil
 }
 
+// nodeInfoTimeout limits how long NodeGetInfo waits for the OIM
+// controller.
+const nodeInfoTimeout = 10 * time.Second
+
+// maxVolumesPerNode asks the OIM controller how many volumes it can
+// map. Zero means "unknown" and is used when not using OIM or when
+// the controller cannot be reached: kubelet calls NodeGetInfo while
+// registering the plugin, which must not fail just because the
+// registry or controller are temporarily down.
+func (od *oimDriver) maxVolumesPerNode(ctx context.Context) int64 {
+	if od.registry == nil {
+		return 0
+	}
+	ctx, cancel := context.WithTimeout(ctx, nodeInfoTimeout)
+	defer cancel()
+	conn, err := od.DialRegistry(ctx)
+	if err != nil {
+		log.FromContext(ctx).Warnw("max volumes per node unknown", "error", err)
+		return 0
+	}
+	defer conn.Close()
+	controllerClient := oim.NewControllerClient(conn)
+	ctx = metadata.AppendToOutgoingContext(ctx, "controllerid", od.oimControllerID)
+	reply, err := controllerClient.GetNodeInfo(ctx, &oim.GetNodeInfoRequest{})
+	if err != nil {
+		log.FromContext(ctx).Warnw("max volumes per node unknown", "controllerid", od.oimControllerID, "error", err)
+		return 0
+	}
+	return reply.GetMaxVolumes()
+}
+
 func (od *oimDriver) NodeGetCapabilities(ctx context.Context, req *csi.NodeGetCapabilitiesRequest) (*csi.NodeGetCapabilitiesResponse, error) {
-	return &csi.NodeGetCapabilitiesResponse{
-		Capabilities: []*csi.NodeServiceCapability{
-			{
-				Type: &csi.NodeServiceCapability_Rpc{
-					Rpc: &csi.NodeServiceCapability_RPC{
-						Type: csi.NodeServiceCapability_RPC_UNKNOWN,
-					},
-				},
-			},
-		},
-	}, nil
+	// NodeStageVolume and NodeUnstageVolume are no-ops, so
+	// STAGE_UNSTAGE_VOLUME is not advertised.
+	return &csi.NodeGetCapabilitiesResponse{}, nil
 }
 
 func findNBDDevice(ctx context.Context, client *spdk.Client, volumeID string) (nbdDevice string, err error) {
@@ -73,7 +100,22 @@ func findNBDDevice(ctx context.Context, client *spdk.Client, volumeID string) (n
 	return "", nil
 }
 
+// NodePublishVolume honors the deadline of the request: waiting for
+// the device, formatting and mounting get interrupted when it is
+// reached. Until that is done, only further operations for the same
+// volume are blocked.
 func (od *oimDriver) NodePublishVolume(ctx context.Context, req *csi.NodePublishVolumeRequest) (*csi.NodePublishVolumeResponse, error) {
+	// Volume ID is the same as the volume name in CreateVolume. Serialize by that.
+	response, err := serialize(ctx, req.GetVolumeId(), func() (interface{}, error) {
+		return od.nodePublishVolume(ctx, req)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return response.(*csi.NodePublishVolumeResponse), nil
+}
+
+func (od *oimDriver) nodePublishVolume(ctx context.Context, req *csi.NodePublishVolumeRequest) (*csi.NodePublishVolumeResponse, error) {
 	// Check arguments
 	if req.GetVolumeCapability() == nil {
 		return nil, status.Error(codes.InvalidArgument, "Volume capability missing in request")
@@ -82,40 +124,73 @@ func (od *oimDriver) NodePublishVolume(ctx context.Context, req *csi.NodePublish
 		return nil, status.Error(codes.InvalidArgument, "Target path missing in request")
 	}
 
-	// Volume ID is the same as the volume name in CreateVolume. Serialize by that.
-	name := req.GetVolumeId()
-	if name == "" {
+	if req.GetVolumeId() == "" {
 		return nil, status.Error(codes.InvalidArgument, "empty volume ID")
 	}
-	volumeNameMutex.LockKey(name)
-	defer volumeNameMutex.UnlockKey(name)
 
-	// Check and prepare mount point.
+	// Check and prepare mount point. For raw block volumes, the
+	// mount point is a file onto which the device gets bind-mounted.
 	targetPath := req.GetTargetPath()
+	block := req.GetVolumeCapability().GetBlock() != nil
 	notMnt, err := mount.New("").IsLikelyNotMountPoint(targetPath)
 	if err != nil {
-		if os.IsNotExist(err) {
-			if err = os.MkdirAll(targetPath, 0750); err != nil {
+		switch {
+		case os.IsNotExist(err):
+			if err = createTarget(targetPath, block); err != nil {
 				return nil, status.Error(codes.Internal, err.Error())
 			}
 			notMnt = true
-		} else {
+		case mount.IsCorruptedMnt(err):
+			// Left over from before a reboot or a crash,
+			// mount again.
+			log.FromContext(ctx).Warnw("unmounting stale mount point", "target", targetPath, "error", err)
+			if err := mount.NewWithContext(ctx, "").Unmount(targetPath); err != nil {
+				return nil, status.Error(codes.Internal, fmt.Sprintf("unmount stale %s: %s", targetPath, err))
+			}
+			notMnt = true
+		default:
 			return nil, status.Error(codes.Internal, err.Error())
 		}
 	}
-	if !notMnt {
-		// Already mounted, nothing to do.
-		return &csi.NodePublishVolumeResponse{}, nil
-	}
+	// When already mounted, we still need to find the device and then
+	// check that it is the one that is mounted.
+	mounted := !notMnt
 
 	fsType := req.GetVolumeCapability().GetMount().GetFsType()
-	readOnly := req.GetReadonly()
+	readOnly := req.GetReadonly() ||
+		req.GetVolumeCapability().GetAccessMode().GetMode() == csi.VolumeCapability_AccessMode_SINGLE_NODE_READER_ONLY
 	volumeID := req.GetVolumeId()
 	attrib := req.GetVolumeAttributes()
 	mountFlags := req.GetVolumeCapability().GetMount().GetMountFlags()
+	if !block {
+		if err := checkFsType(fsType); err != nil {
+			return nil, err
+		}
+		if err := checkMountFlags(mountFlags, readOnly); err != nil {
+			return nil, err
+		}
+	}
+	mkfsOptions := strings.Fields(attrib[MkfsOptionsAttribute])
+
+	if isEphemeral(req) {
+		if od.emulate != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "ephemeral volumes not supported when emulating CSI driver %q", od.emulate.CSIDriverName)
+		}
+		size, err := ephemeralSize(attrib, od.minVolumeSize)
+		if err != nil {
+			return nil, err
+		}
+		// From now on the BDev gets mapped and mounted like
+		// the one of a normal volume.
+		volumeID = ephemeralBDevName(volumeID)
+		if err := od.createEphemeral(ctx, volumeID, size); err != nil {
+			return nil, err
+		}
+	}
 
 	log.FromContext(ctx).Infow("mounting",
 		"target", targetPath,
+		"block", block,
 		"fstype", fsType,
 		"read-only", readOnly,
 		"volumeid", volumeID,
@@ -192,7 +267,7 @@ func (od *oimDriver) NodePublishVolume(ctx context.Context, req *csi.NodePublish
 			NBDDevice: nbdDevice,
 		}
 		if err := spdk.StartNBDDisk(ctx, client, args); err != nil {
-			return nil, status.Error(codes.FailedPrecondition, fmt.Sprintf("Failed to start SPDK NBD disk %+v: %s", args, err))
+			return nil, oimcommon.GRPCError(err, codes.FailedPrecondition, "Failed to start SPDK NBD disk %+v", args)
 		}
 
 		device = nbdDevice
@@ -227,27 +302,38 @@ func (od *oimDriver) NodePublishVolume(ctx context.Context, req *csi.NodePublish
 			defPCIAddress = *p
 		}
 
-		// Make volume available and/or find out where it is.
-		ctx := metadata.AppendToOutgoingContext(ctx, "controllerid", od.oimControllerID)
-		request := &oim.MapVolumeRequest{
-			VolumeId: volumeID,
-			// Malloc BDev is the default. It takes no special parameters.
-			Params: &oim.MapVolumeRequest_Malloc{
-				Malloc: &oim.MallocParams{},
-			},
+		// ControllerPublishVolume might have mapped the volume
+		// already, otherwise we do it here.
+		reply, err := parsePublishInfo(req.GetPublishInfo())
+		if err != nil {
+			return nil, err
 		}
-		if od.emulate != nil {
-			// Replace default parameters with the actual
-			// values for the request. Interpretation of
-			// the request depends on which CSI driver we
-			// emulate.
-			if err := od.emulate.MapVolumeParams(req, request); err != nil {
-				return nil, status.Error(codes.FailedPrecondition, fmt.Sprintf("create MapVolumeRequest parameters: %s", err))
+		if reply == nil {
+			// Make volume available and/or find out where it is.
+			ctx := metadata.AppendToOutgoingContext(ctx, "controllerid", od.oimControllerID)
+			request := &oim.MapVolumeRequest{
+				VolumeId: volumeID,
+				// Malloc BDev is the default. It takes no special parameters.
+				Params: &oim.MapVolumeRequest_Malloc{
+					Malloc: &oim.MallocParams{},
+				},
+			}
+			if od.emulate != nil {
+				// Replace default parameters with the actual
+				// values for the request. Interpretation of
+				// the request depends on which CSI driver we
+				// emulate.
+				if err := od.emulate.MapVolumeParams(req, request); err != nil {
+					return nil, status.Error(codes.FailedPrecondition, fmt.Sprintf("create MapVolumeRequest parameters: %s", err))
+				}
+			}
+			reply, err = controllerClient.MapVolume(ctx, request)
+			if err != nil {
+				// Keeps ResourceExhausted when the node has no
+				// room for more volumes, so that the caller
+				// can try elsewhere.
+				return nil, oimcommon.GRPCError(err, codes.FailedPrecondition, "MapVolume for %s failed", volumeID)
 			}
-		}
-		reply, err := controllerClient.MapVolume(ctx, request)
-		if err != nil {
-			return nil, status.Error(codes.FailedPrecondition, fmt.Sprintf("MapVolume for %s failed: %s", volumeID, err))
 		}
 
 		// Find device node based on reply. If the PCI address
@@ -272,7 +358,9 @@ func (od *oimDriver) NodePublishVolume(ctx context.Context, req *csi.NodePublish
 				path))
 		}
 
-		dev, major, minor, err := waitForDevice(ctx, "/sys/dev/block", &complete, reply.GetScsiDisk())
+		waitCtx, cancel := context.WithTimeout(ctx, od.deviceTimeout)
+		defer cancel()
+		dev, major, minor, err := waitForDevice(waitCtx, "/sys/dev/block", &complete, reply.GetScsiDisk())
 		if err != nil {
 			return nil, err
 		}
@@ -296,12 +384,54 @@ func (od *oimDriver) NodePublishVolume(ctx context.Context, req *csi.NodePublish
 		device = devNode
 	}
 
+	if mounted {
+		if err := checkMountedDevice(targetPath, device, block); err != nil {
+			return nil, err
+		}
+		// Already mounted, nothing to do.
+		return &csi.NodePublishVolumeResponse{}, nil
+	}
+
 	options := []string{}
 	if readOnly {
 		options = append(options, "ro")
 	}
-	diskMounter := &mount.SafeFormatAndMount{Interface: mount.New(""), Exec: mount.NewOsExec()}
-	if err := diskMounter.FormatAndMount(device, targetPath, fsType, options); err != nil {
+	options = append(options, mountFlags...)
+	if block {
+		// No file system, the application gets the device itself.
+		options = append(options, "bind")
+		if err := mount.NewWithContext(ctx, "").Mount(device, targetPath, "", options); err != nil {
+			return nil, errors.Wrapf(err, "bind-mounting %s at %s", device, targetPath)
+		}
+		return &csi.NodePublishVolumeResponse{}, nil
+	}
+	diskMounter := &mount.SafeFormatAndMount{Interface: mount.NewWithContext(ctx, ""), Exec: mount.NewOsExecWithContext(ctx)}
+	// Never reformat a device which already has some other file system,
+	// that would destroy the data on it.
+	existingFsType, err := diskMounter.GetDiskFormat(device)
+	if err != nil {
+		return nil, status.Error(codes.Internal, fmt.Sprintf("determine file system on %s: %s", device, err))
+	}
+	if existingFsType != "" && fsType != "" && existingFsType != fsType {
+		return nil, status.Errorf(codes.FailedPrecondition, "volume %s already contains a %s file system, cannot mount it as %s", volumeID, existingFsType, fsType)
+	}
+	if existingFsType == "" && readOnly {
+		return nil, status.Errorf(codes.FailedPrecondition, "volume %s has no file system and cannot be formatted because it is read-only", volumeID)
+	}
+	if existingFsType == "" {
+		// Formatting is only safe when the device really is
+		// empty. Otherwise it might contain data that blkid
+		// does not recognize, for example a file system that
+		// mkfs only wrote partially before a crash.
+		empty, err := isZeroed(device, emptyCheckSize)
+		if err != nil {
+			return nil, status.Error(codes.Internal, fmt.Sprintf("check content of %s: %s", device, err))
+		}
+		if !empty {
+			return nil, status.Errorf(codes.FailedPrecondition, "volume %s contains data without a recognized file system, refusing to format it", volumeID)
+		}
+	}
+	if err := diskMounter.FormatAndMountWithFormatOptions(device, targetPath, fsType, options, mkfsOptions); err != nil {
 		// We get a pretty bad error code from FormatAndMount ("exit code 1") :-/
 		return nil, errors.Wrapf(err, "formatting as %s and mounting %s at %s", fsType, device, targetPath)
 	}
@@ -309,6 +439,115 @@ func (od *oimDriver) NodePublishVolume(ctx context.Context, req *csi.NodePublish
 	return &csi.NodePublishVolumeResponse{}, nil
 }
 
+// emptyCheckSize is the amount of data at the start of a device
+// which must be zero before the device gets formatted. It covers the
+// superblocks and partition tables that blkid looks for.
+const emptyCheckSize = mib
+
+// isZeroed checks whether the first size bytes of the device (or
+// less, if the device is smaller) are all zero.
+func isZeroed(device string, size int64) (bool, error) {
+	file, err := os.Open(device)
+	if err != nil {
+		return false, err
+	}
+	defer file.Close()
+	buffer := make([]byte, 64*kib)
+	for remaining := size; remaining > 0; {
+		chunk := buffer
+		if remaining < int64(len(chunk)) {
+			chunk = chunk[:remaining]
+		}
+		n, err := file.Read(chunk)
+		for _, b := range chunk[:n] {
+			if b != 0 {
+				return false, nil
+			}
+		}
+		remaining -= int64(n)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+// checkMountedDevice verifies that the target is the device (for
+// raw block volumes) or a file system on the device.
+func checkMountedDevice(targetPath, device string, block bool) error {
+	var deviceStat, targetStat syscall.Stat_t
+	if err := syscall.Stat(device, &deviceStat); err != nil {
+		return status.Error(codes.Internal, fmt.Sprintf("stat %s: %s", device, err))
+	}
+	if err := syscall.Stat(targetPath, &targetStat); err != nil {
+		return status.Error(codes.Internal, fmt.Sprintf("stat %s: %s", targetPath, err))
+	}
+	mounted := targetStat.Dev
+	if block {
+		mounted = targetStat.Rdev
+	}
+	if mounted != deviceStat.Rdev {
+		return status.Errorf(codes.FailedPrecondition, "%s is already in use for device %d:%d instead of %s (%d:%d)",
+			targetPath,
+			unix.Major(mounted), unix.Minor(mounted),
+			device,
+			unix.Major(deviceStat.Rdev), unix.Minor(deviceStat.Rdev))
+	}
+	return nil
+}
+
+// MkfsOptionsAttribute is the volume attribute (= StorageClass parameter)
+// with additional, space-separated parameters for mkfs.
+const MkfsOptionsAttribute = "mkfsOptions"
+
+// checkFsType ensures that the requested file system is supported.
+// Empty selects the default, ext4.
+func checkFsType(fsType string) error {
+	switch fsType {
+	case "", "ext2", "ext3", "ext4", "xfs":
+		return nil
+	}
+	return status.Errorf(codes.InvalidArgument, "unsupported file system type %q", fsType)
+}
+
+// checkMountFlags rejects contradicting mount flags.
+func checkMountFlags(mountFlags []string, readOnly bool) error {
+	ro, rw := readOnly, false
+	for _, flag := range mountFlags {
+		switch flag {
+		case "ro":
+			ro = true
+		case "rw":
+			rw = true
+		}
+	}
+	if ro && rw {
+		// The flags themselves are not included because they
+		// might contain sensitive information.
+		return status.Error(codes.InvalidArgument, "conflicting mount flags, cannot mount both read-only and read-write")
+	}
+	return nil
+}
+
+// createTarget creates the directory for a file system mount or the
+// file for a raw block volume.
+func createTarget(targetPath string, block bool) error {
+	if !block {
+		return os.MkdirAll(targetPath, 0750)
+	}
+	if err := os.MkdirAll(filepath.Dir(targetPath), 0750); err != nil {
+		return err
+	}
+	file, err := os.OpenFile(targetPath, os.O_CREATE|os.O_RDWR, 0640)
+	if err != nil {
+		return err
+	}
+	return file.Close()
+}
+
 // makedev prepares the dev argument for Mknod.
 func makedev(major int, minor int) int {
 	// Formular from https://github.com/lattera/glibc/blob/master/sysdeps/unix/sysv/linux/makedev.c
@@ -328,13 +567,15 @@ func waitForDevice(ctx context.Context, sys string, pciAddress *oim.PCIAddress,
 		"PCI", pciAddress,
 		"scsi", scsiDisk,
 	)
+	start := time.Now()
 	watcher, err := fsnotify.NewWatcher()
-	if err == nil {
-		err = watcher.Add(sys)
-	}
 	if err != nil {
 		return "", 0, 0, status.Error(codes.Internal, err.Error())
 	}
+	defer watcher.Close()
+	if err := watcher.Add(sys); err != nil {
+		return "", 0, 0, status.Error(codes.Internal, err.Error())
+	}
 
 	for {
 		dev, major, minor, err := findDev(ctx, sys, pciAddress, scsiDisk)
@@ -347,8 +588,12 @@ func waitForDevice(ctx context.Context, sys string, pciAddress *oim.PCIAddress,
 		}
 		select {
 		case <-ctx.Done():
-			return "", 0, 0, status.Errorf(codes.DeadlineExceeded, "timed out waiting for device %s, SCSI disk '%+v'",
-				oimcommon.PrettyPCIAddress(pciAddress), scsiDisk)
+			if ctx.Err() == context.Canceled {
+				return "", 0, 0, status.Errorf(codes.Canceled, "canceled after %s while waiting for device %s, SCSI disk '%+v' to appear in %s",
+					time.Since(start).Round(time.Second), oimcommon.PrettyPCIAddress(pciAddress), scsiDisk, sys)
+			}
+			return "", 0, 0, status.Errorf(codes.DeadlineExceeded, "timed out after %s waiting for device %s, SCSI disk '%+v' to appear in %s",
+				time.Since(start).Round(time.Second), oimcommon.PrettyPCIAddress(pciAddress), scsiDisk, sys)
 		case <-watcher.Events:
 			// Try again.
 			log.FromContext(ctx).Debugw("changed",
@@ -448,7 +693,20 @@ func findDev(ctx context.Context, sys string, pciAddress *oim.PCIAddress, scsiDi
 	return "", 0, 0, nil
 }
 
+// NodeUnpublishVolume honors the deadline of the request like
+// NodePublishVolume.
 func (od *oimDriver) NodeUnpublishVolume(ctx context.Context, req *csi.NodeUnpublishVolumeRequest) (*csi.NodeUnpublishVolumeResponse, error) {
+	// Volume ID is the same as the volume name in CreateVolume. Serialize by that.
+	response, err := serialize(ctx, req.GetVolumeId(), func() (interface{}, error) {
+		return od.nodeUnpublishVolume(ctx, req)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return response.(*csi.NodeUnpublishVolumeResponse), nil
+}
+
+func (od *oimDriver) nodeUnpublishVolume(ctx context.Context, req *csi.NodeUnpublishVolumeRequest) (*csi.NodeUnpublishVolumeResponse, error) {
 	// Check arguments
 	if len(req.GetVolumeId()) == 0 {
 		return nil, status.Error(codes.InvalidArgument, "Volume ID missing in request")
@@ -459,22 +717,19 @@ func (od *oimDriver) NodeUnpublishVolume(ctx context.Context, req *csi.NodeUnpub
 	targetPath := req.GetTargetPath()
 	volumeID := req.GetVolumeId()
 
-	// Volume ID is the same as the volume name in CreateVolume. Serialize by that.
-	name := req.GetVolumeId()
-	if name == "" {
-		return nil, status.Error(codes.InvalidArgument, "empty volume ID")
+	// Unmounting the image, unless already done earlier.
+	if err := unmount(ctx, targetPath); err != nil {
+		return nil, status.Errorf(codes.Internal, "unmount %s: %s", targetPath, err)
 	}
-	volumeNameMutex.LockKey(name)
-	defer volumeNameMutex.UnlockKey(name)
 
-	// Unmounting the image
-	// TODO: check whether this really is still a mount point. We might have removed it already.
-	log.FromContext(ctx).Infow("unmount",
-		"target", targetPath,
-		"volumeid", volumeID,
-	)
-	if err := mount.New("").Unmount(req.GetTargetPath()); err != nil {
-		return nil, status.Error(codes.Internal, err.Error())
+	// Ephemeral volumes get deleted after unmapping them.
+	ephemeral, err := od.ephemeralExists(ctx, volumeID)
+	if err != nil {
+		return nil, err
+	}
+	bdevName := volumeID
+	if ephemeral {
+		bdevName = ephemeralBDevName(volumeID)
 	}
 
 	if od.vhostEndpoint != "" {
@@ -486,28 +741,40 @@ func (od *oimDriver) NodeUnpublishVolume(ctx context.Context, req *csi.NodeUnpub
 		defer client.Close()
 
 		// Stop NBD disk.
-		nbdDevice, err := findNBDDevice(ctx, client, volumeID)
+		nbdDevice, err := findNBDDevice(ctx, client, bdevName)
 		if err != nil {
 			return nil, status.Error(codes.FailedPrecondition, fmt.Sprintf("Failed to get NDB disks from SPDK: %s", err))
 		}
 		args := spdk.StopNBDDiskArgs{NBDDevice: nbdDevice}
 		if err := spdk.StopNBDDisk(ctx, client, args); err != nil {
-			return nil, status.Error(codes.FailedPrecondition, fmt.Sprintf("Failed to stop SPDK NDB disk %+v: %s", args, err))
+			return nil, oimcommon.GRPCError(err, codes.FailedPrecondition, "Failed to stop SPDK NDB disk %+v", args)
 		}
-	} else {
+	} else if ephemeral || !od.controllerPublishes() {
+		// When ControllerPublishVolume is used, then
+		// ControllerUnpublishVolume unmaps the volume.
+		// Otherwise (and always for ephemeral volumes)
+		// it has to be done here.
+
 		// Connect to OIM controller through OIM registry.
 		conn, err := od.DialRegistry(ctx)
 		if err != nil {
 			return nil, status.Error(codes.FailedPrecondition, err.Error())
 		}
+		defer conn.Close()
 		controllerClient := oim.NewControllerClient(conn)
 
 		// Make volume available and/or find out where it is.
 		ctx := metadata.AppendToOutgoingContext(ctx, "controllerid", od.oimControllerID)
 		if _, err := controllerClient.UnmapVolume(ctx, &oim.UnmapVolumeRequest{
-			VolumeId: volumeID,
+			VolumeId: bdevName,
 		}); err != nil {
-			return nil, status.Error(codes.FailedPrecondition, fmt.Sprintf("UnmapVolume for %s failed: %s", volumeID, err))
+			return nil, oimcommon.GRPCError(err, codes.FailedPrecondition, "UnmapVolume for %s failed", bdevName)
+		}
+	}
+
+	if ephemeral {
+		if err := od.deleteEphemeral(ctx, bdevName); err != nil {
+			return nil, err
 		}
 	}
 
@@ -537,5 +804,17 @@ func (od *oimDriver) NodeUnstageVolume(ctx context.Context, req *csi.NodeUnstage
 		return nil, status.Error(codes.InvalidArgument, "Target path missing in request")
 	}
 
+	// NodeStageVolume does not mount anything, but whatever
+	// is left at the staging path must not leak. Unmounting
+	// might hang.
+	if _, err := serialize(ctx, req.GetVolumeId(), func() (interface{}, error) {
+		return nil, unmountAndRemove(ctx, req.GetStagingTargetPath())
+	}); err != nil {
+		if _, ok := status.FromError(err); ok {
+			return nil, err
+		}
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
 	return &csi.NodeUnstageVolumeResponse{}, nil
 }
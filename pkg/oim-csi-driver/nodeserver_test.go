@@ -12,13 +12,19 @@ import (
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"syscall"
 	"testing"
 	"time"
 
+	"github.com/container-storage-interface/spec/lib/go/csi/v0"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	"github.com/intel/oim/pkg/log/testlog"
+	"github.com/intel/oim/pkg/oim-common"
 	"github.com/intel/oim/pkg/spec/oim/v0"
 )
 
@@ -140,7 +146,7 @@ func TestFindDev(t *testing.T) {
 		},
 	)
 	assert.Error(t, err)
-	assert.Equal(t, "rpc error: code = DeadlineExceeded desc = timed out waiting for device 0000:00:17.0, SCSI disk 'target:1 '", err.Error())
+	assert.Equal(t, "rpc error: code = DeadlineExceeded desc = timed out after 1s waiting for device 0000:00:17.0, SCSI disk 'target:1 ' to appear in "+tmp, err.Error())
 
 	// Create the expected entry in two seconds, wait at most five.
 	timeout2, cancel := context.WithTimeout(ctx, 5*time.Second)
@@ -178,3 +184,113 @@ func TestFindDev(t *testing.T) {
 		assert.Equal(t, fmt.Sprintf("Unexpected entry in %s, not a major:minor symlink: a:b", tmp), err.Error())
 	}
 }
+
+func TestCreateTarget(t *testing.T) {
+	tmp, err := ioutil.TempDir("", "create-target")
+	require.NoError(t, err)
+	defer os.RemoveAll(tmp)
+
+	dir := filepath.Join(tmp, "mount", "target")
+	if assert.NoError(t, createTarget(dir, false)) {
+		info, err := os.Stat(dir)
+		require.NoError(t, err)
+		assert.True(t, info.IsDir(), "directory for file system")
+	}
+
+	file := filepath.Join(tmp, "block", "target")
+	if assert.NoError(t, createTarget(file, true)) {
+		info, err := os.Stat(file)
+		require.NoError(t, err)
+		assert.True(t, info.Mode().IsRegular(), "file for raw block")
+	}
+}
+
+func TestIsZeroed(t *testing.T) {
+	tmp, err := ioutil.TempDir("", "is-zeroed")
+	require.NoError(t, err)
+	defer os.RemoveAll(tmp)
+
+	for i, tc := range []struct {
+		content []byte
+		size    int64
+		zeroed  bool
+	}{
+		{nil, mib, true},
+		{make([]byte, 2*mib), mib, true},
+		{append(make([]byte, mib-1), 1), mib, false},
+		{append(make([]byte, mib), 1), mib, true},
+		{append(make([]byte, 100*kib), 0xEF, 0x53), mib, false},
+	} {
+		file := filepath.Join(tmp, fmt.Sprintf("device-%d", i))
+		require.NoError(t, ioutil.WriteFile(file, tc.content, 0644))
+		zeroed, err := isZeroed(file, tc.size)
+		if assert.NoError(t, err, "%d", i) {
+			assert.Equal(t, tc.zeroed, zeroed, "%d", i)
+		}
+	}
+
+	_, err = isZeroed(filepath.Join(tmp, "no-such-device"), mib)
+	assert.Error(t, err)
+}
+
+func TestCheckMountedDevice(t *testing.T) {
+	if os.Getuid() != 0 {
+		t.Skip("creating device nodes requires root")
+	}
+	tmp, err := ioutil.TempDir("", "mounted-device")
+	require.NoError(t, err)
+	defer os.RemoveAll(tmp)
+
+	// The temp directory stands in for a mounted file system.
+	var stat syscall.Stat_t
+	require.NoError(t, syscall.Stat(tmp, &stat))
+	device := filepath.Join(tmp, "device")
+	require.NoError(t, syscall.Mknod(device, syscall.S_IFBLK|0600, int(stat.Dev)))
+	other := filepath.Join(tmp, "other")
+	require.NoError(t, syscall.Mknod(other, syscall.S_IFBLK|0600, int(stat.Dev+1)))
+
+	// Already correctly mounted.
+	assert.NoError(t, checkMountedDevice(tmp, device, false))
+	assert.NoError(t, checkMountedDevice(device, device, true))
+
+	// Mounted, but the wrong device.
+	err = checkMountedDevice(tmp, other, false)
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err), "file system: %s", err)
+	err = checkMountedDevice(other, device, true)
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err), "block: %s", err)
+}
+
+func TestCheckFsType(t *testing.T) {
+	for _, fsType := range []string{"", "ext4", "xfs"} {
+		assert.NoError(t, checkFsType(fsType), fsType)
+	}
+	err := checkFsType("vfat")
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestCheckMountFlags(t *testing.T) {
+	assert.NoError(t, checkMountFlags(nil, false))
+	assert.NoError(t, checkMountFlags(nil, true))
+	assert.NoError(t, checkMountFlags([]string{"noatime", "rw"}, false))
+	assert.NoError(t, checkMountFlags([]string{"ro"}, true))
+	assert.Equal(t, codes.InvalidArgument, status.Code(checkMountFlags([]string{"ro", "rw"}, false)))
+	assert.Equal(t, codes.InvalidArgument, status.Code(checkMountFlags([]string{"rw"}, true)))
+}
+
+func TestNodeGetInfoWithoutRegistry(t *testing.T) {
+	defer testlog.SetGlobal(t)()
+
+	// The registry is not running, which must not prevent
+	// registering the node plugin.
+	dialOpts := func(endpoint string) ([]grpc.DialOption, error) {
+		return []grpc.DialOption{grpc.WithInsecure()}, nil
+	}
+	od := &oimDriver{
+		nodeID:   "host-0",
+		registry: oimcommon.NewFailover([]string{"unix:///no/such/oim-registry.sock"}, dialOpts, oimcommon.WithFailoverDialTimeout(100*time.Millisecond)),
+	}
+	info, err := od.NodeGetInfo(context.Background(), &csi.NodeGetInfoRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, "host-0", info.GetNodeId())
+	assert.Equal(t, int64(0), info.GetMaxVolumesPerNode())
+}
@@ -10,6 +10,8 @@ package oimcsidriver
 import (
 	"context"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/container-storage-interface/spec/lib/go/csi/v0"
 	"github.com/pkg/errors"
@@ -27,6 +29,9 @@ const (
 	tib int64 = gib * 1024
 )
 
+// DefaultDeviceTimeout is the default for WithDeviceTimeout.
+const DefaultDeviceTimeout = time.Minute
+
 // Driver is the public interface for managing the OIM CSI driver.
 type Driver interface {
 	Start(ctx context.Context) (*oimcommon.NonBlockingGRPCServer, error)
@@ -44,7 +49,12 @@ type oimDriver struct {
 	registryCA         string
 	registryKey        string
 	oimControllerID    string
+	deviceTimeout      time.Duration
+	minVolumeSize      int64
+	stagingDir         string
 	emulate            *EmulateCSIDriver
+	registry           *oimcommon.Failover
+	volumeParameters   volumeParameters
 
 	cap []*csi.ControllerServiceCapability
 	vc  []*csi.VolumeCapability_AccessMode
@@ -111,7 +121,9 @@ func WithVHostEndpoint(endpoint string) Option {
 }
 
 // WithOIMRegistryAddress sets the gRPC dial string for
-// contacting the OIM registry.
+// contacting the OIM registry. Several replicas of the
+// registry can be given as a comma-separated list, they
+// are then tried in turn until one is reachable.
 func WithOIMRegistryAddress(address string) Option {
 	return func(od *oimDriver) error {
 		od.oimRegistryAddress = address
@@ -138,6 +150,38 @@ func WithOIMControllerID(id string) Option {
 	}
 }
 
+// WithDeviceTimeout sets how long NodePublishVolume waits for the
+// block device of a volume to appear after the OIM controller mapped
+// it.
+func WithDeviceTimeout(timeout time.Duration) Option {
+	return func(od *oimDriver) error {
+		od.deviceTimeout = timeout
+		return nil
+	}
+}
+
+// WithMinVolumeSize sets the minimum size of new volumes. Smaller
+// requests are rounded up to it, unless that would exceed their limit.
+func WithMinVolumeSize(size int64) Option {
+	return func(od *oimDriver) error {
+		if size < 0 || size >= maxStorageCapacity {
+			return fmt.Errorf("invalid minimum volume size %d", size)
+		}
+		od.minVolumeSize = size
+		return nil
+	}
+}
+
+// WithStagingDir sets the directory which contains the staging
+// directories of volumes. It gets checked for orphaned
+// directories when the driver starts.
+func WithStagingDir(dir string) Option {
+	return func(od *oimDriver) error {
+		od.stagingDir = dir
+		return nil
+	}
+}
+
 // WithEmulation switches between different personalities:
 // in this mode, the OIM CSI driver handles arguments for
 // some other, "emulated" CSI driver and redirects local
@@ -160,10 +204,11 @@ func WithEmulation(csiDriverName string) Option {
 // New constructs a new OIM driver instance.
 func New(options ...Option) (Driver, error) {
 	od := oimDriver{
-		driverName:  "oim-driver",
-		version:     "unknown",
-		nodeID:      "unset-node-id",
-		csiEndpoint: "unix:///var/run/oim-driver.socket",
+		driverName:    "oim-driver",
+		version:       "unknown",
+		nodeID:        "unset-node-id",
+		csiEndpoint:   "unix:///var/run/oim-driver.socket",
+		deviceTimeout: DefaultDeviceTimeout,
 	}
 	for _, op := range options {
 		err := op(&od)
@@ -182,51 +227,87 @@ func New(options ...Option) (Driver, error) {
 		od.registryKey == "") {
 		return nil, errors.New("Cannot use a OIM registry without a controller ID, CA file and key file")
 	}
+	if od.oimRegistryAddress != "" {
+		od.registry = oimcommon.NewFailover(strings.Split(od.oimRegistryAddress, ","), od.registryDialOpts)
+	}
+	od.initCapabilities()
 	return &od, nil
 }
 
-func (od *oimDriver) Start(ctx context.Context) (*oimcommon.NonBlockingGRPCServer, error) {
-	// Determine capabilities.
+// initCapabilities determines what the driver advertises. When
+// emulating some other driver, its capabilities are reported
+// unchanged because both share the same driver name and the
+// controller operations are handled by that other driver. Otherwise
+// only the operations actually implemented for Malloc BDevs are
+// advertised.
+func (od *oimDriver) initCapabilities() {
 	if od.emulate != nil {
 		od.setControllerServiceCapabilities(od.emulate.ControllerServiceCapabilities)
 		od.setVolumeCapabilityAccessModes(od.emulate.VolumeCapabilityAccessModes)
-	} else {
-		// malloc fallback
-		od.setControllerServiceCapabilities([]csi.ControllerServiceCapability_RPC_Type{csi.ControllerServiceCapability_RPC_CREATE_DELETE_VOLUME})
-		od.setVolumeCapabilityAccessModes([]csi.VolumeCapability_AccessMode_Mode{csi.VolumeCapability_AccessMode_SINGLE_NODE_WRITER})
+		return
+	}
+	caps := []csi.ControllerServiceCapability_RPC_Type{
+		csi.ControllerServiceCapability_RPC_CREATE_DELETE_VOLUME,
+		csi.ControllerServiceCapability_RPC_LIST_VOLUMES,
+	}
+	if od.controllerPublishes() {
+		caps = append(caps, csi.ControllerServiceCapability_RPC_PUBLISH_UNPUBLISH_VOLUME)
 	}
+	od.setControllerServiceCapabilities(caps)
+	od.setVolumeCapabilityAccessModes([]csi.VolumeCapability_AccessMode_Mode{
+		csi.VolumeCapability_AccessMode_SINGLE_NODE_WRITER,
+		csi.VolumeCapability_AccessMode_SINGLE_NODE_READER_ONLY,
+	})
+}
 
-	s := oimcommon.NonBlockingGRPCServer{
+// server returns the gRPC server for the CSI endpoint and the
+// function which registers the CSI services.
+func (od *oimDriver) server() (*oimcommon.NonBlockingGRPCServer, oimcommon.RegisterService) {
+	s := &oimcommon.NonBlockingGRPCServer{
 		Endpoint: od.csiEndpoint,
 	}
-	s.Start(ctx, func(s *grpc.Server) {
+	return s, func(s *grpc.Server) {
 		csi.RegisterIdentityServer(s, od)
 		csi.RegisterNodeServer(s, od)
 		csi.RegisterControllerServer(s, od)
-	})
-	return &s, nil
+	}
+}
+
+func (od *oimDriver) Start(ctx context.Context) (*oimcommon.NonBlockingGRPCServer, error) {
+	od.sweepStagingDir(ctx)
+
+	s, service := od.server()
+	s.Start(ctx, service)
+	return s, nil
 }
 
+// Run serves until the context is canceled or the process receives
+// SIGINT or SIGTERM.
 func (od *oimDriver) Run(ctx context.Context) error {
-	s, err := od.Start(ctx)
-	if err != nil {
-		return err
-	}
-	s.Wait(ctx)
-	return nil
+	od.sweepStagingDir(ctx)
+
+	s, service := od.server()
+	return oimcommon.RunServer(ctx, s, []oimcommon.RegisterService{service})
 }
 
 func (od *oimDriver) DialRegistry(ctx context.Context) (*grpc.ClientConn, error) {
-	// Intentionally loaded anew for each connection attempt.
-	// File content can change over time.
-	transportCreds, err := oimcommon.LoadTLS(od.registryCA, od.registryKey, "component.registry")
+	conn, err := od.registry.Dial(ctx)
 	if err != nil {
-		return nil, errors.Wrap(err, "load TLS certs")
-	}
-	opts := oimcommon.ChooseDialOpts(od.oimRegistryAddress, grpc.WithTransportCredentials(transportCreds))
-	conn, err := grpc.Dial(od.oimRegistryAddress, opts...)
-	if err != nil {
-		return nil, errors.Wrapf(err, "connect to OIM registry at %s", od.oimRegistryAddress)
+		return nil, errors.Wrap(err, "connect to OIM registry")
 	}
 	return conn, nil
 }
+
+func (od *oimDriver) registryDialOpts(endpoint string) ([]grpc.DialOption, error) {
+	// All calls to the registry and the controllers behind it
+	// are idempotent and thus can be retried when the other side
+	// is temporarily unavailable, for example while restarting.
+	// Calls share the circuit breaker with the failover, so a
+	// registry which stops responding also gets skipped when
+	// connecting. The breaker comes first so that it sees the
+	// final result of a call instead of each failed attempt.
+	return oimcommon.DialOpts(endpoint,
+		oimcommon.WithTLSFiles(od.registryCA, od.registryKey, "component.registry"),
+		oimcommon.WithCircuitBreaker(od.registry.Breaker(endpoint)),
+		oimcommon.WithInterceptors(oimcommon.RetryUnaryClient()))
+}
@@ -15,6 +15,7 @@ import (
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"sync"
 	"testing"
 	"time"
 
@@ -50,7 +51,7 @@ func SetupSudoMount(t *testing.T) SudoMount {
 		tmpDir:     tmpDir,
 		searchPath: os.Getenv("PATH"),
 	}
-	for _, cmd := range []string{"mount", "umount", "blkid", "fsck", "mkfs.ext2", "mkfs.ext3", "mkfs.ext4"} {
+	for _, cmd := range []string{"mount", "umount", "blkid", "fsck", "mkfs.ext2", "mkfs.ext3", "mkfs.ext4", "mkfs.xfs"} {
 		wrapper := filepath.Join(s.tmpDir, cmd)
 		content := fmt.Sprintf(`#!/bin/sh
 PATH=%q
@@ -117,6 +118,11 @@ func TestSPDK(t *testing.T) {
 type MockController struct {
 	MapVolumes   []oim.MapVolumeRequest
 	UnmapVolumes []oim.UnmapVolumeRequest
+
+	mutex sync.Mutex
+	// BDevs maps from name to size, like the real controller
+	// would do for Malloc BDevs.
+	BDevs map[string]int64
 }
 
 func (m *MockController) MapVolume(ctx context.Context, in *oim.MapVolumeRequest) (*oim.MapVolumeReply, error) {
@@ -131,17 +137,53 @@ func (m *MockController) MapVolume(ctx context.Context, in *oim.MapVolumeRequest
 }
 
 func (m *MockController) UnmapVolume(ctx context.Context, in *oim.UnmapVolumeRequest) (*oim.UnmapVolumeReply, error) {
+	m.UnmapVolumes = append(m.UnmapVolumes, *in)
 	return &oim.UnmapVolumeReply{}, nil
 }
 
 func (m *MockController) ProvisionMallocBDev(ctx context.Context, in *oim.ProvisionMallocBDevRequest) (*oim.ProvisionMallocBDevReply, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	if m.BDevs == nil {
+		m.BDevs = map[string]int64{}
+	}
+	if in.Size_ == 0 {
+		delete(m.BDevs, in.BdevName)
+	} else if size, ok := m.BDevs[in.BdevName]; !ok {
+		m.BDevs[in.BdevName] = in.Size_
+	} else if size != in.Size_ {
+		return nil, status.Errorf(codes.AlreadyExists, "Existing BDev %s has wrong size %d", in.BdevName, size)
+	}
 	return &oim.ProvisionMallocBDevReply{}, nil
 }
 
 func (m *MockController) CheckMallocBDev(ctx context.Context, in *oim.CheckMallocBDevRequest) (*oim.CheckMallocBDevReply, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	if _, ok := m.BDevs[in.BdevName]; !ok {
+		return nil, status.Error(codes.NotFound, "")
+	}
 	return &oim.CheckMallocBDevReply{}, nil
 }
 
+func (m *MockController) ListMallocBDevs(ctx context.Context, in *oim.ListMallocBDevsRequest) (*oim.ListMallocBDevsReply, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	reply := &oim.ListMallocBDevsReply{}
+	for name, size := range m.BDevs {
+		reply.Bdevs = append(reply.Bdevs, &oim.MallocBDev{BdevName: name, Size_: size})
+	}
+	return reply, nil
+}
+
+func (m *MockController) GetNodeInfo(ctx context.Context, in *oim.GetNodeInfoRequest) (*oim.GetNodeInfoReply, error) {
+	return &oim.GetNodeInfoReply{}, nil
+}
+
+func (m *MockController) GetVersion(ctx context.Context, in *oim.GetVersionRequest) (*oim.GetVersionReply, error) {
+	return &oim.GetVersionReply{}, nil
+}
+
 // Runs tests with OIM registry and a mock controller.
 // This can only be used to test the communication paths, but not
 // the actual operation.
@@ -192,6 +234,7 @@ func TestMockOIM(t *testing.T) {
 		WithOIMRegistryAddress(registryAddress),
 		WithRegistryCreds(os.ExpandEnv("${TEST_WORK}/ca/ca.crt"), os.ExpandEnv("${TEST_WORK}/ca/host."+controllerID)),
 		WithOIMControllerID(controllerID),
+		WithNodeID(controllerID),
 	)
 	require.NoError(t, err)
 	s, err := driver.Start(ctx)
@@ -223,4 +266,159 @@ func TestMockOIM(t *testing.T) {
 		// What we can test reliably is that we get a DeadlineExceeded gRPC code.
 		assert.Equal(t, status.Convert(err).Code(), codes.DeadlineExceeded, fmt.Sprintf("expected DeadlineExceeded, got: %s", err))
 	}
+
+	// The external-provisioner retries calls when it doesn't get
+	// a response in time, so the same request may be seen
+	// several times, also concurrently.
+	controllerClient := csi.NewControllerClient(conn)
+	retries := 10
+	capabilities := []*csi.VolumeCapability{{
+		AccessType: &csi.VolumeCapability_Mount{Mount: &csi.VolumeCapability_MountVolume{}},
+		AccessMode: &csi.VolumeCapability_AccessMode{Mode: csi.VolumeCapability_AccessMode_SINGLE_NODE_WRITER},
+	}}
+	create := &csi.CreateVolumeRequest{
+		Name:               volumeID,
+		CapacityRange:      &csi.CapacityRange{RequiredBytes: 1000},
+		VolumeCapabilities: capabilities,
+	}
+	storm := func(call func() error) []error {
+		var wg sync.WaitGroup
+		errs := make([]error, retries)
+		for i := 0; i < retries; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				errs[i] = call()
+			}(i)
+		}
+		wg.Wait()
+		return errs
+	}
+	capacities := make(chan int64, retries)
+	for _, err := range storm(func() error {
+		response, err := controllerClient.CreateVolume(ctx, create)
+		if err == nil {
+			capacities <- response.GetVolume().GetCapacityBytes()
+		}
+		return err
+	}) {
+		assert.NoError(t, err, "CreateVolume")
+	}
+	close(capacities)
+	for capacity := range capacities {
+		assert.Equal(t, int64(1024), capacity, "capacity")
+	}
+	assert.Equal(t, map[string]int64{volumeID: 1024}, controller.BDevs)
+
+	_, err = controllerClient.CreateVolume(ctx, &csi.CreateVolumeRequest{
+		Name:               volumeID,
+		CapacityRange:      &csi.CapacityRange{RequiredBytes: 2 * 1024 * 1024},
+		VolumeCapabilities: capabilities,
+	})
+	assert.Equal(t, codes.AlreadyExists, status.Code(err), "CreateVolume with different size: %s", err)
+
+	// The existing volume is used when it fits into the range,
+	// even if a new volume would get a different size.
+	response, err := controllerClient.CreateVolume(ctx, &csi.CreateVolumeRequest{
+		Name:               volumeID,
+		CapacityRange:      &csi.CapacityRange{RequiredBytes: 1, LimitBytes: 4096},
+		VolumeCapabilities: capabilities,
+	})
+	if assert.NoError(t, err, "CreateVolume with compatible range") {
+		assert.Equal(t, int64(1024), response.GetVolume().GetCapacityBytes(), "capacity")
+	}
+	assert.Equal(t, map[string]int64{volumeID: 1024}, controller.BDevs)
+
+	_, err = controllerClient.CreateVolume(ctx, &csi.CreateVolumeRequest{
+		Name:               volumeID,
+		CapacityRange:      create.CapacityRange,
+		VolumeCapabilities: capabilities,
+		Parameters:         map[string]string{FsTypeParameter: "xfs"},
+	})
+	assert.Equal(t, codes.AlreadyExists, status.Code(err), "CreateVolume with different parameters: %s", err)
+
+	validate := &csi.ValidateVolumeCapabilitiesRequest{
+		VolumeId:           volumeID,
+		VolumeCapabilities: capabilities,
+	}
+	validated, err := controllerClient.ValidateVolumeCapabilities(ctx, validate)
+	if assert.NoError(t, err, "ValidateVolumeCapabilities") {
+		assert.True(t, validated.GetSupported(), "supported: %s", validated.GetMessage())
+	}
+
+	publish := &csi.ControllerPublishVolumeRequest{
+		VolumeId:         volumeID,
+		NodeId:           controllerID,
+		VolumeCapability: capabilities[0],
+	}
+	for i := 0; i < 2; i++ {
+		published, err := controllerClient.ControllerPublishVolume(ctx, publish)
+		if assert.NoError(t, err, "ControllerPublishVolume #%d", i) {
+			assert.Equal(t, map[string]string{
+				publishInfoPCIAddress: "0000:08:07.0",
+				publishInfoSCSITarget: "0",
+				publishInfoSCSILUN:    "0",
+			}, published.GetPublishInfo())
+		}
+	}
+	publish.NodeId = "host-1"
+	_, err = controllerClient.ControllerPublishVolume(ctx, publish)
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err), "ControllerPublishVolume for other node: %s", err)
+	controller.UnmapVolumes = nil
+	_, err = controllerClient.ControllerUnpublishVolume(ctx, &csi.ControllerUnpublishVolumeRequest{
+		VolumeId: volumeID,
+		NodeId:   "host-1",
+	})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err), "ControllerUnpublishVolume for other node: %s", err)
+	assert.Empty(t, controller.UnmapVolumes, "ControllerUnpublishVolume for other node")
+	_, err = controllerClient.ControllerUnpublishVolume(ctx, &csi.ControllerUnpublishVolumeRequest{
+		VolumeId: volumeID,
+		NodeId:   controllerID,
+	})
+	assert.NoError(t, err, "ControllerUnpublishVolume")
+	assert.Equal(t, []oim.UnmapVolumeRequest{{VolumeId: volumeID}}, controller.UnmapVolumes)
+
+	for _, err := range storm(func() error {
+		_, err := controllerClient.DeleteVolume(ctx, &csi.DeleteVolumeRequest{VolumeId: volumeID})
+		return err
+	}) {
+		assert.NoError(t, err, "DeleteVolume")
+	}
+	assert.Empty(t, controller.BDevs)
+
+	_, err = controllerClient.ValidateVolumeCapabilities(ctx, validate)
+	assert.Equal(t, codes.NotFound, status.Code(err), "ValidateVolumeCapabilities for deleted volume: %s", err)
+
+	// Ephemeral volumes get created by NodePublishVolume, which
+	// then times out as above, and deleted by NodeUnpublishVolume.
+	ephemeralID := "csi-ephemeral"
+	ephemeralTarget := tmp + "/ephemeral"
+	deadline, cancel = context.WithTimeout(ctx, time.Second)
+	defer cancel()
+	_, err = csiClient.NodePublishVolume(deadline,
+		&csi.NodePublishVolumeRequest{
+			VolumeId:         ephemeralID,
+			TargetPath:       ephemeralTarget,
+			VolumeCapability: capabilities[0],
+			VolumeAttributes: map[string]string{
+				EphemeralAttribute:     "true",
+				EphemeralSizeAttribute: "2Ki",
+			},
+		})
+	assert.Equal(t, codes.DeadlineExceeded, status.Code(err), "NodePublishVolume for ephemeral volume: %s", err)
+	assert.Equal(t, map[string]int64{ephemeralBDevName(ephemeralID): 2048}, controller.BDevs)
+	listed, err := controllerClient.ListVolumes(ctx, &csi.ListVolumesRequest{})
+	if assert.NoError(t, err, "ListVolumes") {
+		assert.Empty(t, listed.GetEntries(), "ephemeral volumes are not listed")
+	}
+	controller.UnmapVolumes = nil
+	for i := 0; i < 2; i++ {
+		_, err = csiClient.NodeUnpublishVolume(ctx, &csi.NodeUnpublishVolumeRequest{
+			VolumeId:   ephemeralID,
+			TargetPath: ephemeralTarget,
+		})
+		assert.NoError(t, err, "NodeUnpublishVolume #%d", i)
+	}
+	assert.Equal(t, []oim.UnmapVolumeRequest{{VolumeId: ephemeralBDevName(ephemeralID)}}, controller.UnmapVolumes)
+	assert.Empty(t, controller.BDevs)
 }
@@ -0,0 +1,150 @@
+/*
+Copyright (C) 2018 Intel Corporation.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package oimcsidriver
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// FsTypeParameter is the StorageClass parameter for the file system
+// type. The external-provisioner copies it into the volume
+// capability; it is accepted in any case (fstype, FSType, ...).
+const FsTypeParameter = "fsType"
+
+// sidecarParameterPrefix is the prefix of parameters which are
+// meant for the Kubernetes sidecars and not for the driver.
+const sidecarParameterPrefix = "csi.storage.k8s.io/"
+
+// sidecarParameters are the parameters for the sidecars which
+// predate sidecarParameterPrefix.
+var sidecarParameters = map[string]bool{
+	"csiProvisionerSecretName":            true,
+	"csiProvisionerSecretNamespace":       true,
+	"csiControllerPublishSecretName":      true,
+	"csiControllerPublishSecretNamespace": true,
+	"csiNodeStageSecretName":              true,
+	"csiNodeStageSecretNamespace":         true,
+	"csiNodePublishSecretName":            true,
+	"csiNodePublishSecretNamespace":       true,
+}
+
+// parameters maps the known StorageClass parameters to a function
+// which checks the value and returns it in normalized form.
+var parameters = map[string]func(value string) (string, error){
+	FsTypeParameter: func(value string) (string, error) {
+		value = strings.ToLower(value)
+		if err := checkFsType(value); err != nil {
+			return "", fmt.Errorf("unsupported file system type %q", value)
+		}
+		return value, nil
+	},
+	MkfsOptionsAttribute: func(value string) (string, error) {
+		return strings.Join(strings.Fields(value), " "), nil
+	},
+}
+
+// validateParameters checks the parameters of CreateVolume and
+// returns them in normalized form, which then become the volume
+// attributes that NodePublishVolume gets. Parameters for the sidecars
+// are skipped. Unknown or malformed parameters are reported together
+// in one InvalidArgument error.
+func validateParameters(params map[string]string) (map[string]string, error) {
+	var unknown, malformed []string
+	var normalized map[string]string
+	for key, value := range params {
+		if strings.EqualFold(key, FsTypeParameter) {
+			key = FsTypeParameter
+		}
+		normalize, ok := parameters[key]
+		if !ok {
+			if !strings.HasPrefix(key, sidecarParameterPrefix) && !sidecarParameters[key] {
+				unknown = append(unknown, key)
+			}
+			continue
+		}
+		value, err := normalize(value)
+		if err != nil {
+			malformed = append(malformed, fmt.Sprintf("%s: %s", key, err))
+			continue
+		}
+		if normalized == nil {
+			normalized = map[string]string{}
+		}
+		normalized[key] = value
+	}
+	if len(unknown) == 0 && len(malformed) == 0 {
+		return normalized, nil
+	}
+	var problems []string
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		problems = append(problems, "unknown parameters: "+strings.Join(unknown, ", "))
+	}
+	if len(malformed) > 0 {
+		sort.Strings(malformed)
+		problems = append(problems, "malformed parameters: "+strings.Join(malformed, ", "))
+	}
+	return nil, status.Error(codes.InvalidArgument, strings.Join(problems, "; "))
+}
+
+// volumeParameters remembers the normalized parameters of the volumes
+// created by the driver, because a CreateVolume call for an existing
+// volume must fail when the parameters are different. Malloc BDevs
+// cannot store them, so this only lasts as long as the driver runs.
+// After a restart, the parameters of older volumes are unknown and
+// any parameters are accepted for them. Retries of the
+// external-provisioner use the parameters of the same StorageClass,
+// so a mismatch is only missed when the StorageClass gets replaced
+// while the driver restarts.
+type volumeParameters struct {
+	mutex  sync.Mutex
+	params map[string]map[string]string
+}
+
+// matches returns false if the volume was created with different
+// parameters.
+func (vp *volumeParameters) matches(name string, params map[string]string) bool {
+	vp.mutex.Lock()
+	defer vp.mutex.Unlock()
+
+	known, ok := vp.params[name]
+	if !ok {
+		return true
+	}
+	if len(known) != len(params) {
+		return false
+	}
+	for key, value := range params {
+		if known[key] != value {
+			return false
+		}
+	}
+	return true
+}
+
+func (vp *volumeParameters) set(name string, params map[string]string) {
+	vp.mutex.Lock()
+	defer vp.mutex.Unlock()
+
+	if vp.params == nil {
+		vp.params = map[string]map[string]string{}
+	}
+	vp.params[name] = params
+}
+
+func (vp *volumeParameters) remove(name string) {
+	vp.mutex.Lock()
+	defer vp.mutex.Unlock()
+
+	delete(vp.params, name)
+}
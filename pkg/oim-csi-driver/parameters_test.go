@@ -0,0 +1,55 @@
+/*
+Copyright 2018 Intel Corporation.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package oimcsidriver
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestValidateParameters(t *testing.T) {
+	for i, tc := range []struct {
+		params     map[string]string
+		normalized map[string]string
+		message    string
+	}{
+		{nil, nil, ""},
+		{
+			map[string]string{"fstype": "XFS", MkfsOptionsAttribute: "  -b   size=1024 "},
+			map[string]string{FsTypeParameter: "xfs", MkfsOptionsAttribute: "-b size=1024"},
+			"",
+		},
+		{
+			map[string]string{"csiProvisionerSecretName": "secret", "csi.storage.k8s.io/fstype": "ext4"},
+			nil,
+			"",
+		},
+		{
+			map[string]string{"csiFsType": "ext4", "csimkfsOptions": "-b 1024", "csiProvisionerSecretNam": "secret"},
+			nil,
+			`unknown parameters: csiFsType, csiProvisionerSecretNam, csimkfsOptions`,
+		},
+		{
+			map[string]string{"mkfsOption": "-b 1024", "fsTyp": "ext4", FsTypeParameter: "nfs"},
+			nil,
+			`unknown parameters: fsTyp, mkfsOption; malformed parameters: fsType: unsupported file system type "nfs"`,
+		},
+	} {
+		normalized, err := validateParameters(tc.params)
+		if tc.message == "" {
+			if assert.NoError(t, err, "%d", i) {
+				assert.Equal(t, tc.normalized, normalized, "%d", i)
+			}
+		} else {
+			assert.Equal(t, codes.InvalidArgument, status.Code(err), "%d", i)
+			assert.Equal(t, tc.message, status.Convert(err).Message(), "%d", i)
+		}
+	}
+}
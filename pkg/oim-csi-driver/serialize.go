@@ -7,10 +7,115 @@ SPDX-License-Identifier: Apache-2.0
 package oimcsidriver
 
 import (
-	"k8s.io/kubernetes/pkg/util/keymutex" // TODO: move to k8s.io/utils (https://github.com/kubernetes/utils/issues/62)
+	"context"
+	"sync"
+
+	"google.golang.org/grpc/status"
+
+	"github.com/intel/oim/pkg/log"
 )
 
+// volumeLocks serializes operations by volume name. In contrast to
+// keymutex.NewHashed, different volumes never block each other and
+// waiting for a lock ends when the context is done.
+type volumeLocks struct {
+	mutex sync.Mutex
+	// locks has a channel for each locked volume which gets
+	// closed when the lock is released.
+	locks map[string]chan struct{}
+}
+
 var (
 	// Volume names are the keys.
-	volumeNameMutex = keymutex.NewHashed(-1)
+	volumeNameMutex = &volumeLocks{locks: map[string]chan struct{}{}}
 )
+
+// lock blocks until the volume is not locked anymore or the context
+// is done. On success, the returned function must be called to
+// release the lock.
+func (vl *volumeLocks) lock(ctx context.Context, name string) (func(), error) {
+	for {
+		vl.mutex.Lock()
+		released, locked := vl.locks[name]
+		if !locked {
+			vl.locks[name] = make(chan struct{})
+			vl.mutex.Unlock()
+			return func() { vl.unlock(name) }, nil
+		}
+		vl.mutex.Unlock()
+
+		select {
+		case <-released:
+			// Try again, someone else might have been faster.
+		case <-ctx.Done():
+			return nil, contextError(ctx)
+		}
+	}
+}
+
+func (vl *volumeLocks) unlock(name string) {
+	vl.mutex.Lock()
+	defer vl.mutex.Unlock()
+	close(vl.locks[name])
+	delete(vl.locks, name)
+}
+
+// contextError turns the error of a context that is done into a gRPC
+// status error, i.e. DeadlineExceeded or Canceled.
+func contextError(ctx context.Context) error {
+	return status.FromContextError(ctx.Err()).Err()
+}
+
+// serialize runs the operation while holding the lock for the volume
+// and returns its result. When the context is done before the
+// operation completes, the call returns with DeadlineExceeded or
+// Canceled right away. The operation must use the same context and
+// stop soon after that; it keeps the volume locked until it returns.
+func serialize(ctx context.Context, name string, operation func() (interface{}, error)) (interface{}, error) {
+	unlock, err := volumeNameMutex.lock(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	done := make(chan operationResult, 1)
+	go func() {
+		defer unlock()
+		response, err := operation()
+		done <- operationResult{response, err}
+	}()
+	select {
+	case r := <-done:
+		return r.get(ctx)
+	case <-ctx.Done():
+	}
+	select {
+	case r := <-done:
+		// Completed just in time.
+		return r.get(ctx)
+	default:
+	}
+	log.FromContext(ctx).Warnw("volume operation interrupted, waiting for it in the background", "volumeid", name, "error", ctx.Err())
+	go func() {
+		if r := <-done; r.err != nil {
+			log.FromContext(ctx).Warnw("interrupted volume operation failed", "volumeid", name, "error", r.err)
+		} else {
+			log.FromContext(ctx).Infow("interrupted volume operation completed", "volumeid", name)
+		}
+	}()
+	return nil, contextError(ctx)
+}
+
+type operationResult struct {
+	response interface{}
+	err      error
+}
+
+// get returns the result of an operation. A failed operation is
+// reported as DeadlineExceeded or Canceled when the context is done,
+// because then the failure was most likely caused by interrupting
+// the operation.
+func (r operationResult) get(ctx context.Context) (interface{}, error) {
+	if r.err != nil && ctx.Err() != nil {
+		return nil, contextError(ctx)
+	}
+	return r.response, r.err
+}
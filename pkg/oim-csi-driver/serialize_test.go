@@ -0,0 +1,94 @@
+/*
+Copyright 2018 Intel Corporation.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package oimcsidriver
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestSerialize(t *testing.T) {
+	ctx := context.Background()
+
+	// A stuck operation gets abandoned when the deadline is reached.
+	stuck := make(chan struct{})
+	finished := make(chan struct{})
+	timeoutCtx, cancel := context.WithTimeout(ctx, 100*time.Millisecond)
+	defer cancel()
+	response, err := serialize(timeoutCtx, "vol-1", func() (interface{}, error) {
+		<-stuck
+		close(finished)
+		return "too late", nil
+	})
+	assert.Equal(t, codes.DeadlineExceeded, status.Code(err), "stuck operation: %s", err)
+	assert.Nil(t, response, "stuck operation")
+
+	// It still holds the lock, so other operations for the same
+	// volume time out, too, or get canceled...
+	timeoutCtx, cancel = context.WithTimeout(ctx, 100*time.Millisecond)
+	defer cancel()
+	_, err = serialize(timeoutCtx, "vol-1", func() (interface{}, error) {
+		t.Error("must not run while vol-1 is locked")
+		return nil, nil
+	})
+	assert.Equal(t, codes.DeadlineExceeded, status.Code(err), "same volume: %s", err)
+	cancelCtx, cancel := context.WithCancel(ctx)
+	time.AfterFunc(100*time.Millisecond, cancel)
+	_, err = serialize(cancelCtx, "vol-1", func() (interface{}, error) {
+		t.Error("must not run while vol-1 is locked")
+		return nil, nil
+	})
+	assert.Equal(t, codes.Canceled, status.Code(err), "canceled: %s", err)
+
+	// ... while other volumes are not affected.
+	response, err = serialize(ctx, "vol-2", func() (interface{}, error) {
+		return "partial", errors.New("failed")
+	})
+	assert.EqualError(t, err, "failed", "other volume")
+	assert.Equal(t, "partial", response, "other volume")
+
+	// Once the stuck operation completes, the volume can be used again.
+	close(stuck)
+	<-finished
+	response, err = serialize(ctx, "vol-1", func() (interface{}, error) {
+		return "done", nil
+	})
+	assert.NoError(t, err, "after unlock")
+	assert.Equal(t, "done", response, "after unlock")
+}
+
+func TestSerializeInterrupt(t *testing.T) {
+	// The operation uses the same context and stops when it is
+	// done. The response that it produces after the deadline
+	// must not be visible to the caller (checked by -race).
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	stopped := make(chan struct{})
+	var response *struct{ value string }
+	result, err := serialize(ctx, "vol-3", func() (interface{}, error) {
+		defer close(stopped)
+		<-ctx.Done()
+		response = &struct{ value string }{"canceled"}
+		return response, ctx.Err()
+	})
+	assert.Equal(t, codes.DeadlineExceeded, status.Code(err), "interrupted operation: %s", err)
+	assert.Nil(t, result, "interrupted operation")
+	<-stopped
+
+	// The lock was released by the operation.
+	result, err = serialize(context.Background(), "vol-3", func() (interface{}, error) {
+		return "unlocked", nil
+	})
+	assert.NoError(t, err, "after interrupt")
+	assert.Equal(t, "unlocked", result, "after interrupt")
+}
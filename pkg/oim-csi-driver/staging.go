@@ -0,0 +1,119 @@
+/*
+Copyright (C) 2018 Intel Corporation.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package oimcsidriver
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+
+	"github.com/intel/oim/pkg/log"
+	"github.com/intel/oim/pkg/mount"
+)
+
+// unmount unmounts the path if it is a mount point. A path that
+// does not exist or is not mounted is not an error, so calling this
+// repeatedly is fine.
+func unmount(ctx context.Context, path string) error {
+	mounter := mount.NewWithContext(ctx, "")
+	notMnt, err := mounter.IsLikelyNotMountPoint(path)
+	switch {
+	case os.IsNotExist(err):
+		return nil
+	case err != nil && !mount.IsCorruptedMnt(err):
+		return err
+	case err == nil && notMnt:
+		return nil
+	}
+	log.FromContext(ctx).Infow("unmount", "target", path)
+	return mounter.Unmount(path)
+}
+
+// unmountAndRemove unmounts the path if necessary and then removes
+// it, also idempotently.
+func unmountAndRemove(ctx context.Context, path string) error {
+	if err := unmount(ctx, path); err != nil {
+		return errors.Wrapf(err, "unmount %s", path)
+	}
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// findOrphanedStagingDirs returns all empty directories under the
+// base directory which are not mount points. The layout below the
+// base directory does not matter, and mount points are not entered.
+// Such directories are left behind when a volume was not unstaged
+// properly, for example because of a crash.
+func findOrphanedStagingDirs(base string) ([]string, error) {
+	var orphaned []string
+	mounter := mount.New("")
+	var walk func(dir string) error
+	walk = func(dir string) error {
+		entries, err := ioutil.ReadDir(dir)
+		if err != nil {
+			return err
+		}
+		for _, entry := range entries {
+			if !entry.IsDir() {
+				continue
+			}
+			path := filepath.Join(dir, entry.Name())
+			notMnt, err := mounter.IsLikelyNotMountPoint(path)
+			if err != nil || !notMnt {
+				// Mounted, perhaps stale. Left alone
+				// because it might be in use.
+				continue
+			}
+			empty, err := isEmptyDir(path)
+			if err != nil {
+				return err
+			}
+			if empty {
+				orphaned = append(orphaned, path)
+				continue
+			}
+			if err := walk(path); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if err := walk(base); err != nil {
+		return nil, errors.Wrapf(err, "scan staging directory %s", base)
+	}
+	return orphaned, nil
+}
+
+func isEmptyDir(path string) (bool, error) {
+	entries, err := ioutil.ReadDir(path)
+	if err != nil {
+		return false, err
+	}
+	return len(entries) == 0, nil
+}
+
+// sweepStagingDir logs all orphaned staging directories. They are
+// not removed automatically, because kubelet might still be about
+// to use them.
+func (od *oimDriver) sweepStagingDir(ctx context.Context) {
+	if od.stagingDir == "" {
+		return
+	}
+	orphaned, err := findOrphanedStagingDirs(od.stagingDir)
+	if err != nil {
+		log.FromContext(ctx).Warnw("checking for orphaned staging directories failed", "error", err)
+		return
+	}
+	for _, dir := range orphaned {
+		log.FromContext(ctx).Warnw("orphaned staging directory", "path", dir)
+	}
+}
@@ -0,0 +1,58 @@
+/*
+Copyright 2018 Intel Corporation.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package oimcsidriver
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/container-storage-interface/spec/lib/go/csi/v0"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStaging(t *testing.T) {
+	ctx := context.Background()
+	tmp, err := ioutil.TempDir("", "staging")
+	require.NoError(t, err)
+	defer os.RemoveAll(tmp)
+
+	mkdir := func(path string) string {
+		path = filepath.Join(tmp, path)
+		require.NoError(t, os.MkdirAll(path, 0755))
+		return path
+	}
+	orphaned1 := mkdir("pv-1/globalmount")
+	orphaned2 := mkdir("pv-2")
+	mkdir("pv-3")
+	require.NoError(t, ioutil.WriteFile(filepath.Join(tmp, "pv-3", "data"), nil, 0644))
+
+	orphaned, err := findOrphanedStagingDirs(tmp)
+	require.NoError(t, err)
+	assert.Equal(t, []string{orphaned1, orphaned2}, orphaned)
+
+	// Unstaging is idempotent.
+	od := &oimDriver{}
+	for i := 0; i < 2; i++ {
+		_, err := od.NodeUnstageVolume(ctx, &csi.NodeUnstageVolumeRequest{
+			VolumeId:          "pv-1",
+			StagingTargetPath: orphaned1,
+		})
+		require.NoError(t, err, "NodeUnstageVolume #%d", i)
+		_, err = os.Stat(orphaned1)
+		assert.True(t, os.IsNotExist(err), "%s removed", orphaned1)
+	}
+	orphaned, err = findOrphanedStagingDirs(tmp)
+	require.NoError(t, err)
+	assert.Equal(t, []string{filepath.Dir(orphaned1), orphaned2}, orphaned)
+
+	_, err = findOrphanedStagingDirs(filepath.Join(tmp, "no-such-dir"))
+	assert.Error(t, err)
+}
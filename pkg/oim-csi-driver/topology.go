@@ -0,0 +1,67 @@
+/*
+Copyright (C) 2018 Intel Corporation.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package oimcsidriver
+
+import (
+	"github.com/container-storage-interface/spec/lib/go/csi/v0"
+)
+
+// TopologyKeyNode is the topology segment which identifies the node
+// on which volumes are accessible. Malloc BDevs only exist in the
+// SPDK instance of the node where they were created.
+const TopologyKeyNode = "intel.com/oim-node"
+
+// topology returns the topology of volumes provisioned by this driver
+// instance, nil when emulating some other CSI driver which has its
+// own notion of where volumes are accessible.
+func (od *oimDriver) topology() *csi.Topology {
+	if od.emulate != nil {
+		return nil
+	}
+	return &csi.Topology{
+		Segments: map[string]string{
+			TopologyKeyNode: od.nodeID,
+		},
+	}
+}
+
+// accessibleTopology returns the topology for csi.Volume.
+func (od *oimDriver) accessibleTopology() []*csi.Topology {
+	if topology := od.topology(); topology != nil {
+		return []*csi.Topology{topology}
+	}
+	return nil
+}
+
+// satisfiesTopology checks whether volumes created by this driver
+// instance can fulfill the requirements. Volumes can only be created
+// locally, so only the requisite topologies matter: preferred
+// topologies are a subset of those or, when there are no requisite
+// topologies, merely a hint.
+func (od *oimDriver) satisfiesTopology(requirement *csi.TopologyRequirement) bool {
+	topology := od.topology()
+	if topology == nil || len(requirement.GetRequisite()) == 0 {
+		return true
+	}
+	for _, requisite := range requirement.GetRequisite() {
+		if matchesTopology(topology, requisite) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesTopology returns true if all segments in the requested
+// topology have the same value in the actual topology.
+func matchesTopology(actual, requested *csi.Topology) bool {
+	for key, value := range requested.GetSegments() {
+		if v, ok := actual.GetSegments()[key]; !ok || v != value {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,65 @@
+/*
+Copyright 2018 Intel Corporation.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package oimcsidriver
+
+import (
+	"context"
+	"testing"
+
+	"github.com/container-storage-interface/spec/lib/go/csi/v0"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestTopology(t *testing.T) {
+	od := &oimDriver{nodeID: "host-0"}
+	node := func(id string) *csi.Topology {
+		return &csi.Topology{Segments: map[string]string{TopologyKeyNode: id}}
+	}
+
+	info, err := od.NodeGetInfo(context.Background(), &csi.NodeGetInfoRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, node("host-0"), info.GetAccessibleTopology())
+
+	caps, err := od.GetPluginCapabilities(context.Background(), &csi.GetPluginCapabilitiesRequest{})
+	require.NoError(t, err)
+	var types []csi.PluginCapability_Service_Type
+	for _, cap := range caps.GetCapabilities() {
+		types = append(types, cap.GetService().GetType())
+	}
+	assert.Contains(t, types, csi.PluginCapability_Service_ACCESSIBILITY_CONSTRAINTS)
+
+	for i, tc := range []struct {
+		requirement *csi.TopologyRequirement
+		satisfied   bool
+	}{
+		{nil, true},
+		{&csi.TopologyRequirement{Preferred: []*csi.Topology{node("host-1")}}, true},
+		{&csi.TopologyRequirement{Requisite: []*csi.Topology{node("host-0")}}, true},
+		{&csi.TopologyRequirement{Requisite: []*csi.Topology{node("host-1"), node("host-0")}}, true},
+		{&csi.TopologyRequirement{Requisite: []*csi.Topology{node("host-1")}}, false},
+		{&csi.TopologyRequirement{Requisite: []*csi.Topology{{Segments: map[string]string{"zone": "a"}}}}, false},
+	} {
+		assert.Equal(t, tc.satisfied, od.satisfiesTopology(tc.requirement), "%d: %s", i, tc.requirement)
+	}
+
+	_, err = od.CreateVolume(context.Background(), &csi.CreateVolumeRequest{
+		Name:                      "volume",
+		VolumeCapabilities:        []*csi.VolumeCapability{{AccessMode: &csi.VolumeCapability_AccessMode{Mode: csi.VolumeCapability_AccessMode_SINGLE_NODE_WRITER}}},
+		AccessibilityRequirements: &csi.TopologyRequirement{Requisite: []*csi.Topology{node("host-1")}},
+	})
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err), "CreateVolume on other node")
+
+	// No topology when emulating some other driver.
+	od.emulate = &EmulateCSIDriver{}
+	info, err = od.NodeGetInfo(context.Background(), &csi.NodeGetInfoRequest{})
+	require.NoError(t, err)
+	assert.Nil(t, info.GetAccessibleTopology())
+	assert.True(t, od.satisfiesTopology(&csi.TopologyRequirement{Requisite: []*csi.Topology{node("host-1")}}))
+}
@@ -0,0 +1,188 @@
+/*
+Copyright (C) 2018 Intel Corporation.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package oimregistry
+
+import (
+	"context"
+	"io"
+	"sort"
+	"time"
+
+	"github.com/gogo/protobuf/types"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/intel/oim/pkg/log"
+	"github.com/intel/oim/pkg/oim-common"
+	"github.com/intel/oim/pkg/spec/oim/v0"
+)
+
+func (r *registry) Export(in *oim.ExportRequest, stream oim.Registry_ExportServer) error {
+	ctx := stream.Context()
+	peer, err := checkAdmin(ctx, "export")
+	if err != nil {
+		return err
+	}
+	if err := r.limits.allow(peer, false); err != nil {
+		return err
+	}
+
+	var values []*oim.Value
+	r.db.Foreach(func(key, value string) bool {
+		values = append(values, &oim.Value{
+			Path:  key,
+			Value: value,
+		})
+		return true
+	})
+	sort.Slice(values, func(i, j int) bool {
+		return values[i].Path < values[j].Path
+	})
+	for _, value := range values {
+		r.changes.addTTL(value)
+		r.changes.addInfo(value)
+		if err := stream.Send(value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *registry) Import(stream oim.Registry_ImportServer) error {
+	ctx := stream.Context()
+	peer, err := checkAdmin(ctx, "import")
+	if err != nil {
+		return err
+	}
+	if err := r.limits.allow(peer, true); err != nil {
+		return err
+	}
+
+	// Everything gets validated before applying any change.
+	var entries []restoredEntry
+	overwrite := false
+	for first := true; ; first = false {
+		in, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		if first {
+			overwrite = in.GetOverwrite()
+		}
+		for _, value := range in.GetValues() {
+			elements, err := oimcommon.SplitRegistryPath(value.GetPath())
+			if err != nil {
+				return status.Errorf(codes.InvalidArgument, "%q: %s", value.GetPath(), err)
+			}
+			if len(elements) == 0 {
+				return status.Error(codes.InvalidArgument, "empty path")
+			}
+			key := oimcommon.JoinRegistryPath(elements)
+			if value.GetValue() == "" {
+				return status.Errorf(codes.InvalidArgument, "%q: empty value", key)
+			}
+			ttl := time.Duration(value.GetTtlSeconds()) * time.Second
+			remaining := time.Duration(value.GetRemainingTtlSeconds()) * time.Second
+			if ttl < 0 {
+				return status.Errorf(codes.InvalidArgument, "%q: negative TTL %s", key, ttl)
+			}
+			if remaining < 0 {
+				return status.Errorf(codes.InvalidArgument, "%q: negative remaining TTL %s", key, remaining)
+			}
+			// Exports without the TTL can only be restored
+			// with the remaining time as new TTL.
+			if ttl == 0 {
+				ttl = remaining
+			}
+			if remaining == 0 {
+				remaining = ttl
+			}
+			entry := restoredEntry{
+				update: update{
+					key:   key,
+					value: value.GetValue(),
+					ttl:   ttl,
+					peer:  peer,
+				},
+				remaining: remaining,
+			}
+			// Without metadata, the entry is treated like
+			// a new one created by the caller.
+			if value.GetUpdatedBy() != "" {
+				entry.info = &entryInfo{
+					updatedBy: value.GetUpdatedBy(),
+				}
+				if entry.info.created, err = types.TimestampFromProto(value.GetCreated()); err != nil {
+					return status.Errorf(codes.InvalidArgument, "%q: created: %s", key, err)
+				}
+				if entry.info.updated, err = types.TimestampFromProto(value.GetUpdated()); err != nil {
+					return status.Errorf(codes.InvalidArgument, "%q: updated: %s", key, err)
+				}
+			}
+			entries = append(entries, entry)
+		}
+	}
+
+	imported, skipped := r.changes.restore(r.db, overwrite, entries)
+	log.FromContext(ctx).Infow("registry import",
+		"peer", peer,
+		"overwrite", overwrite,
+		"imported", imported,
+		"skipped", skipped)
+	return stream.SendAndClose(&oim.ImportReply{
+		Imported: int32(imported),
+		Skipped:  int32(skipped),
+	})
+}
+
+// checkAdmin ensures that the caller is the admin.
+func checkAdmin(ctx context.Context, operation string) (string, error) {
+	peer, err := getPeer(ctx)
+	if err != nil {
+		return "", err
+	}
+	if peer != "user.admin" {
+		return "", status.Errorf(codes.PermissionDenied, "caller %q not allowed to %s", peer, operation)
+	}
+	return peer, nil
+}
+
+// restoredEntry is an update from Import, with the state of the
+// exported entry that gets preserved.
+type restoredEntry struct {
+	update
+	// remaining is the time until the entry expires, ttl
+	// is used for renewing it.
+	remaining time.Duration
+	// info replaces the entry info if set.
+	info *entryInfo
+}
+
+// restore is like store, except that existing entries are only
+// overwritten when asked to do so.
+func (l *changeLog) restore(db RegistryDB, overwrite bool, entries []restoredEntry) (imported, skipped int) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	for _, e := range entries {
+		if !overwrite && db.Lookup(e.key) != "" {
+			skipped++
+			continue
+		}
+		l.storeLocked(db, e.key, e.value, e.peer)
+		if e.info != nil {
+			info := *e.info
+			l.info[e.key] = &info
+		}
+		l.restoreLeaseLocked(db, e.key, e.value, e.ttl, e.remaining)
+		imported++
+	}
+	return
+}
@@ -0,0 +1,61 @@
+/*
+Copyright (C) 2018 Intel Corporation.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package oimregistry
+
+import (
+	"time"
+
+	"github.com/gogo/protobuf/types"
+
+	"github.com/intel/oim/pkg/spec/oim/v0"
+)
+
+// entryInfo is kept for each entry in the registry DB to help
+// with debugging: when was it created, and when and by whom was
+// it changed last?
+type entryInfo struct {
+	created   time.Time
+	updated   time.Time
+	updatedBy string
+}
+
+// setInfoLocked must be called with the mutex held.
+func (l *changeLog) setInfoLocked(key, value, peer string) {
+	if value == "" {
+		delete(l.info, key)
+		return
+	}
+
+	now := time.Now()
+	info := l.info[key]
+	if info == nil {
+		info = &entryInfo{created: now}
+		if l.info == nil {
+			l.info = map[string]*entryInfo{}
+		}
+		l.info[key] = info
+	}
+	info.updated = now
+	info.updatedBy = peer
+}
+
+// addInfo fills in the fields in the value which are derived from
+// the entry info, if there is any.
+func (l *changeLog) addInfo(value *oim.Value) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	info := l.info[value.Path]
+	if info == nil {
+		return
+	}
+	// Conversion only fails for times outside of the valid
+	// range, which cannot happen here.
+	value.Created, _ = types.TimestampProto(info.created)
+	value.Updated, _ = types.TimestampProto(info.updated)
+	value.UpdatedBy = info.updatedBy
+}
@@ -0,0 +1,107 @@
+/*
+Copyright (C) 2018 Intel Corporation.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package oimregistry
+
+import (
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/intel/oim/pkg/log"
+	"github.com/intel/oim/pkg/spec/oim/v0"
+)
+
+// lease removes an entry from the registry DB unless it gets
+// renewed in time. Renewing replaces the lease, so an expired
+// timer which fires concurrently can detect that it is obsolete.
+type lease struct {
+	ttl     time.Duration
+	expires time.Time
+	timer   *time.Timer
+}
+
+// setLeaseLocked must be called with the mutex held.
+func (l *changeLog) setLeaseLocked(db RegistryDB, key, value string, ttl time.Duration) {
+	l.restoreLeaseLocked(db, key, value, ttl, ttl)
+}
+
+// restoreLeaseLocked is like setLeaseLocked, except that the entry
+// expires after the remaining time instead of the TTL. Renewing
+// uses the TTL. Must be called with the mutex held.
+func (l *changeLog) restoreLeaseLocked(db RegistryDB, key, value string, ttl, remaining time.Duration) {
+	if old := l.leases[key]; old != nil {
+		old.timer.Stop()
+		delete(l.leases, key)
+	}
+	if value == "" || ttl <= 0 {
+		return
+	}
+
+	le := &lease{
+		ttl:     ttl,
+		expires: time.Now().Add(remaining),
+	}
+	le.timer = time.AfterFunc(remaining, func() {
+		l.expire(db, key, le)
+	})
+	if l.leases == nil {
+		l.leases = map[string]*lease{}
+	}
+	l.leases[key] = le
+}
+
+// renew extends the lifetime of an entry by its TTL.
+func (l *changeLog) renew(db RegistryDB, key string) error {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	value := db.Lookup(key)
+	if value == "" {
+		return status.Errorf(codes.NotFound, "%q not found", key)
+	}
+	if le := l.leases[key]; le != nil {
+		l.setLeaseLocked(db, key, value, le.ttl)
+	}
+	return nil
+}
+
+// addTTL fills in the TTL and the remaining time until the entry
+// expires, rounded up to full seconds. Both stay zero if the entry
+// does not expire.
+func (l *changeLog) addTTL(value *oim.Value) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	le := l.leases[value.Path]
+	if le == nil {
+		return
+	}
+	remaining := time.Until(le.expires)
+	if remaining <= 0 {
+		// About to expire.
+		remaining = time.Nanosecond
+	}
+	value.TtlSeconds = int64((le.ttl + time.Second - 1) / time.Second)
+	value.RemainingTtlSeconds = int64((remaining + time.Second - 1) / time.Second)
+}
+
+func (l *changeLog) expire(db RegistryDB, key string, le *lease) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	if l.leases[key] != le {
+		// Renewed or removed in the meantime.
+		return
+	}
+	log.L().Infow("registry entry expired", "path", key, "ttl", le.ttl)
+	delete(l.leases, key)
+	l.storeLocked(db, key, "", "")
+	if l.expired != nil {
+		l.expired.Inc()
+	}
+}
@@ -0,0 +1,99 @@
+/*
+Copyright (C) 2018 Intel Corporation.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package oimregistry
+
+import (
+	"context"
+	"path"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/grpc"
+
+	"github.com/intel/oim/pkg/oim-common"
+	"github.com/intel/oim/pkg/spec/oim/v0"
+)
+
+// metrics contains all Prometheus metrics maintained by the registry.
+type metrics struct {
+	operations *prometheus.CounterVec
+	expired    prometheus.Counter
+	// grpc has the metrics that all OIM components provide,
+	// including request counts by code and latencies.
+	grpc *oimcommon.GRPCMetrics
+}
+
+func newMetrics(registerer prometheus.Registerer, db RegistryDB) (*metrics, error) {
+	m := &metrics{
+		operations: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "oim",
+			Subsystem: "registry",
+			Name:      "operations_total",
+			Help:      "Total number of registry requests by operation.",
+		}, []string{"operation"}),
+		expired: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "oim",
+			Subsystem: "registry",
+			Name:      "expired_entries_total",
+			Help:      "Total number of entries that were removed because their TTL expired.",
+		}),
+	}
+	entries := prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+		Namespace: "oim",
+		Subsystem: "registry",
+		Name:      "entries",
+		Help:      "Current number of registry entries.",
+	}, func() float64 {
+		count := 0
+		db.Foreach(func(key, value string) bool {
+			count++
+			return true
+		})
+		return float64(count)
+	})
+	for _, collector := range []prometheus.Collector{m.operations, m.expired, entries} {
+		if err := registerer.Register(collector); err != nil {
+			return nil, err
+		}
+	}
+	grpcMetrics, err := oimcommon.NewGRPCMetrics(registerer)
+	if err != nil {
+		return nil, err
+	}
+	m.grpc = grpcMetrics
+	return m, nil
+}
+
+// measure is a gRPC interceptor which counts requests by operation.
+// Counts by method and code and the latencies are already recorded
+// by the shared gRPC metrics, but those cannot tell apart setting
+// and deleting a value.
+func (m *metrics) measure(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	m.operations.WithLabelValues(operation(info.FullMethod, req)).Inc()
+	return handler(ctx, req)
+}
+
+// operation describes what a request does. SetValue is split into
+// "set" and "delete", unknown requests are identified by the method
+// name.
+func operation(method string, req interface{}) string {
+	switch req := req.(type) {
+	case *oim.SetValueRequest:
+		if req.GetValue().GetValue() == "" {
+			return "delete"
+		}
+		return "set"
+	case *oim.SetValuesRequest:
+		return "batch"
+	case *oim.SetValueIfMatchRequest:
+		return "cas"
+	case *oim.HeartbeatRequest:
+		return "heartbeat"
+	case *oim.GetValuesRequest:
+		return "get"
+	}
+	return path.Base(method)
+}
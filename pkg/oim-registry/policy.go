@@ -0,0 +1,58 @@
+/*
+Copyright (C) 2018 Intel Corporation.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package oimregistry
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/intel/oim/pkg/oim-common"
+)
+
+// ReadPolicy decides whether the peer (identified by the name in its
+// certificate) may read the registry DB entry with the given path.
+// Entries which must not be read are silently skipped by GetValues
+// and Watch.
+type ReadPolicy func(peer, key string) bool
+
+// AllowAllReads lets every authenticated peer read all entries. This
+// is the default.
+func AllowAllReads(peer, key string) bool {
+	return true
+}
+
+// AllowOwnReads lets "user.admin" read all entries. Controllers
+// ("controller.<controller ID>") and hosts ("host.<controller ID>")
+// may only read entries beneath "<controller ID>".
+func AllowOwnReads(peer, key string) bool {
+	if peer == "user.admin" {
+		return true
+	}
+	elements, err := oimcommon.SplitRegistryPath(key)
+	if err != nil || len(elements) == 0 {
+		return false
+	}
+	for _, prefix := range []string{"controller.", "host."} {
+		if strings.HasPrefix(peer, prefix) &&
+			peer[len(prefix):] == elements[0] {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseReadPolicy returns the policy for a name as used in command
+// line flags ("all" or "own").
+func ParseReadPolicy(name string) (ReadPolicy, error) {
+	switch name {
+	case "all":
+		return AllowAllReads, nil
+	case "own":
+		return AllowOwnReads, nil
+	}
+	return nil, fmt.Errorf("unknown read policy %q", name)
+}
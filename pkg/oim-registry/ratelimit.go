@@ -0,0 +1,108 @@
+/*
+Copyright (C) 2018 Intel Corporation.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package oimregistry
+
+import (
+	"context"
+	"sync"
+
+	"golang.org/x/time/rate"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/intel/oim/pkg/spec/oim/v0"
+)
+
+const (
+	// DefaultReadRate is the number of read requests per second
+	// that each client may send unless configured differently
+	// with RateLimits.
+	DefaultReadRate = 100
+
+	// DefaultWriteRate is the number of write requests per
+	// second that each client may send unless configured
+	// differently with RateLimits.
+	DefaultWriteRate = 10
+)
+
+// rateLimits hands out one token bucket per client for reads and
+// another one for writes. The size of each bucket is the number
+// of requests per second, i.e. a client may send a burst of
+// requests after being idle for a second.
+type rateLimits struct {
+	reads, writes float64
+
+	mutex    sync.Mutex
+	limiters map[string]*clientLimiters
+}
+
+type clientLimiters struct {
+	reads, writes *rate.Limiter
+}
+
+func newLimiter(limit float64) *rate.Limiter {
+	if limit <= 0 {
+		return rate.NewLimiter(rate.Inf, 0)
+	}
+	burst := int(limit)
+	if burst < 1 {
+		burst = 1
+	}
+	return rate.NewLimiter(rate.Limit(limit), burst)
+}
+
+// allow checks whether the client may send another request.
+func (rl *rateLimits) allow(peer string, write bool) error {
+	rl.mutex.Lock()
+	limiters := rl.limiters[peer]
+	if limiters == nil {
+		limiters = &clientLimiters{
+			reads:  newLimiter(rl.reads),
+			writes: newLimiter(rl.writes),
+		}
+		if rl.limiters == nil {
+			rl.limiters = map[string]*clientLimiters{}
+		}
+		rl.limiters[peer] = limiters
+	}
+	rl.mutex.Unlock()
+
+	limiter, kind := limiters.reads, "read"
+	if write {
+		limiter, kind = limiters.writes, "write"
+	}
+	if !limiter.Allow() {
+		return status.Errorf(codes.ResourceExhausted, "too many %s requests from %q", kind, peer)
+	}
+	return nil
+}
+
+// limit is a gRPC interceptor which rejects requests from clients
+// which exceed their rate limit. Watch is checked separately
+// because it is a streaming call.
+func (r *registry) limit(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	var write bool
+	switch req.(type) {
+	case *oim.GetValuesRequest:
+		write = false
+	case *oim.SetValueRequest,
+		*oim.SetValuesRequest,
+		*oim.SetValueIfMatchRequest,
+		*oim.HeartbeatRequest:
+		write = true
+	default:
+		return handler(ctx, req)
+	}
+	// Requests without peer fail later in the handler.
+	if peer, err := getPeer(ctx); err == nil {
+		if err := r.limits.allow(peer, write); err != nil {
+			return nil, err
+		}
+	}
+	return handler(ctx, req)
+}
@@ -11,8 +11,11 @@ import (
 	"crypto/tls"
 	"errors"
 	"fmt"
+	"sort"
 	"strings"
+	"time"
 
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/vgough/grpc-proxy/proxy"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
@@ -24,6 +27,7 @@ import (
 	"github.com/intel/oim/pkg/log"
 	"github.com/intel/oim/pkg/oim-common"
 	"github.com/intel/oim/pkg/spec/oim/v0"
+	"github.com/intel/oim/pkg/version"
 )
 
 // RegistryDB stores the mapping from controller ID to gRPC address of
@@ -50,10 +54,28 @@ func GetRegistryEntries(db RegistryDB) map[string]string {
 	return entries
 }
 
+// DefaultMinTTL is the minimum TTL for registry entries unless
+// configured differently with MinTTL.
+const DefaultMinTTL = 10 * time.Second
+
+// DefaultTombstoneRetention is how long removed entries are remembered
+// for watchers unless configured differently with TombstoneRetention.
+const DefaultTombstoneRetention = time.Hour
+
 // Registry implements oim.Registry.
 type registry struct {
 	db        RegistryDB
 	tlsConfig *tls.Config
+	minTTL    time.Duration
+	canRead   ReadPolicy
+	// checkAddress is the timeout for connecting to a
+	// controller address before accepting it, zero if
+	// not enabled.
+	checkAddress time.Duration
+	registerer   prometheus.Registerer
+	metrics      *metrics
+	changes      changeLog
+	limits       rateLimits
 }
 
 // RegistryServer is the public interface for managing a OIM registry server.
@@ -77,18 +99,127 @@ func getPeer(ctx context.Context) (string, error) {
 		len(tlsInfo.State.VerifiedChains[0]) == 0 {
 		return "", status.Error(codes.FailedPrecondition, "cannot determine peer, empty TLS verification chain")
 	}
-	commonName := tlsInfo.State.VerifiedChains[0][0].Subject.CommonName
-	return commonName, nil
+	name := oimcommon.PeerName(tlsInfo.State.VerifiedChains[0][0])
+	if name == "" {
+		return "", status.Error(codes.FailedPrecondition, "cannot determine peer, no name in certificate")
+	}
+	return name, nil
 }
 
 func (r *registry) SetValue(ctx context.Context, in *oim.SetValueRequest) (*oim.SetValueReply, error) {
+	u, err := r.checkSetValue(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+
+	previous := r.changes.store(r.db, u)
+	return &oim.SetValueReply{
+		PreviousValue: previous[0],
+		Existed:       previous[0] != "",
+	}, nil
+}
+
+func (r *registry) SetValues(ctx context.Context, in *oim.SetValuesRequest) (*oim.SetValuesReply, error) {
+	out := oim.SetValuesReply{}
+	var updates []update
+	failed := false
+	for _, value := range in.GetValues() {
+		s := &oim.SetValueStatus{}
+		u, err := r.checkSetValue(ctx, value)
+		if err != nil {
+			st := status.Convert(err)
+			s.Code = int32(st.Code())
+			s.Message = st.Message()
+			failed = true
+		}
+		out.Status = append(out.Status, s)
+		updates = append(updates, u)
+	}
+
+	if failed {
+		for _, s := range out.Status {
+			if s.Code == int32(codes.OK) {
+				s.Code = int32(codes.Aborted)
+				s.Message = "not applied because of other failures"
+			}
+		}
+		return &out, nil
+	}
+
+	r.changes.store(r.db, updates...)
+	return &out, nil
+}
+
+func (r *registry) SetValueIfMatch(ctx context.Context, in *oim.SetValueIfMatchRequest) (*oim.SetValueReply, error) {
+	u, err := r.checkSetValue(ctx, in.GetUpdate())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := r.changes.storeIfMatch(r.db, in.GetExpectedValue(), u); err != nil {
+		return nil, err
+	}
+	return &oim.SetValueReply{
+		PreviousValue: in.GetExpectedValue(),
+		Existed:       in.GetExpectedValue() != "",
+	}, nil
+}
+
+// update is a validated and permitted change of one entry.
+type update struct {
+	key   string
+	value string
+	ttl   time.Duration
+	peer  string
+}
+
+// checkSetValue turns a SetValue request into an update if the request
+// is valid and the caller has the necessary permissions.
+func (r *registry) checkSetValue(ctx context.Context, in *oim.SetValueRequest) (update, error) {
 	value := in.GetValue()
 	if value == nil {
-		return nil, errors.New("missing value")
+		return update{}, errors.New("missing value")
 	}
 
 	// sanitize path
 	elements, err := oimcommon.SplitRegistryPath(value.Path)
+	if err != nil {
+		return update{}, err
+	}
+	if len(elements) == 0 {
+		return update{}, errors.New("empty path")
+	}
+	key := oimcommon.JoinRegistryPath(elements)
+
+	if err := checkWrite(ctx, elements); err != nil {
+		return update{}, err
+	}
+	peer, _ := getPeer(ctx)
+
+	ttl := time.Duration(in.GetTtlSeconds()) * time.Second
+	if ttl < 0 || ttl > 0 && ttl < r.minTTL {
+		return update{}, status.Errorf(codes.InvalidArgument, "TTL %s for %q below minimum of %s", ttl, key, r.minTTL)
+	}
+
+	if r.checkAddress > 0 &&
+		value.Value != "" &&
+		len(elements) == 2 && elements[1] == oimcommon.RegistryAddress {
+		if err := r.dialController(ctx, elements[0], value.Value); err != nil {
+			return update{}, err
+		}
+	}
+
+	return update{
+		key:   key,
+		value: value.Value,
+		ttl:   ttl,
+		peer:  peer,
+	}, nil
+}
+
+func (r *registry) Heartbeat(ctx context.Context, in *oim.HeartbeatRequest) (*oim.HeartbeatReply, error) {
+	// sanitize path
+	elements, err := oimcommon.SplitRegistryPath(in.GetPath())
 	if err != nil {
 		return nil, err
 	}
@@ -97,19 +228,72 @@ func (r *registry) SetValue(ctx context.Context, in *oim.SetValueRequest) (*oim.
 	}
 	key := oimcommon.JoinRegistryPath(elements)
 
-	// Permission check: admin can set anything, controller only '<controller ID>/address'.
+	// Renewing is allowed for those who may also set the value.
+	if err := checkWrite(ctx, elements); err != nil {
+		return nil, err
+	}
+
+	if err := r.changes.renew(r.db, key); err != nil {
+		return nil, err
+	}
+	return &oim.HeartbeatReply{}, nil
+}
+
+// GetVersion returns information about the build of the registry.
+// Every client which can connect may ask for it.
+func (r *registry) GetVersion(ctx context.Context, in *oim.GetVersionRequest) (*oim.GetVersionReply, error) {
+	return version.Get(), nil
+}
+
+// dialController verifies that the controller is reachable under the
+// address and identifies itself correctly.
+func (r *registry) dialController(ctx context.Context, controllerID, address string) error {
+	ctx, cancel := context.WithTimeout(ctx, r.checkAddress)
+	defer cancel()
+	conn, err := oimcommon.Dial(ctx, address, r.controllerDialOptions(controllerID, grpc.WithBlock())...)
+	if err != nil {
+		if ctx.Err() != nil {
+			return status.Errorf(codes.FailedPrecondition, "controller %q not reachable at %q within %s", controllerID, address, r.checkAddress)
+		}
+		return status.Errorf(codes.InvalidArgument, "controller %q address %q: %s", controllerID, address, err)
+	}
+	if err := conn.Close(); err != nil {
+		log.FromContext(ctx).Warnw("closing connection", "error", err)
+	}
+	return nil
+}
+
+// controllerDialOptions returns the options for connecting to the
+// controller with the given ID.
+func (r *registry) controllerDialOptions(controllerID string, opts ...grpc.DialOption) []oimcommon.DialOption {
+	// We check the controller's common name to ensure that we talk to the right service
+	// and not some man-in-the-middle attacker, or simply use the wrong address.
+	outgoingTLS := r.tlsConfig.Clone()
+	outgoingTLS.ServerName = fmt.Sprintf("controller.%s", controllerID)
+	creds := credentials.NewTLS(outgoingTLS)
+	options := []oimcommon.DialOption{
+		oimcommon.WithCredentials(creds),
+		oimcommon.WithGRPCOptions(opts...),
+	}
+	if r.metrics != nil {
+		options = append(options, oimcommon.WithMetrics(r.metrics.grpc))
+	}
+	return options
+}
+
+// checkWrite ensures that the caller may modify the entry:
+// admin can set anything, controller only '<controller ID>/address'.
+func checkWrite(ctx context.Context, elements []string) error {
 	peer, err := getPeer(ctx)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	allowed := peer == "user.admin" ||
 		peer == "controller."+elements[0] && len(elements) == 2 && elements[1] == oimcommon.RegistryAddress
 	if !allowed {
-		return nil, status.Errorf(codes.PermissionDenied, "caller %q not allowed to set %q", peer, key)
+		return status.Errorf(codes.PermissionDenied, "caller %q not allowed to set %q", peer, oimcommon.JoinRegistryPath(elements))
 	}
-
-	r.db.Store(key, value.Value)
-	return &oim.SetValueReply{}, nil
+	return nil
 }
 
 func (r *registry) GetValues(ctx context.Context, in *oim.GetValuesRequest) (*oim.GetValuesReply, error) {
@@ -119,19 +303,22 @@ func (r *registry) GetValues(ctx context.Context, in *oim.GetValuesRequest) (*oi
 		return nil, err
 	}
 	prefix := oimcommon.JoinRegistryPath(elements)
+	if in.GetMaxEntries() < 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid max_entries %d", in.GetMaxEntries())
+	}
 
-	// Permission check: everyone can read, but we want to at least know that
-	// we have identified a peer (i.e. TLS is active).
-	if _, err := getPeer(ctx); err != nil {
+	// Permission check: we need to know who the peer is (i.e. TLS is active),
+	// then the read policy decides which entries are visible.
+	peer, err := getPeer(ctx)
+	if err != nil {
 		return nil, err
 	}
 
 	out := oim.GetValuesReply{}
 	r.db.Foreach(func(key, value string) bool {
-		if prefix == "" ||
-			strings.HasPrefix(key, prefix) &&
-				(len(key) == len(prefix) ||
-					key[len(prefix)] == '/') {
+		if matchesPrefix(key, prefix) &&
+			key >= in.GetStartingToken() &&
+			r.canRead(peer, key) {
 			out.Values = append(out.Values,
 				&oim.Value{
 					Path:  key,
@@ -141,9 +328,89 @@ func (r *registry) GetValues(ctx context.Context, in *oim.GetValuesRequest) (*oi
 		// More data please...
 		return true
 	})
+
+	// The token is simply the path of the first value that
+	// was not returned yet. That way, paging continues at
+	// the right place even when values were added or removed.
+	sort.Slice(out.Values, func(i, j int) bool {
+		return out.Values[i].Path < out.Values[j].Path
+	})
+	if max := int(in.GetMaxEntries()); max > 0 && len(out.Values) > max {
+		out.NextToken = out.Values[max].Path
+		out.Values = out.Values[:max]
+	}
+	for _, value := range out.Values {
+		r.changes.addTTL(value)
+		if in.GetWithMetadata() {
+			r.changes.addInfo(value)
+		}
+	}
 	return &out, nil
 }
 
+func (r *registry) Watch(in *oim.WatchRequest, stream oim.Registry_WatchServer) error {
+	ctx := stream.Context()
+
+	// sanitize path
+	elements, err := oimcommon.SplitRegistryPath(in.GetPath())
+	if err != nil {
+		return err
+	}
+	prefix := oimcommon.JoinRegistryPath(elements)
+
+	// Same permission check as for GetValues.
+	peer, err := getPeer(ctx)
+	if err != nil {
+		return err
+	}
+	if err := r.limits.allow(peer, false); err != nil {
+		return err
+	}
+
+	initial, w, err := r.changes.watch(r.db, prefix, in.GetRevision())
+	if err != nil {
+		return err
+	}
+	defer r.changes.unwatch(w)
+
+	revision := in.GetRevision()
+	for _, c := range initial {
+		if !r.canRead(peer, c.key) {
+			continue
+		}
+		if err := stream.Send(c.reply()); err != nil {
+			return err
+		}
+		revision = c.revision
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case c, ok := <-w.changes:
+			if !ok {
+				return status.Errorf(codes.Aborted, "too many pending changes, resume after revision %d", revision)
+			}
+			if !r.canRead(peer, c.key) {
+				continue
+			}
+			if err := stream.Send(c.reply()); err != nil {
+				return err
+			}
+			revision = c.revision
+		}
+	}
+}
+
+// matchesPrefix returns true if the key is the same as the prefix or
+// beneath it. All keys match the empty prefix.
+func matchesPrefix(key, prefix string) bool {
+	return prefix == "" ||
+		strings.HasPrefix(key, prefix) &&
+			(len(key) == len(prefix) ||
+				key[len(prefix)] == '/')
+}
+
 // StreamDirectory transparently proxies gRPC method calls to the
 // corresponding controller, without keeping connections open.
 func (r *registry) StreamDirector() proxy.StreamDirector {
@@ -188,18 +455,11 @@ func (sd *streamDirector) Connect(ctx context.Context, method string) (context.C
 		return nil, nil, status.Errorf(codes.Unavailable, "%s: no address registered", controllerID)
 	}
 
-	// We check the controller's common name to ensure that we talk to the right service
-	// and not some man-in-the-middle attacker, or simply use the wrong address.
-	outgoingTLS := sd.r.tlsConfig.Clone()
-	outgoingTLS.ServerName = fmt.Sprintf("controller.%s", controllerID)
-	creds := credentials.NewTLS(outgoingTLS)
-	opts := oimcommon.ChooseDialOpts(address, grpc.WithCodec(proxy.Codec()), grpc.WithTransportCredentials(creds))
-
 	// Copy the inbound metadata explicitly.
 	outCtx := metadata.NewOutgoingContext(ctx, md.Copy())
 
 	// Make sure we use DialContext so the dialing can be cancelled/time out together with the context.
-	conn, err := grpc.DialContext(ctx, address, opts...)
+	conn, err := oimcommon.Dial(ctx, address, sd.r.controllerDialOptions(controllerID, grpc.WithCodec(proxy.Codec()))...)
 	return outCtx, conn, err
 }
 
@@ -228,11 +488,71 @@ func TLS(tlsConfig *tls.Config) Option {
 	}
 }
 
+// MinTTL sets the lower limit for the TTL of registry entries.
+func MinTTL(ttl time.Duration) Option {
+	return func(r *registry) error {
+		r.minTTL = ttl
+		return nil
+	}
+}
+
+// Reads sets the policy for read access. Write access is always
+// limited to the owner of an entry.
+func Reads(policy ReadPolicy) Option {
+	return func(r *registry) error {
+		r.canRead = policy
+		return nil
+	}
+}
+
+// CheckAddress enables connecting to a controller when it registers
+// its address. The registration is rejected if the controller cannot
+// be reached within the given time. Zero disables the check.
+func CheckAddress(timeout time.Duration) Option {
+	return func(r *registry) error {
+		r.checkAddress = timeout
+		return nil
+	}
+}
+
+// TombstoneRetention sets how long the registry remembers removed
+// entries for watchers which resume after an older revision.
+func TombstoneRetention(retention time.Duration) Option {
+	return func(r *registry) error {
+		r.changes.tombstoneRetention = retention
+		return nil
+	}
+}
+
+// RateLimits sets how many read and write requests per second each
+// client may send. Zero or less disables the respective limit.
+func RateLimits(reads, writes float64) Option {
+	return func(r *registry) error {
+		r.limits.reads = reads
+		r.limits.writes = writes
+		return nil
+	}
+}
+
+// Metrics enables Prometheus metrics and registers them with the
+// given registerer.
+func Metrics(registerer prometheus.Registerer) Option {
+	return func(r *registry) error {
+		r.registerer = registerer
+		return nil
+	}
+}
+
 // New creates a new instance of the OIM registry.
 func New(options ...Option) (RegistryServer, error) {
 	r := registry{
-		db: NewMemRegistryDB(),
+		db:      NewMemRegistryDB(),
+		minTTL:  DefaultMinTTL,
+		canRead: AllowAllReads,
 	}
+	r.changes.tombstoneRetention = DefaultTombstoneRetention
+	r.limits.reads = DefaultReadRate
+	r.limits.writes = DefaultWriteRate
 	for _, op := range options {
 		err := op(&r)
 		if err != nil {
@@ -242,6 +562,17 @@ func New(options ...Option) (RegistryServer, error) {
 	if r.tlsConfig == nil {
 		return nil, errors.New("transport credentials missing")
 	}
+	if r.tlsConfig.ClientAuth != tls.RequireAndVerifyClientCert {
+		return nil, errors.New("transport credentials must require and verify client certificates")
+	}
+	if r.registerer != nil {
+		m, err := newMetrics(r.registerer, r.db)
+		if err != nil {
+			return nil, err
+		}
+		r.metrics = m
+		r.changes.expired = m.expired
+	}
 	return &r, nil
 }
 
@@ -256,6 +587,41 @@ func (r *registry) Server(endpoint string) (*oimcommon.NonBlockingGRPCServer, fu
 			grpc.UnknownServiceHandler(proxy.TransparentHandler(&streamDirector{r})),
 			grpc.Creds(credentials.NewTLS(r.tlsConfig)),
 		},
+		UnaryInterceptors: []grpc.UnaryServerInterceptor{
+			logUpdates,
+		},
+	}
+	if r.metrics != nil {
+		server.Metrics = r.metrics.grpc
+		server.UnaryInterceptors = append(server.UnaryInterceptors, r.metrics.measure)
 	}
+	server.UnaryInterceptors = append(server.UnaryInterceptors, r.limit)
 	return server, service
 }
+
+// logUpdates is a gRPC interceptor which records who modified which
+// registry entry. Heartbeats are not logged because they are
+// frequent and do not change the value.
+func logUpdates(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	var paths []string
+	switch req := req.(type) {
+	case *oim.SetValueRequest:
+		paths = append(paths, req.GetValue().GetPath())
+	case *oim.SetValueIfMatchRequest:
+		paths = append(paths, req.GetUpdate().GetValue().GetPath())
+	case *oim.SetValuesRequest:
+		for _, value := range req.GetValues() {
+			paths = append(paths, value.GetValue().GetPath())
+		}
+	default:
+		return handler(ctx, req)
+	}
+	resp, err := handler(ctx, req)
+	peer, _ := getPeer(ctx)
+	log.FromContext(ctx).Infow("registry update",
+		"operation", operation(info.FullMethod, req),
+		"peer", peer,
+		"paths", paths,
+		"error", err)
+	return resp, err
+}
@@ -8,17 +8,24 @@ package oimregistry_test
 
 import (
 	"context"
+	"fmt"
+	"io"
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"time"
 
+	"github.com/prometheus/client_golang/prometheus"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 
 	"github.com/intel/oim/pkg/oim-common"
 	"github.com/intel/oim/pkg/oim-controller"
 	"github.com/intel/oim/pkg/oim-registry"
 	"github.com/intel/oim/pkg/spec/oim/v0"
+	"github.com/intel/oim/pkg/version"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -52,6 +59,49 @@ func (m *MockController) CheckMallocBDev(ctx context.Context, in *oim.CheckMallo
 	return &oim.CheckMallocBDevReply{}, nil
 }
 
+func (m *MockController) ListMallocBDevs(ctx context.Context, in *oim.ListMallocBDevsRequest) (*oim.ListMallocBDevsReply, error) {
+	return &oim.ListMallocBDevsReply{}, nil
+}
+
+func (m *MockController) GetNodeInfo(ctx context.Context, in *oim.GetNodeInfoRequest) (*oim.GetNodeInfoReply, error) {
+	return &oim.GetNodeInfoReply{}, nil
+}
+
+func (m *MockController) GetVersion(ctx context.Context, in *oim.GetVersionRequest) (*oim.GetVersionReply, error) {
+	return &oim.GetVersionReply{}, nil
+}
+
+// watchStream implements oim.Registry_WatchServer for calling
+// Watch directly.
+type watchStream struct {
+	grpc.ServerStream
+	ctx     context.Context
+	replies chan *oim.WatchReply
+}
+
+func (w *watchStream) Context() context.Context {
+	return w.ctx
+}
+
+func (w *watchStream) Send(reply *oim.WatchReply) error {
+	select {
+	case w.replies <- reply:
+		return nil
+	case <-w.ctx.Done():
+		return w.ctx.Err()
+	}
+}
+
+// newRegistry creates a registry with the test CA and the
+// registry's own key, plus the additional options.
+func newRegistry(options ...oimregistry.Option) oimregistry.RegistryServer {
+	tlsConfig, err := oimcommon.LoadTLSConfig(os.ExpandEnv("${TEST_WORK}/ca/ca.crt"), os.ExpandEnv("${TEST_WORK}/ca/component.registry.key"), "")
+	Expect(err).NotTo(HaveOccurred())
+	r, err := oimregistry.New(append([]oimregistry.Option{oimregistry.TLS(tlsConfig)}, options...)...)
+	Expect(err).NotTo(HaveOccurred())
+	return r
+}
+
 var _ = Describe("OIM Registry", func() {
 	ctx := context.Background()
 	adminCtx := oimregistry.RegistryClientContext(ctx, "user.admin")
@@ -60,10 +110,7 @@ var _ = Describe("OIM Registry", func() {
 		It("should work", func() {
 			db := oimregistry.NewMemRegistryDB()
 			var err error
-			tlsConfig, err := oimcommon.LoadTLSConfig(os.ExpandEnv("${TEST_WORK}/ca/ca.crt"), os.ExpandEnv("${TEST_WORK}/ca/component.registry.key"), "")
-			Expect(err).NotTo(HaveOccurred())
-			r, err := oimregistry.New(oimregistry.DB(db), oimregistry.TLS(tlsConfig))
-			Expect(err).NotTo(HaveOccurred())
+			r := newRegistry(oimregistry.DB(db))
 			key1 := "foo/controller-id"
 			value1 := "dns:///1.1.1.1/"
 			expected := map[string]string{key1: value1}
@@ -162,6 +209,771 @@ var _ = Describe("OIM Registry", func() {
 				&oim.Value{Path: key2, Value: value2},
 			}))
 		})
+
+		It("should return previous value", func() {
+			r := newRegistry()
+			set := func(value string) string {
+				reply, err := r.SetValue(adminCtx, &oim.SetValueRequest{
+					Value: &oim.Value{
+						Path:  "foo",
+						Value: value,
+					},
+				})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(reply.Existed).To(Equal(reply.PreviousValue != ""))
+				return reply.PreviousValue
+			}
+			Expect(set("")).To(BeEmpty())
+			Expect(set("bar")).To(BeEmpty())
+			Expect(set("baz")).To(Equal("bar"))
+			Expect(set("")).To(Equal("baz"))
+			Expect(set("")).To(BeEmpty())
+		})
+	})
+
+	Describe("listing", func() {
+		It("should support paging", func() {
+			r := newRegistry()
+			for _, path := range []string{"host-2/address", "host-0/address", "host-1/address", "host-1/pci", "other"} {
+				_, err := r.SetValue(adminCtx, &oim.SetValueRequest{
+					Value: &oim.Value{
+						Path:  path,
+						Value: "foo",
+					},
+				})
+				Expect(err).NotTo(HaveOccurred())
+			}
+
+			var paths []string
+			token := ""
+			for {
+				values, err := r.GetValues(adminCtx, &oim.GetValuesRequest{
+					MaxEntries:    2,
+					StartingToken: token,
+				})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(len(values.Values)).To(BeNumerically("<=", 2))
+				for _, value := range values.Values {
+					paths = append(paths, value.Path)
+				}
+				token = values.NextToken
+				if token == "" {
+					break
+				}
+			}
+			Expect(paths).To(Equal([]string{"host-0/address", "host-1/address", "host-1/pci", "host-2/address", "other"}))
+
+			values, err := r.GetValues(adminCtx, &oim.GetValuesRequest{
+				Path:          "host-1",
+				MaxEntries:    1,
+				StartingToken: "host-1/pci",
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(values.Values).To(Equal([]*oim.Value{{Path: "host-1/pci", Value: "foo"}}))
+			Expect(values.NextToken).To(BeEmpty())
+		})
+	})
+
+	Describe("metadata", func() {
+		It("should track changes", func() {
+			r := newRegistry()
+			set := func(ctx context.Context, value string) {
+				_, err := r.SetValue(ctx, &oim.SetValueRequest{
+					Value: &oim.Value{
+						Path:  "host-0/address",
+						Value: value,
+					},
+				})
+				Expect(err).NotTo(HaveOccurred())
+			}
+			get := func(withMetadata bool) []*oim.Value {
+				values, err := r.GetValues(adminCtx, &oim.GetValuesRequest{
+					WithMetadata: withMetadata,
+				})
+				Expect(err).NotTo(HaveOccurred())
+				return values.Values
+			}
+
+			start := time.Now()
+			set(adminCtx, "foo")
+			values := get(true)
+			Expect(values).To(HaveLen(1))
+			Expect(values[0].UpdatedBy).To(Equal("user.admin"))
+			Expect(values[0].Created).NotTo(BeNil())
+			Expect(values[0].Updated).To(Equal(values[0].Created))
+			Expect(values[0].Created.Seconds).To(BeNumerically(">=", start.Unix()))
+			created := values[0].Created
+
+			time.Sleep(10 * time.Millisecond)
+			set(oimregistry.RegistryClientContext(ctx, "controller.host-0"), "bar")
+			values = get(true)
+			Expect(values).To(HaveLen(1))
+			Expect(values[0].UpdatedBy).To(Equal("controller.host-0"))
+			Expect(values[0].Created).To(Equal(created))
+			Expect(values[0].Updated).NotTo(Equal(created))
+
+			// Only returned when asked for.
+			Expect(get(false)).To(Equal([]*oim.Value{{Path: "host-0/address", Value: "bar"}}))
+
+			// Starts anew after removing the entry.
+			set(adminCtx, "")
+			set(adminCtx, "foo")
+			values = get(true)
+			Expect(values).To(HaveLen(1))
+			Expect(values[0].Created).NotTo(Equal(created))
+			Expect(values[0].UpdatedBy).To(Equal("user.admin"))
+		})
+	})
+
+	Describe("batch", func() {
+		var (
+			db oimregistry.RegistryDB
+			r  oimregistry.RegistryServer
+		)
+
+		BeforeEach(func() {
+			db = oimregistry.NewMemRegistryDB()
+			r = newRegistry(oimregistry.DB(db))
+		})
+
+		value := func(path, value string) *oim.SetValueRequest {
+			return &oim.SetValueRequest{
+				Value: &oim.Value{
+					Path:  path,
+					Value: value,
+				},
+			}
+		}
+
+		It("should apply all", func() {
+			reply, err := r.SetValues(adminCtx, &oim.SetValuesRequest{
+				Values: []*oim.SetValueRequest{
+					value("host-0/address", "foo"),
+					value("host-1/address", "bar"),
+					value("host-1/address", "baz"),
+				},
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(reply.Status).To(Equal([]*oim.SetValueStatus{{}, {}, {}}))
+			Expect(oimregistry.GetRegistryEntries(db)).To(Equal(map[string]string{
+				"host-0/address": "foo",
+				"host-1/address": "baz",
+			}))
+		})
+
+		It("should apply none", func() {
+			controllerCtx := oimregistry.RegistryClientContext(ctx, "controller.host-0")
+			reply, err := r.SetValues(controllerCtx, &oim.SetValuesRequest{
+				Values: []*oim.SetValueRequest{
+					value("host-0/address", "foo"),
+					value("host-1/address", "bar"),
+				},
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(reply.Status).To(HaveLen(2))
+			Expect(codes.Code(reply.Status[0].Code)).To(Equal(codes.Aborted))
+			Expect(codes.Code(reply.Status[1].Code)).To(Equal(codes.PermissionDenied))
+			Expect(reply.Status[1].Message).To(Equal(`caller "controller.host-0" not allowed to set "host-1/address"`))
+			Expect(oimregistry.GetRegistryEntries(db)).To(BeEmpty())
+		})
+	})
+
+	Describe("compare and swap", func() {
+		It("should only update matching value", func() {
+			db := oimregistry.NewMemRegistryDB()
+			r := newRegistry(oimregistry.DB(db))
+			controllerCtx := oimregistry.RegistryClientContext(ctx, "controller.host-0")
+			swap := func(expected, value string) error {
+				_, err := r.SetValueIfMatch(controllerCtx, &oim.SetValueIfMatchRequest{
+					Update: &oim.SetValueRequest{
+						Value: &oim.Value{
+							Path:  "host-0/address",
+							Value: value,
+						},
+					},
+					ExpectedValue: expected,
+				})
+				return err
+			}
+
+			// Create, only if it does not exist yet.
+			Expect(swap("", "foo")).To(Succeed())
+			err := swap("", "bar")
+			Expect(status.Code(err)).To(Equal(codes.Aborted))
+			Expect(err.Error()).To(ContainSubstring(`"host-0/address": expected value "", current value "foo"`))
+			Expect(oimregistry.GetRegistryEntries(db)).To(Equal(map[string]string{"host-0/address": "foo"}))
+
+			// Overwrite.
+			err = swap("bar", "baz")
+			Expect(status.Code(err)).To(Equal(codes.Aborted))
+			Expect(swap("foo", "baz")).To(Succeed())
+			Expect(oimregistry.GetRegistryEntries(db)).To(Equal(map[string]string{"host-0/address": "baz"}))
+
+			// Remove.
+			Expect(swap("baz", "")).To(Succeed())
+			Expect(oimregistry.GetRegistryEntries(db)).To(BeEmpty())
+
+			// Same permission checks as for SetValue.
+			_, err = r.SetValueIfMatch(controllerCtx, &oim.SetValueIfMatchRequest{
+				Update: &oim.SetValueRequest{
+					Value: &oim.Value{
+						Path:  "host-1/address",
+						Value: "foo",
+					},
+				},
+			})
+			Expect(status.Code(err)).To(Equal(codes.PermissionDenied))
+		})
+	})
+
+	Describe("tombstones", func() {
+		It("should report removals after history was trimmed", func() {
+			r := newRegistry(oimregistry.TombstoneRetention(time.Second))
+			set := func(path, value string) {
+				_, err := r.SetValue(adminCtx, &oim.SetValueRequest{
+					Value: &oim.Value{
+						Path:  path,
+						Value: value,
+					},
+				})
+				Expect(err).NotTo(HaveOccurred())
+			}
+			watch := func(revision int64, numReplies int) ([]*oim.WatchReply, error) {
+				watchCtx, cancel := context.WithCancel(adminCtx)
+				defer cancel()
+				stream := &watchStream{
+					ctx:     watchCtx,
+					replies: make(chan *oim.WatchReply, 10),
+				}
+				result := make(chan error, 1)
+				go func() {
+					result <- r.Watch(&oim.WatchRequest{Revision: revision}, stream)
+				}()
+				var replies []*oim.WatchReply
+				for len(replies) < numReplies {
+					select {
+					case reply := <-stream.replies:
+						replies = append(replies, reply)
+					case err := <-result:
+						return replies, err
+					}
+				}
+				return replies, nil
+			}
+			reply := func(path, value string, revision int64) *oim.WatchReply {
+				return &oim.WatchReply{
+					Value: &oim.Value{
+						Path:  path,
+						Value: value,
+					},
+					Revision: revision,
+				}
+			}
+
+			set("host-0/address", "foo")
+			set("host-1/address", "bar")
+			set("host-0/address", "")
+			// Pushes all previous changes out of the history.
+			for i := 0; i < 1000; i++ {
+				set("host-2/address", fmt.Sprintf("%d", i))
+			}
+
+			replies, err := watch(2, 3)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(replies[0]).To(Equal(reply("host-0/address", "", 3)))
+			Expect(replies[1:]).To(ConsistOf(
+				reply("host-1/address", "bar", 1003),
+				reply("host-2/address", "999", 1003),
+			))
+
+			// Tombstone is gone after the retention period.
+			time.Sleep(1100 * time.Millisecond)
+			_, err = watch(2, 1)
+			Expect(status.Code(err)).To(Equal(codes.OutOfRange))
+			// Still in the history.
+			replies, err = watch(3, 1)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(replies).To(Equal([]*oim.WatchReply{reply("host-2/address", "0", 4)}))
+		})
+	})
+
+	Describe("read policy", func() {
+		It("should limit access to own entries", func() {
+			r := newRegistry(oimregistry.Reads(oimregistry.AllowOwnReads))
+			for _, path := range []string{"host-0/address", "host-1/address", "other"} {
+				_, err := r.SetValue(adminCtx, &oim.SetValueRequest{
+					Value: &oim.Value{
+						Path:  path,
+						Value: "foo",
+					},
+				})
+				Expect(err).NotTo(HaveOccurred())
+			}
+
+			paths := func(ctx context.Context) []string {
+				values, err := r.GetValues(ctx, &oim.GetValuesRequest{})
+				Expect(err).NotTo(HaveOccurred())
+				var paths []string
+				for _, value := range values.Values {
+					paths = append(paths, value.Path)
+				}
+				return paths
+			}
+			Expect(paths(adminCtx)).To(Equal([]string{"host-0/address", "host-1/address", "other"}))
+			Expect(paths(oimregistry.RegistryClientContext(ctx, "host.host-0"))).To(Equal([]string{"host-0/address"}))
+			Expect(paths(oimregistry.RegistryClientContext(ctx, "controller.host-1"))).To(Equal([]string{"host-1/address"}))
+			Expect(paths(oimregistry.RegistryClientContext(ctx, "user.normal"))).To(BeEmpty())
+		})
+	})
+
+	Describe("version", func() {
+		It("should report build", func() {
+			r := newRegistry()
+			reply, err := r.GetVersion(oimregistry.RegistryClientContext(ctx, "user.normal"), &oim.GetVersionRequest{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(reply).To(Equal(version.Get()))
+		})
+	})
+
+	Describe("address check", func() {
+		var (
+			tmpDir           string
+			r                oimregistry.RegistryServer
+			controllerServer *oimcommon.NonBlockingGRPCServer
+		)
+
+		BeforeEach(func() {
+			var err error
+			tmpDir, err = ioutil.TempDir("", "oim-registry-test")
+			Expect(err).NotTo(HaveOccurred())
+			r = newRegistry(oimregistry.CheckAddress(time.Second))
+		})
+
+		AfterEach(func() {
+			if controllerServer != nil {
+				controllerServer.ForceStop(ctx)
+				controllerServer.Wait(ctx)
+				controllerServer = nil
+			}
+			os.RemoveAll(tmpDir)
+		})
+
+		set := func(controllerID, address string) error {
+			_, err := r.SetValue(adminCtx, &oim.SetValueRequest{
+				Value: &oim.Value{
+					Path:  controllerID + "/" + oimcommon.RegistryAddress,
+					Value: address,
+				},
+			})
+			return err
+		}
+
+		startController := func(key string) string {
+			controllerCreds, err := oimcommon.LoadTLS(os.ExpandEnv("${TEST_WORK}/ca/ca.crt"), key, "component.registry")
+			Expect(err).NotTo(HaveOccurred())
+			controllerAddress := "unix://" + filepath.Join(tmpDir, "controller.sock")
+			server, service := oimcontroller.Server(controllerAddress, &MockController{}, controllerCreds)
+			controllerServer = server
+			err = controllerServer.Start(ctx, service)
+			Expect(err).NotTo(HaveOccurred())
+			return controllerAddress
+		}
+
+		It("should accept reachable controller", func() {
+			address := startController(os.ExpandEnv("${TEST_WORK}/ca/controller.host-0.key"))
+			err := set("host-0", address)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should reject unreachable controller", func() {
+			err := set("host-0", "unix://"+filepath.Join(tmpDir, "no-such.sock"))
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("code = FailedPrecondition"))
+		})
+
+		It("should reject wrong controller", func() {
+			address := startController(os.ExpandEnv("${TEST_WORK}/ca/controller.host-1.key"))
+			err := set("host-0", address)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("code = FailedPrecondition"))
+		})
+
+		It("should not check removal", func() {
+			err := set("host-0", "")
+			Expect(err).NotTo(HaveOccurred())
+		})
+	})
+
+	Describe("metrics", func() {
+		It("should count", func() {
+			tmpDir, err := ioutil.TempDir("", "oim-registry-test")
+			Expect(err).NotTo(HaveOccurred())
+			defer os.RemoveAll(tmpDir)
+
+			promRegistry := prometheus.NewRegistry()
+			r := newRegistry(oimregistry.MinTTL(time.Second), oimregistry.Metrics(promRegistry))
+			registryAddress := "unix://" + filepath.Join(tmpDir, "registry.sock")
+			server, service := r.Server(registryAddress)
+			err = server.Start(ctx, service)
+			Expect(err).NotTo(HaveOccurred())
+			defer func() {
+				server.ForceStop(ctx)
+				server.Wait(ctx)
+			}()
+
+			clientCreds, err := oimcommon.LoadTLS(os.ExpandEnv("${TEST_WORK}/ca/ca.crt"), os.ExpandEnv("${TEST_WORK}/ca/user.admin"), "component.registry")
+			Expect(err).NotTo(HaveOccurred())
+			opts := oimcommon.ChooseDialOpts(registryAddress, grpc.WithTransportCredentials(clientCreds))
+			conn, err := grpc.Dial(registryAddress, opts...)
+			Expect(err).NotTo(HaveOccurred())
+			defer conn.Close()
+			registryClient := oim.NewRegistryClient(conn)
+
+			set := func(path, value string, ttl int64) {
+				_, err := registryClient.SetValue(ctx, &oim.SetValueRequest{
+					Value: &oim.Value{
+						Path:  path,
+						Value: value,
+					},
+					TtlSeconds: ttl,
+				})
+				Expect(err).NotTo(HaveOccurred())
+			}
+			set("host-0/address", "foo", 0)
+			set("host-1/address", "bar", 0)
+			set("host-1/address", "", 0)
+			set("host-2/address", "baz", 1)
+			_, err = registryClient.GetValues(ctx, &oim.GetValuesRequest{})
+			Expect(err).NotTo(HaveOccurred())
+
+			value := func(name string, labels map[string]string) float64 {
+				families, err := promRegistry.Gather()
+				Expect(err).NotTo(HaveOccurred())
+				for _, family := range families {
+					if family.GetName() != name {
+						continue
+					}
+				metrics:
+					for _, metric := range family.GetMetric() {
+						for _, label := range metric.GetLabel() {
+							if labels[label.GetName()] != label.GetValue() {
+								continue metrics
+							}
+						}
+						switch {
+						case metric.Counter != nil:
+							return metric.GetCounter().GetValue()
+						case metric.Gauge != nil:
+							return metric.GetGauge().GetValue()
+						case metric.Histogram != nil:
+							return float64(metric.GetHistogram().GetSampleCount())
+						}
+					}
+				}
+				return -1
+			}
+			Expect(value("oim_registry_operations_total", map[string]string{"operation": "set"})).To(Equal(3.0))
+			Expect(value("oim_registry_operations_total", map[string]string{"operation": "delete"})).To(Equal(1.0))
+			Expect(value("oim_registry_operations_total", map[string]string{"operation": "get"})).To(Equal(1.0))
+			Expect(value("oim_grpc_server_requests_total", map[string]string{"method": "/oim.v0.Registry/SetValue", "code": "OK"})).To(Equal(4.0))
+			Expect(value("oim_grpc_server_request_duration_seconds", map[string]string{"method": "/oim.v0.Registry/GetValues"})).To(Equal(1.0))
+			Expect(value("oim_registry_entries", nil)).To(Equal(2.0))
+			Eventually(func() float64 {
+				return value("oim_registry_expired_entries_total", nil)
+			}, 5*time.Second).Should(Equal(1.0))
+			Expect(value("oim_registry_entries", nil)).To(Equal(1.0))
+		})
+	})
+
+	Describe("rate limits", func() {
+		It("should apply per client", func() {
+			tmpDir, err := ioutil.TempDir("", "oim-registry-test")
+			Expect(err).NotTo(HaveOccurred())
+			defer os.RemoveAll(tmpDir)
+
+			r := newRegistry(oimregistry.RateLimits(5, 2))
+			registryAddress := "unix://" + filepath.Join(tmpDir, "registry.sock")
+			server, service := r.Server(registryAddress)
+			err = server.Start(ctx, service)
+			Expect(err).NotTo(HaveOccurred())
+			defer func() {
+				server.ForceStop(ctx)
+				server.Wait(ctx)
+			}()
+
+			connect := func(key string) *grpc.ClientConn {
+				clientCreds, err := oimcommon.LoadTLS(os.ExpandEnv("${TEST_WORK}/ca/ca.crt"), os.ExpandEnv("${TEST_WORK}/ca/"+key), "component.registry")
+				Expect(err).NotTo(HaveOccurred())
+				opts := oimcommon.ChooseDialOpts(registryAddress, grpc.WithTransportCredentials(clientCreds))
+				conn, err := grpc.Dial(registryAddress, opts...)
+				Expect(err).NotTo(HaveOccurred())
+				return conn
+			}
+			adminConn := connect("user.admin")
+			defer adminConn.Close()
+			admin := oim.NewRegistryClient(adminConn)
+			hostConn := connect("host.host-0")
+			defer hostConn.Close()
+			host := oim.NewRegistryClient(hostConn)
+
+			set := func() error {
+				_, err := admin.SetValue(ctx, &oim.SetValueRequest{
+					Value: &oim.Value{
+						Path:  "host-0/address",
+						Value: "foo",
+					},
+				})
+				return err
+			}
+			get := func(client oim.RegistryClient) error {
+				_, err := client.GetValues(ctx, &oim.GetValuesRequest{})
+				return err
+			}
+
+			Expect(set()).To(Succeed())
+			Expect(set()).To(Succeed())
+			err = set()
+			Expect(status.Code(err)).To(Equal(codes.ResourceExhausted))
+			Expect(err.Error()).To(ContainSubstring(`too many write requests from "user.admin"`))
+
+			// Reads have their own, higher limit.
+			for i := 0; i < 5; i++ {
+				Expect(get(admin)).To(Succeed())
+			}
+			Expect(status.Code(get(admin))).To(Equal(codes.ResourceExhausted))
+
+			// Other clients are not affected.
+			Expect(get(host)).To(Succeed())
+		})
+	})
+
+	Describe("backup", func() {
+		It("should export and import", func() {
+			tmpDir, err := ioutil.TempDir("", "oim-registry-test")
+			Expect(err).NotTo(HaveOccurred())
+			defer os.RemoveAll(tmpDir)
+
+			db := oimregistry.NewMemRegistryDB()
+			// More writes than the default rate limit allows.
+			r := newRegistry(oimregistry.DB(db), oimregistry.RateLimits(0, 0))
+			registryAddress := "unix://" + filepath.Join(tmpDir, "registry.sock")
+			server, service := r.Server(registryAddress)
+			err = server.Start(ctx, service)
+			Expect(err).NotTo(HaveOccurred())
+			defer func() {
+				server.ForceStop(ctx)
+				server.Wait(ctx)
+			}()
+
+			connect := func(key string) *grpc.ClientConn {
+				clientCreds, err := oimcommon.LoadTLS(os.ExpandEnv("${TEST_WORK}/ca/ca.crt"), os.ExpandEnv("${TEST_WORK}/ca/"+key), "component.registry")
+				Expect(err).NotTo(HaveOccurred())
+				opts := oimcommon.ChooseDialOpts(registryAddress, grpc.WithTransportCredentials(clientCreds))
+				conn, err := grpc.Dial(registryAddress, opts...)
+				Expect(err).NotTo(HaveOccurred())
+				return conn
+			}
+			adminConn := connect("user.admin")
+			defer adminConn.Close()
+			admin := oim.NewRegistryClient(adminConn)
+			hostConn := connect("host.host-0")
+			defer hostConn.Close()
+			host := oim.NewRegistryClient(hostConn)
+
+			set := func(path, value string, ttl int64) {
+				_, err := admin.SetValue(ctx, &oim.SetValueRequest{
+					Value: &oim.Value{
+						Path:  path,
+						Value: value,
+					},
+					TtlSeconds: ttl,
+				})
+				Expect(err).NotTo(HaveOccurred())
+			}
+			export := func(client oim.RegistryClient) ([]*oim.Value, error) {
+				stream, err := client.Export(ctx, &oim.ExportRequest{})
+				Expect(err).NotTo(HaveOccurred())
+				var values []*oim.Value
+				for {
+					value, err := stream.Recv()
+					if err == io.EOF {
+						return values, nil
+					}
+					if err != nil {
+						return nil, err
+					}
+					values = append(values, value)
+				}
+			}
+			tryRestore := func(values []*oim.Value, overwrite bool) (*oim.ImportReply, error) {
+				stream, err := admin.Import(ctx)
+				Expect(err).NotTo(HaveOccurred())
+				// One message per value, to cover streaming.
+				for i, value := range values {
+					err := stream.Send(&oim.ImportRequest{
+						Values:    []*oim.Value{value},
+						Overwrite: i == 0 && overwrite,
+					})
+					Expect(err).NotTo(HaveOccurred())
+				}
+				return stream.CloseAndRecv()
+			}
+			restore := func(values []*oim.Value, overwrite bool) *oim.ImportReply {
+				reply, err := tryRestore(values, overwrite)
+				Expect(err).NotTo(HaveOccurred())
+				return reply
+			}
+			get := func(path string) *oim.Value {
+				values, err := admin.GetValues(ctx, &oim.GetValuesRequest{Path: path, WithMetadata: true})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(values.Values).To(HaveLen(1))
+				return values.Values[0]
+			}
+
+			set("host-0/address", "foo", 0)
+			set("host-1/address", "bar", 100)
+			backup, err := export(admin)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(backup).To(HaveLen(2))
+			Expect(backup[0].Path).To(Equal("host-0/address"))
+			Expect(backup[0].Value).To(Equal("foo"))
+			Expect(backup[0].RemainingTtlSeconds).To(Equal(int64(0)))
+			Expect(backup[0].UpdatedBy).To(Equal("user.admin"))
+			Expect(backup[1].Path).To(Equal("host-1/address"))
+			Expect(backup[1].Value).To(Equal("bar"))
+			Expect(backup[1].RemainingTtlSeconds).To(BeNumerically("~", 100, 1))
+			Expect(backup[1].TtlSeconds).To(Equal(int64(100)))
+
+			_, err = export(host)
+			Expect(status.Code(err)).To(Equal(codes.PermissionDenied))
+
+			// Merge keeps existing entries.
+			set("host-0/address", "baz", 0)
+			set("host-1/address", "", 0)
+			Expect(restore(backup, false)).To(Equal(&oim.ImportReply{Imported: 1, Skipped: 1}))
+			Expect(oimregistry.GetRegistryEntries(db)).To(Equal(map[string]string{
+				"host-0/address": "baz",
+				"host-1/address": "bar",
+			}))
+			Expect(get("host-1").RemainingTtlSeconds).To(BeNumerically("~", 100, 1))
+
+			// Overwrite restores the old state, including the metadata.
+			Expect(restore(backup, true)).To(Equal(&oim.ImportReply{Imported: 2}))
+			Expect(oimregistry.GetRegistryEntries(db)).To(Equal(map[string]string{
+				"host-0/address": "foo",
+				"host-1/address": "bar",
+			}))
+			Expect(get("host-0").Created).To(Equal(backup[0].Created))
+			Expect(get("host-0").Updated).To(Equal(backup[0].Updated))
+
+			// An entry restored shortly before it expires keeps
+			// its original TTL for renewing it.
+			restore([]*oim.Value{{
+				Path:                "host-1/address",
+				Value:               "bar",
+				TtlSeconds:          100,
+				RemainingTtlSeconds: 2,
+				Created:             backup[1].Created,
+				Updated:             backup[1].Updated,
+				UpdatedBy:           "controller.host-1",
+			}}, true)
+			value := get("host-1")
+			Expect(value.RemainingTtlSeconds).To(Equal(int64(2)))
+			Expect(value.TtlSeconds).To(Equal(int64(100)))
+			Expect(value.UpdatedBy).To(Equal("controller.host-1"))
+			_, err = admin.Heartbeat(ctx, &oim.HeartbeatRequest{Path: "host-1/address"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(get("host-1").RemainingTtlSeconds).To(BeNumerically("~", 100, 1))
+
+			// Without metadata, the importing admin is the author.
+			restore([]*oim.Value{{Path: "host-0/address", Value: "foo"}}, true)
+			Expect(get("host-0").UpdatedBy).To(Equal("user.admin"))
+
+			// Invalid TTLs are rejected.
+			for _, value := range []*oim.Value{
+				{Path: "host-2/address", Value: "foo", RemainingTtlSeconds: -1},
+				{Path: "host-2/address", Value: "foo", TtlSeconds: -1},
+			} {
+				_, err := tryRestore([]*oim.Value{value}, true)
+				Expect(status.Code(err)).To(Equal(codes.InvalidArgument), "%+v", value)
+			}
+			Expect(oimregistry.GetRegistryEntries(db)).NotTo(HaveKey("host-2/address"))
+		})
+	})
+
+	Describe("entries with TTL", func() {
+		var (
+			db  oimregistry.RegistryDB
+			r   oimregistry.RegistryServer
+			key = "host-0/address"
+
+			getDB = func() map[string]string {
+				return oimregistry.GetRegistryEntries(db)
+			}
+			set = func(ttl int64) error {
+				_, err := r.SetValue(adminCtx, &oim.SetValueRequest{
+					Value: &oim.Value{
+						Path:  key,
+						Value: "foo",
+					},
+					TtlSeconds: ttl,
+				})
+				return err
+			}
+		)
+
+		BeforeEach(func() {
+			db = oimregistry.NewMemRegistryDB()
+			r = newRegistry(oimregistry.DB(db), oimregistry.MinTTL(time.Second))
+		})
+
+		It("should expire", func() {
+			err := set(1)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(getDB()).To(Equal(map[string]string{key: "foo"}))
+			Eventually(getDB, 5*time.Second).Should(BeEmpty())
+		})
+
+		It("should be renewed", func() {
+			err := set(2)
+			Expect(err).NotTo(HaveOccurred())
+			for i := 0; i < 4; i++ {
+				time.Sleep(time.Second)
+				_, err := r.Heartbeat(adminCtx, &oim.HeartbeatRequest{Path: key})
+				Expect(err).NotTo(HaveOccurred())
+			}
+			Expect(getDB()).To(Equal(map[string]string{key: "foo"}))
+			Eventually(getDB, 5*time.Second).Should(BeEmpty())
+		})
+
+		It("should report remaining TTL", func() {
+			err := set(10)
+			Expect(err).NotTo(HaveOccurred())
+			values, err := r.GetValues(adminCtx, &oim.GetValuesRequest{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(values.Values).To(HaveLen(1))
+			Expect(values.Values[0].RemainingTtlSeconds).To(BeNumerically("~", 10, 1))
+		})
+
+		It("should become permanent", func() {
+			err := set(1)
+			Expect(err).NotTo(HaveOccurred())
+			err = set(0)
+			Expect(err).NotTo(HaveOccurred())
+			Consistently(getDB, 2*time.Second).Should(Equal(map[string]string{key: "foo"}))
+		})
+
+		It("should reject invalid TTL", func() {
+			err := set(-1)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("code = InvalidArgument"))
+		})
+
+		It("should fail heartbeat for missing entry", func() {
+			_, err := r.Heartbeat(adminCtx, &oim.HeartbeatRequest{Path: key})
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("code = NotFound"))
+		})
 	})
 
 	Describe("server", func() {
@@ -182,10 +994,7 @@ var _ = Describe("OIM Registry", func() {
 			Expect(err).NotTo(HaveOccurred())
 
 			// Spin up registry.
-			tlsConfig, err := oimcommon.LoadTLSConfig(os.ExpandEnv("${TEST_WORK}/ca/ca.crt"), os.ExpandEnv("${TEST_WORK}/ca/component.registry.key"), "")
-			Expect(err).NotTo(HaveOccurred())
-			registry, err = oimregistry.New(oimregistry.TLS(tlsConfig))
-			Expect(err).NotTo(HaveOccurred())
+			registry = newRegistry()
 			registryAddress = "unix://" + filepath.Join(tmpDir, "registry.sock")
 			server, service := registry.Server(registryAddress)
 			registryServer = server
@@ -248,6 +1057,89 @@ var _ = Describe("OIM Registry", func() {
 			Expect(err.Error()).To(ContainSubstring(`code = PermissionDenied desc = caller "host.host-0" not allowed to set "foo"`))
 		})
 
+		Context("watch", func() {
+			var (
+				registryClient oim.RegistryClient
+				watchCtx       context.Context
+				cancel         context.CancelFunc
+			)
+
+			BeforeEach(func() {
+				registryClient = oim.NewRegistryClient(clientConn)
+				watchCtx, cancel = context.WithCancel(ctx)
+			})
+
+			AfterEach(func() {
+				cancel()
+			})
+
+			set := func(path, value string) {
+				_, err := registry.SetValue(adminCtx, &oim.SetValueRequest{
+					Value: &oim.Value{
+						Path:  path,
+						Value: value,
+					},
+				})
+				Expect(err).NotTo(HaveOccurred())
+			}
+
+			recv := func(stream oim.Registry_WatchClient) *oim.WatchReply {
+				reply, err := stream.Recv()
+				Expect(err).NotTo(HaveOccurred())
+				return reply
+			}
+
+			reply := func(path, value string, revision int64) *oim.WatchReply {
+				return &oim.WatchReply{
+					Value: &oim.Value{
+						Path:  path,
+						Value: value,
+					},
+					Revision: revision,
+				}
+			}
+
+			It("should replay and stream changes", func() {
+				set("host-0/address", "foo")
+				set("host-1/address", "bar")
+
+				stream, err := registryClient.Watch(watchCtx, &oim.WatchRequest{
+					Path: "host-0",
+				})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(recv(stream)).To(Equal(reply("host-0/address", "foo", 2)))
+
+				set("host-1/address", "baz")
+				set("host-0/address", "")
+				set("host-0/pci", "00:03.0")
+				Expect(recv(stream)).To(Equal(reply("host-0/address", "", 4)))
+				Expect(recv(stream)).To(Equal(reply("host-0/pci", "00:03.0", 5)))
+			})
+
+			It("should resume", func() {
+				set("host-0/address", "foo")
+				set("host-1/address", "bar")
+				set("host-0/address", "baz")
+
+				stream, err := registryClient.Watch(watchCtx, &oim.WatchRequest{
+					Revision: 1,
+				})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(recv(stream)).To(Equal(reply("host-1/address", "bar", 2)))
+				Expect(recv(stream)).To(Equal(reply("host-0/address", "baz", 3)))
+			})
+
+			It("should reject unknown revision", func() {
+				stream, err := registryClient.Watch(watchCtx, &oim.WatchRequest{
+					Revision: 100,
+				})
+				Expect(err).NotTo(HaveOccurred())
+				_, err = stream.Recv()
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("code = OutOfRange desc = revision 100 not available"))
+			})
+		})
+
 		Context("with client", func() {
 			var (
 				ca       = os.ExpandEnv("${TEST_WORK}/ca/ca.crt")
@@ -0,0 +1,70 @@
+/*
+Copyright (C) 2018 Intel Corporation.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package oimregistry
+
+import (
+	"sort"
+	"time"
+)
+
+// tombstone remembers that an entry was removed, so that watchers
+// which resume after the history of changes was trimmed still learn
+// about the removal.
+type tombstone struct {
+	revision int64
+	removed  time.Time
+}
+
+// setTombstoneLocked must be called with the mutex held.
+func (l *changeLog) setTombstoneLocked(key, value string, revision int64) {
+	l.collectTombstonesLocked()
+	if value != "" {
+		delete(l.tombstones, key)
+		return
+	}
+	if l.tombstones == nil {
+		l.tombstones = map[string]tombstone{}
+	}
+	l.tombstones[key] = tombstone{
+		revision: revision,
+		removed:  time.Now(),
+	}
+}
+
+// collectTombstonesLocked removes all tombstones which are older
+// than the retention period. Watchers can no longer resume at or
+// before the revision of those.
+func (l *changeLog) collectTombstonesLocked() {
+	deadline := time.Now().Add(-l.tombstoneRetention)
+	for key, t := range l.tombstones {
+		if t.removed.Before(deadline) {
+			delete(l.tombstones, key)
+			if t.revision > l.compacted {
+				l.compacted = t.revision
+			}
+		}
+	}
+}
+
+// catchUpLocked returns the current content of the DB plus all
+// removals after the given revision, in the order in which they
+// have to be sent to a watcher which resumes after that revision.
+func (l *changeLog) catchUpLocked(db RegistryDB, prefix string, revision int64) []change {
+	var removed []change
+	for key, t := range l.tombstones {
+		if t.revision > revision && matchesPrefix(key, prefix) {
+			removed = append(removed, change{
+				revision: t.revision,
+				key:      key,
+			})
+		}
+	}
+	sort.Slice(removed, func(i, j int) bool {
+		return removed[i].revision < removed[j].revision
+	})
+	return append(removed, l.snapshotLocked(db, prefix)...)
+}
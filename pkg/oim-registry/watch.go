@@ -0,0 +1,200 @@
+/*
+Copyright (C) 2018 Intel Corporation.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package oimregistry
+
+import (
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/intel/oim/pkg/spec/oim/v0"
+)
+
+const (
+	// maxHistory is the number of recent changes that are
+	// remembered for watchers which resume after a revision.
+	maxHistory = 1000
+
+	// watchQueueLength is the number of changes that may be
+	// pending for a watcher before it gets disconnected.
+	watchQueueLength = 100
+)
+
+// change describes one modification of the registry DB.
+type change struct {
+	revision int64
+	key      string
+	value    string
+}
+
+func (c change) reply() *oim.WatchReply {
+	return &oim.WatchReply{
+		Value: &oim.Value{
+			Path:  c.key,
+			Value: c.value,
+		},
+		Revision: c.revision,
+	}
+}
+
+// watcher receives all changes beneath or at its path prefix.
+// The changes channel gets closed when the watcher falls behind.
+type watcher struct {
+	prefix  string
+	changes chan change
+}
+
+// changeLog serializes all modifications of the registry DB,
+// numbers them and distributes them to watchers. It also
+// keeps track of entries which expire (see lease.go) and of who
+// modified them when (see info.go).
+type changeLog struct {
+	mutex    sync.Mutex
+	revision int64
+	history  []change
+	watchers map[*watcher]bool
+	leases   map[string]*lease
+	info     map[string]*entryInfo
+
+	// tombstones of removed entries are kept for the
+	// tombstoneRetention period. compacted is the most recent
+	// revision for which that information is no longer complete.
+	tombstones         map[string]tombstone
+	tombstoneRetention time.Duration
+	compacted          int64
+
+	// expired gets incremented for each expired lease, if set.
+	expired prometheus.Counter
+}
+
+// store applies all updates at once and notifies watchers about the
+// changes. A positive ttl replaces any previous lease of the entry
+// with a new one, otherwise the entry becomes permanent. It returns
+// the previous values, empty for entries which did not exist.
+func (l *changeLog) store(db RegistryDB, updates ...update) []string {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	var previous []string
+	for _, u := range updates {
+		previous = append(previous, l.storeLocked(db, u.key, u.value, u.peer))
+		l.setLeaseLocked(db, u.key, u.value, u.ttl)
+	}
+	return previous
+}
+
+// storeIfMatch is like store for a single update, except that it
+// only applies the update when the entry currently has the expected
+// value.
+func (l *changeLog) storeIfMatch(db RegistryDB, expected string, u update) error {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	if current := db.Lookup(u.key); current != expected {
+		return status.Errorf(codes.Aborted, "%q: expected value %q, current value %q", u.key, expected, current)
+	}
+	l.storeLocked(db, u.key, u.value, u.peer)
+	l.setLeaseLocked(db, u.key, u.value, u.ttl)
+	return nil
+}
+
+func (l *changeLog) storeLocked(db RegistryDB, key, value, peer string) (previous string) {
+	previous = db.Lookup(key)
+	db.Store(key, value)
+	l.setInfoLocked(key, value, peer)
+	l.revision++
+	l.setTombstoneLocked(key, value, l.revision)
+	c := change{
+		revision: l.revision,
+		key:      key,
+		value:    value,
+	}
+	l.history = append(l.history, c)
+	if len(l.history) > maxHistory {
+		l.history = l.history[len(l.history)-maxHistory:]
+	}
+	for w := range l.watchers {
+		if !matchesPrefix(key, w.prefix) {
+			continue
+		}
+		select {
+		case w.changes <- c:
+		default:
+			// Too slow, the watcher has to resume.
+			close(w.changes)
+			delete(l.watchers, w)
+		}
+	}
+	return previous
+}
+
+// watch registers a new watcher and returns the changes that it
+// needs to catch up with: either the current content of the DB
+// (revision zero) or all changes after the given revision. When
+// those are no longer in the history, the current content of the
+// DB plus the removals since then are returned instead.
+func (l *changeLog) watch(db RegistryDB, prefix string, revision int64) ([]change, *watcher, error) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	var initial []change
+	l.collectTombstonesLocked()
+	switch {
+	case revision == 0:
+		initial = l.snapshotLocked(db, prefix)
+	case revision > l.revision:
+		return nil, nil, status.Errorf(codes.OutOfRange, "revision %d not available", revision)
+	case revision >= l.revision-int64(len(l.history)):
+		for _, c := range l.history {
+			if c.revision > revision && matchesPrefix(c.key, prefix) {
+				initial = append(initial, c)
+			}
+		}
+	case revision >= l.compacted:
+		initial = l.catchUpLocked(db, prefix, revision)
+	default:
+		return nil, nil, status.Errorf(codes.OutOfRange, "revision %d not available", revision)
+	}
+
+	w := &watcher{
+		prefix:  prefix,
+		changes: make(chan change, watchQueueLength),
+	}
+	if l.watchers == nil {
+		l.watchers = map[*watcher]bool{}
+	}
+	l.watchers[w] = true
+	return initial, w, nil
+}
+
+// snapshotLocked returns the current content of the DB as changes
+// with the current revision.
+func (l *changeLog) snapshotLocked(db RegistryDB, prefix string) []change {
+	var changes []change
+	db.Foreach(func(key, value string) bool {
+		if matchesPrefix(key, prefix) {
+			changes = append(changes, change{
+				revision: l.revision,
+				key:      key,
+				value:    value,
+			})
+		}
+		return true
+	})
+	return changes
+}
+
+// unwatch removes a watcher, if it is still registered.
+func (l *changeLog) unwatch(w *watcher) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	delete(l.watchers, w)
+}
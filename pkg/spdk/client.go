@@ -52,6 +52,8 @@ import (
 	"strconv"
 	"sync"
 
+	"github.com/pkg/errors"
+
 	"github.com/intel/oim/pkg/log"
 )
 
@@ -73,15 +75,23 @@ var jsonError = regexp.MustCompile(`^code: (-?\d+) msg: (.*)$`)
 // IsJSONError checks that the error has the expected error code. Use
 // code == 0 to check for any JSONError.
 func IsJSONError(err error, code int) bool {
-	m := jsonError.FindStringSubmatch(err.Error())
+	errorCode, ok := JSONErrorCode(err)
+	return ok && (code == 0 || errorCode == code)
+}
+
+// JSONErrorCode returns the code of a JSON error returned by SPDK,
+// possibly wrapped with errors.Wrap. The second return value is
+// false for all other errors.
+func JSONErrorCode(err error) (int, bool) {
+	m := jsonError.FindStringSubmatch(errors.Cause(err).Error())
 	if m == nil {
-		return false
+		return 0, false
 	}
-	errorCode, ok := strconv.Atoi(m[1])
-	if ok != nil {
-		return false
+	errorCode, convErr := strconv.Atoi(m[1])
+	if convErr != nil {
+		return 0, false
 	}
-	return code == 0 || errorCode == code
+	return errorCode, true
 }
 
 type clientCodec struct {
@@ -267,6 +277,14 @@ func (c *Client) Close() error {
 }
 
 // Invoke a certain method, get the reply and return the error (if any).
-func (c *Client) Invoke(_ context.Context, method string, args, reply interface{}) error {
-	return c.client.Call(method, args, reply)
+// The call is logged with the logger from the context, which
+// identifies the request that triggered it.
+func (c *Client) Invoke(ctx context.Context, method string, args, reply interface{}) error {
+	logger := log.FromContext(ctx).With("at", "spdk-rpc")
+	logger.Debugw("invoke", "method", method)
+	err := c.client.Call(method, args, reply)
+	if err != nil {
+		logger.Debugw("failed", "method", method, "error", err)
+	}
+	return err
 }
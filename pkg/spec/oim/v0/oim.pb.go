@@ -11,8 +11,21 @@
 		SetValueRequest
 		Value
 		SetValueReply
+		SetValuesRequest
+		SetValuesReply
+		SetValueStatus
+		SetValueIfMatchRequest
+		HeartbeatRequest
+		HeartbeatReply
 		GetValuesRequest
 		GetValuesReply
+		WatchRequest
+		WatchReply
+		ExportRequest
+		ImportRequest
+		ImportReply
+		GetVersionRequest
+		GetVersionReply
 		MapVolumeRequest
 		MallocParams
 		CephParams
@@ -25,12 +38,18 @@
 		ProvisionMallocBDevReply
 		CheckMallocBDevRequest
 		CheckMallocBDevReply
+		ListMallocBDevsRequest
+		ListMallocBDevsReply
+		MallocBDev
+		GetNodeInfoRequest
+		GetNodeInfoReply
 */
 package oim
 
 import proto "github.com/gogo/protobuf/proto"
 import fmt "fmt"
 import math "math"
+import google_protobuf "github.com/gogo/protobuf/types"
 import _ "github.com/gogo/protobuf/types"
 
 import "context"
@@ -51,6 +70,11 @@ const _ = proto.GoGoProtoPackageIsVersion2 // please upgrade the proto package
 
 type SetValueRequest struct {
 	Value *Value `protobuf:"bytes,1,opt,name=value" json:"value,omitempty"`
+	// When non-zero, the entry gets removed automatically
+	// unless it is renewed via Heartbeat before that many
+	// seconds have passed. The registry may reject values
+	// below a certain minimum.
+	TtlSeconds int64 `protobuf:"varint,2,opt,name=ttl_seconds,json=ttlSeconds,proto3" json:"ttl_seconds,omitempty"`
 }
 
 func (m *SetValueRequest) Reset()                    { *m = SetValueRequest{} }
@@ -65,6 +89,13 @@ func (m *SetValueRequest) GetValue() *Value {
 	return nil
 }
 
+func (m *SetValueRequest) GetTtlSeconds() int64 {
+	if m != nil {
+		return m.TtlSeconds
+	}
+	return 0
+}
+
 // A single registry DB entry.
 type Value struct {
 	// A value is referenced by a set of path elements,
@@ -74,6 +105,24 @@ type Value struct {
 	Path string `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
 	// The value itself is also a string.
 	Value string `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	// The remaining time in seconds until the entry expires,
+	// zero if it does not expire. Only set by GetValues and
+	// Export, ignored by SetValue.
+	RemainingTtlSeconds int64 `protobuf:"varint,3,opt,name=remaining_ttl_seconds,json=remainingTtlSeconds,proto3" json:"remaining_ttl_seconds,omitempty"`
+	// The time when the entry was created. Only set by
+	// GetValues with_metadata, ignored by SetValue.
+	Created *google_protobuf.Timestamp `protobuf:"bytes,4,opt,name=created" json:"created,omitempty"`
+	// The time when the value was last changed. Only set
+	// by GetValues with_metadata, ignored by SetValue.
+	Updated *google_protobuf.Timestamp `protobuf:"bytes,5,opt,name=updated" json:"updated,omitempty"`
+	// The name of the peer which last changed the value,
+	// as determined from its TLS certificate. Only set by
+	// GetValues with_metadata, ignored by SetValue.
+	UpdatedBy string `protobuf:"bytes,6,opt,name=updated_by,json=updatedBy,proto3" json:"updated_by,omitempty"`
+	// The TTL in seconds that Heartbeat renews the entry
+	// with, zero if it does not expire. Only set by
+	// GetValues and Export, ignored by SetValue.
+	TtlSeconds int64 `protobuf:"varint,7,opt,name=ttl_seconds,json=ttlSeconds,proto3" json:"ttl_seconds,omitempty"`
 }
 
 func (m *Value) Reset()                    { *m = Value{} }
@@ -95,7 +144,51 @@ func (m *Value) GetValue() string {
 	return ""
 }
 
+func (m *Value) GetRemainingTtlSeconds() int64 {
+	if m != nil {
+		return m.RemainingTtlSeconds
+	}
+	return 0
+}
+
+func (m *Value) GetCreated() *google_protobuf.Timestamp {
+	if m != nil {
+		return m.Created
+	}
+	return nil
+}
+
+func (m *Value) GetUpdated() *google_protobuf.Timestamp {
+	if m != nil {
+		return m.Updated
+	}
+	return nil
+}
+
+func (m *Value) GetUpdatedBy() string {
+	if m != nil {
+		return m.UpdatedBy
+	}
+	return ""
+}
+
+func (m *Value) GetTtlSeconds() int64 {
+	if m != nil {
+		return m.TtlSeconds
+	}
+	return 0
+}
+
 type SetValueReply struct {
+	// The value before the call, empty if the entry did not
+	// exist.
+	PreviousValue string `protobuf:"bytes,1,opt,name=previous_value,json=previousValue,proto3" json:"previous_value,omitempty"`
+	// True if the entry existed before the call. When
+	// removing an entry, this tells the caller whether there
+	// was something to remove. Entries never have an empty
+	// value, so this is the same as a non-empty
+	// previous_value.
+	Existed bool `protobuf:"varint,2,opt,name=existed,proto3" json:"existed,omitempty"`
 }
 
 func (m *SetValueReply) Reset()                    { *m = SetValueReply{} }
@@ -103,16 +196,157 @@ func (m *SetValueReply) String() string            { return proto.CompactTextStr
 func (*SetValueReply) ProtoMessage()               {}
 func (*SetValueReply) Descriptor() ([]byte, []int) { return fileDescriptorOim, []int{2} }
 
+func (m *SetValueReply) GetPreviousValue() string {
+	if m != nil {
+		return m.PreviousValue
+	}
+	return ""
+}
+
+func (m *SetValueReply) GetExisted() bool {
+	if m != nil {
+		return m.Existed
+	}
+	return false
+}
+
+type SetValuesRequest struct {
+	// Each entry is handled like a single SetValue call.
+	// If the same path occurs more than once, the last
+	// entry wins.
+	Values []*SetValueRequest `protobuf:"bytes,1,rep,name=values" json:"values,omitempty"`
+}
+
+func (m *SetValuesRequest) Reset()                    { *m = SetValuesRequest{} }
+func (m *SetValuesRequest) String() string            { return proto.CompactTextString(m) }
+func (*SetValuesRequest) ProtoMessage()               {}
+func (*SetValuesRequest) Descriptor() ([]byte, []int) { return fileDescriptorOim, []int{3} }
+
+func (m *SetValuesRequest) GetValues() []*SetValueRequest {
+	if m != nil {
+		return m.Values
+	}
+	return nil
+}
+
+type SetValuesReply struct {
+	// The outcome for each entry, in the same order as in
+	// the request. When some entries were rejected, none
+	// of the entries were applied and the others have the
+	// gRPC "Aborted" status.
+	Status []*SetValueStatus `protobuf:"bytes,1,rep,name=status" json:"status,omitempty"`
+}
+
+func (m *SetValuesReply) Reset()                    { *m = SetValuesReply{} }
+func (m *SetValuesReply) String() string            { return proto.CompactTextString(m) }
+func (*SetValuesReply) ProtoMessage()               {}
+func (*SetValuesReply) Descriptor() ([]byte, []int) { return fileDescriptorOim, []int{4} }
+
+func (m *SetValuesReply) GetStatus() []*SetValueStatus {
+	if m != nil {
+		return m.Status
+	}
+	return nil
+}
+
+type SetValueStatus struct {
+	// A gRPC status code, 0 (= OK) for success.
+	Code int32 `protobuf:"varint,1,opt,name=code,proto3" json:"code,omitempty"`
+	// An error message, empty for success.
+	Message string `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+}
+
+func (m *SetValueStatus) Reset()                    { *m = SetValueStatus{} }
+func (m *SetValueStatus) String() string            { return proto.CompactTextString(m) }
+func (*SetValueStatus) ProtoMessage()               {}
+func (*SetValueStatus) Descriptor() ([]byte, []int) { return fileDescriptorOim, []int{5} }
+
+func (m *SetValueStatus) GetCode() int32 {
+	if m != nil {
+		return m.Code
+	}
+	return 0
+}
+
+func (m *SetValueStatus) GetMessage() string {
+	if m != nil {
+		return m.Message
+	}
+	return ""
+}
+
+type SetValueIfMatchRequest struct {
+	// The new value, handled like in SetValue.
+	Update *SetValueRequest `protobuf:"bytes,1,opt,name=update" json:"update,omitempty"`
+	// The value that the entry must have at the time of the
+	// update. Empty means that the entry must not exist.
+	ExpectedValue string `protobuf:"bytes,2,opt,name=expected_value,json=expectedValue,proto3" json:"expected_value,omitempty"`
+}
+
+func (m *SetValueIfMatchRequest) Reset()                    { *m = SetValueIfMatchRequest{} }
+func (m *SetValueIfMatchRequest) String() string            { return proto.CompactTextString(m) }
+func (*SetValueIfMatchRequest) ProtoMessage()               {}
+func (*SetValueIfMatchRequest) Descriptor() ([]byte, []int) { return fileDescriptorOim, []int{6} }
+
+func (m *SetValueIfMatchRequest) GetUpdate() *SetValueRequest {
+	if m != nil {
+		return m.Update
+	}
+	return nil
+}
+
+func (m *SetValueIfMatchRequest) GetExpectedValue() string {
+	if m != nil {
+		return m.ExpectedValue
+	}
+	return ""
+}
+
+type HeartbeatRequest struct {
+	// The path of an existing entry. For entries without
+	// TTL the call has no effect.
+	Path string `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+}
+
+func (m *HeartbeatRequest) Reset()                    { *m = HeartbeatRequest{} }
+func (m *HeartbeatRequest) String() string            { return proto.CompactTextString(m) }
+func (*HeartbeatRequest) ProtoMessage()               {}
+func (*HeartbeatRequest) Descriptor() ([]byte, []int) { return fileDescriptorOim, []int{7} }
+
+func (m *HeartbeatRequest) GetPath() string {
+	if m != nil {
+		return m.Path
+	}
+	return ""
+}
+
+type HeartbeatReply struct {
+}
+
+func (m *HeartbeatReply) Reset()                    { *m = HeartbeatReply{} }
+func (m *HeartbeatReply) String() string            { return proto.CompactTextString(m) }
+func (*HeartbeatReply) ProtoMessage()               {}
+func (*HeartbeatReply) Descriptor() ([]byte, []int) { return fileDescriptorOim, []int{8} }
+
 type GetValuesRequest struct {
 	// Return all values beneath or at the given path,
 	// all values when empty.
 	Path string `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	// If non-zero, at most this many values are returned.
+	// The remaining ones can be retrieved with further
+	// calls that pass the next_token from the reply.
+	MaxEntries int32 `protobuf:"varint,2,opt,name=max_entries,json=maxEntries,proto3" json:"max_entries,omitempty"`
+	// Continue with the values at which a previous call
+	// stopped. Values are sorted by path.
+	StartingToken string `protobuf:"bytes,3,opt,name=starting_token,json=startingToken,proto3" json:"starting_token,omitempty"`
+	// Also return when and by whom values were set.
+	WithMetadata bool `protobuf:"varint,4,opt,name=with_metadata,json=withMetadata,proto3" json:"with_metadata,omitempty"`
 }
 
 func (m *GetValuesRequest) Reset()                    { *m = GetValuesRequest{} }
 func (m *GetValuesRequest) String() string            { return proto.CompactTextString(m) }
 func (*GetValuesRequest) ProtoMessage()               {}
-func (*GetValuesRequest) Descriptor() ([]byte, []int) { return fileDescriptorOim, []int{3} }
+func (*GetValuesRequest) Descriptor() ([]byte, []int) { return fileDescriptorOim, []int{9} }
 
 func (m *GetValuesRequest) GetPath() string {
 	if m != nil {
@@ -121,15 +355,38 @@ func (m *GetValuesRequest) GetPath() string {
 	return ""
 }
 
+func (m *GetValuesRequest) GetMaxEntries() int32 {
+	if m != nil {
+		return m.MaxEntries
+	}
+	return 0
+}
+
+func (m *GetValuesRequest) GetStartingToken() string {
+	if m != nil {
+		return m.StartingToken
+	}
+	return ""
+}
+
+func (m *GetValuesRequest) GetWithMetadata() bool {
+	if m != nil {
+		return m.WithMetadata
+	}
+	return false
+}
+
 type GetValuesReply struct {
-	// All current registry DB values.
+	// All current registry DB values, sorted by path.
 	Values []*Value `protobuf:"bytes,1,rep,name=values" json:"values,omitempty"`
+	// Non-empty if there are more values.
+	NextToken string `protobuf:"bytes,2,opt,name=next_token,json=nextToken,proto3" json:"next_token,omitempty"`
 }
 
 func (m *GetValuesReply) Reset()                    { *m = GetValuesReply{} }
 func (m *GetValuesReply) String() string            { return proto.CompactTextString(m) }
 func (*GetValuesReply) ProtoMessage()               {}
-func (*GetValuesReply) Descriptor() ([]byte, []int) { return fileDescriptorOim, []int{4} }
+func (*GetValuesReply) Descriptor() ([]byte, []int) { return fileDescriptorOim, []int{10} }
 
 func (m *GetValuesReply) GetValues() []*Value {
 	if m != nil {
@@ -138,6 +395,190 @@ func (m *GetValuesReply) GetValues() []*Value {
 	return nil
 }
 
+func (m *GetValuesReply) GetNextToken() string {
+	if m != nil {
+		return m.NextToken
+	}
+	return ""
+}
+
+type WatchRequest struct {
+	// Watch all values beneath or at the given path,
+	// all values when empty.
+	Path string `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	// When zero, the stream starts with the current values.
+	// Otherwise the stream resumes after the given revision,
+	// typically the last one received before the stream was
+	// interrupted. If the individual changes since then are
+	// no longer known, the stream starts with the removals
+	// since then, followed by the current values. If even
+	// that is not possible because removals were forgotten
+	// already, the call fails with gRPC "OutOfRange" and the
+	// caller has to start again from zero.
+	Revision int64 `protobuf:"varint,2,opt,name=revision,proto3" json:"revision,omitempty"`
+}
+
+func (m *WatchRequest) Reset()                    { *m = WatchRequest{} }
+func (m *WatchRequest) String() string            { return proto.CompactTextString(m) }
+func (*WatchRequest) ProtoMessage()               {}
+func (*WatchRequest) Descriptor() ([]byte, []int) { return fileDescriptorOim, []int{11} }
+
+func (m *WatchRequest) GetPath() string {
+	if m != nil {
+		return m.Path
+	}
+	return ""
+}
+
+func (m *WatchRequest) GetRevision() int64 {
+	if m != nil {
+		return m.Revision
+	}
+	return 0
+}
+
+type WatchReply struct {
+	// The new value. An empty value indicates that the
+	// entry was removed.
+	Value *Value `protobuf:"bytes,1,opt,name=value" json:"value,omitempty"`
+	// The revision of the registry DB after the change.
+	// Revisions increase with each change.
+	Revision int64 `protobuf:"varint,2,opt,name=revision,proto3" json:"revision,omitempty"`
+}
+
+func (m *WatchReply) Reset()                    { *m = WatchReply{} }
+func (m *WatchReply) String() string            { return proto.CompactTextString(m) }
+func (*WatchReply) ProtoMessage()               {}
+func (*WatchReply) Descriptor() ([]byte, []int) { return fileDescriptorOim, []int{12} }
+
+func (m *WatchReply) GetValue() *Value {
+	if m != nil {
+		return m.Value
+	}
+	return nil
+}
+
+func (m *WatchReply) GetRevision() int64 {
+	if m != nil {
+		return m.Revision
+	}
+	return 0
+}
+
+type ExportRequest struct {
+}
+
+func (m *ExportRequest) Reset()                    { *m = ExportRequest{} }
+func (m *ExportRequest) String() string            { return proto.CompactTextString(m) }
+func (*ExportRequest) ProtoMessage()               {}
+func (*ExportRequest) Descriptor() ([]byte, []int) { return fileDescriptorOim, []int{13} }
+
+type ImportRequest struct {
+	// The entries to import. An entry expires after its
+	// remaining_ttl_seconds and Heartbeat then renews it
+	// with its ttl_seconds. When only one of them is set,
+	// it is used for both. The metadata (created, updated,
+	// updated_by) is preserved when present, otherwise the
+	// entry counts as created by the caller. Entries may be
+	// spread across several messages.
+	Values []*Value `protobuf:"bytes,1,rep,name=values" json:"values,omitempty"`
+	// Determines what happens with entries that already
+	// exist: when true, they are overwritten, otherwise
+	// they are kept. The first message determines this
+	// for the entire import.
+	Overwrite bool `protobuf:"varint,2,opt,name=overwrite,proto3" json:"overwrite,omitempty"`
+}
+
+func (m *ImportRequest) Reset()                    { *m = ImportRequest{} }
+func (m *ImportRequest) String() string            { return proto.CompactTextString(m) }
+func (*ImportRequest) ProtoMessage()               {}
+func (*ImportRequest) Descriptor() ([]byte, []int) { return fileDescriptorOim, []int{14} }
+
+func (m *ImportRequest) GetValues() []*Value {
+	if m != nil {
+		return m.Values
+	}
+	return nil
+}
+
+func (m *ImportRequest) GetOverwrite() bool {
+	if m != nil {
+		return m.Overwrite
+	}
+	return false
+}
+
+type ImportReply struct {
+	// The number of entries that were set.
+	Imported int32 `protobuf:"varint,1,opt,name=imported,proto3" json:"imported,omitempty"`
+	// The number of entries that were skipped because
+	// they already existed.
+	Skipped int32 `protobuf:"varint,2,opt,name=skipped,proto3" json:"skipped,omitempty"`
+}
+
+func (m *ImportReply) Reset()                    { *m = ImportReply{} }
+func (m *ImportReply) String() string            { return proto.CompactTextString(m) }
+func (*ImportReply) ProtoMessage()               {}
+func (*ImportReply) Descriptor() ([]byte, []int) { return fileDescriptorOim, []int{15} }
+
+func (m *ImportReply) GetImported() int32 {
+	if m != nil {
+		return m.Imported
+	}
+	return 0
+}
+
+func (m *ImportReply) GetSkipped() int32 {
+	if m != nil {
+		return m.Skipped
+	}
+	return 0
+}
+
+type GetVersionRequest struct {
+}
+
+func (m *GetVersionRequest) Reset()                    { *m = GetVersionRequest{} }
+func (m *GetVersionRequest) String() string            { return proto.CompactTextString(m) }
+func (*GetVersionRequest) ProtoMessage()               {}
+func (*GetVersionRequest) Descriptor() ([]byte, []int) { return fileDescriptorOim, []int{16} }
+
+type GetVersionReply struct {
+	// The release version, derived from the most recent
+	// tag with "git describe".
+	Version string `protobuf:"bytes,1,opt,name=version,proto3" json:"version,omitempty"`
+	// The git commit that the binary was built from.
+	Commit string `protobuf:"bytes,2,opt,name=commit,proto3" json:"commit,omitempty"`
+	// When the binary was built, in RFC 3339 format.
+	BuildDate string `protobuf:"bytes,3,opt,name=build_date,json=buildDate,proto3" json:"build_date,omitempty"`
+}
+
+func (m *GetVersionReply) Reset()                    { *m = GetVersionReply{} }
+func (m *GetVersionReply) String() string            { return proto.CompactTextString(m) }
+func (*GetVersionReply) ProtoMessage()               {}
+func (*GetVersionReply) Descriptor() ([]byte, []int) { return fileDescriptorOim, []int{17} }
+
+func (m *GetVersionReply) GetVersion() string {
+	if m != nil {
+		return m.Version
+	}
+	return ""
+}
+
+func (m *GetVersionReply) GetCommit() string {
+	if m != nil {
+		return m.Commit
+	}
+	return ""
+}
+
+func (m *GetVersionReply) GetBuildDate() string {
+	if m != nil {
+		return m.BuildDate
+	}
+	return ""
+}
+
 type MapVolumeRequest struct {
 	// An identifier for the volume that must be unique
 	// among all volumes mapped by the OIM controller.
@@ -155,7 +596,7 @@ type MapVolumeRequest struct {
 func (m *MapVolumeRequest) Reset()                    { *m = MapVolumeRequest{} }
 func (m *MapVolumeRequest) String() string            { return proto.CompactTextString(m) }
 func (*MapVolumeRequest) ProtoMessage()               {}
-func (*MapVolumeRequest) Descriptor() ([]byte, []int) { return fileDescriptorOim, []int{5} }
+func (*MapVolumeRequest) Descriptor() ([]byte, []int) { return fileDescriptorOim, []int{18} }
 
 type isMapVolumeRequest_Params interface {
 	isMapVolumeRequest_Params()
@@ -285,7 +726,7 @@ type MallocParams struct {
 func (m *MallocParams) Reset()                    { *m = MallocParams{} }
 func (m *MallocParams) String() string            { return proto.CompactTextString(m) }
 func (*MallocParams) ProtoMessage()               {}
-func (*MallocParams) Descriptor() ([]byte, []int) { return fileDescriptorOim, []int{6} }
+func (*MallocParams) Descriptor() ([]byte, []int) { return fileDescriptorOim, []int{19} }
 
 // Defines a Ceph block device.
 type CephParams struct {
@@ -305,7 +746,7 @@ type CephParams struct {
 func (m *CephParams) Reset()                    { *m = CephParams{} }
 func (m *CephParams) String() string            { return proto.CompactTextString(m) }
 func (*CephParams) ProtoMessage()               {}
-func (*CephParams) Descriptor() ([]byte, []int) { return fileDescriptorOim, []int{7} }
+func (*CephParams) Descriptor() ([]byte, []int) { return fileDescriptorOim, []int{20} }
 
 func (m *CephParams) GetUserId() string {
 	if m != nil {
@@ -357,7 +798,7 @@ type MapVolumeReply struct {
 func (m *MapVolumeReply) Reset()                    { *m = MapVolumeReply{} }
 func (m *MapVolumeReply) String() string            { return proto.CompactTextString(m) }
 func (*MapVolumeReply) ProtoMessage()               {}
-func (*MapVolumeReply) Descriptor() ([]byte, []int) { return fileDescriptorOim, []int{8} }
+func (*MapVolumeReply) Descriptor() ([]byte, []int) { return fileDescriptorOim, []int{21} }
 
 func (m *MapVolumeReply) GetPciAddress() *PCIAddress {
 	if m != nil {
@@ -390,7 +831,7 @@ type PCIAddress struct {
 func (m *PCIAddress) Reset()                    { *m = PCIAddress{} }
 func (m *PCIAddress) String() string            { return proto.CompactTextString(m) }
 func (*PCIAddress) ProtoMessage()               {}
-func (*PCIAddress) Descriptor() ([]byte, []int) { return fileDescriptorOim, []int{9} }
+func (*PCIAddress) Descriptor() ([]byte, []int) { return fileDescriptorOim, []int{22} }
 
 func (m *PCIAddress) GetDomain() uint32 {
 	if m != nil {
@@ -428,7 +869,7 @@ type SCSIDisk struct {
 func (m *SCSIDisk) Reset()                    { *m = SCSIDisk{} }
 func (m *SCSIDisk) String() string            { return proto.CompactTextString(m) }
 func (*SCSIDisk) ProtoMessage()               {}
-func (*SCSIDisk) Descriptor() ([]byte, []int) { return fileDescriptorOim, []int{10} }
+func (*SCSIDisk) Descriptor() ([]byte, []int) { return fileDescriptorOim, []int{23} }
 
 func (m *SCSIDisk) GetTarget() uint32 {
 	if m != nil {
@@ -452,7 +893,7 @@ type UnmapVolumeRequest struct {
 func (m *UnmapVolumeRequest) Reset()                    { *m = UnmapVolumeRequest{} }
 func (m *UnmapVolumeRequest) String() string            { return proto.CompactTextString(m) }
 func (*UnmapVolumeRequest) ProtoMessage()               {}
-func (*UnmapVolumeRequest) Descriptor() ([]byte, []int) { return fileDescriptorOim, []int{11} }
+func (*UnmapVolumeRequest) Descriptor() ([]byte, []int) { return fileDescriptorOim, []int{24} }
 
 func (m *UnmapVolumeRequest) GetVolumeId() string {
 	if m != nil {
@@ -467,7 +908,7 @@ type UnmapVolumeReply struct {
 func (m *UnmapVolumeReply) Reset()                    { *m = UnmapVolumeReply{} }
 func (m *UnmapVolumeReply) String() string            { return proto.CompactTextString(m) }
 func (*UnmapVolumeReply) ProtoMessage()               {}
-func (*UnmapVolumeReply) Descriptor() ([]byte, []int) { return fileDescriptorOim, []int{12} }
+func (*UnmapVolumeReply) Descriptor() ([]byte, []int) { return fileDescriptorOim, []int{25} }
 
 type ProvisionMallocBDevRequest struct {
 	// The desired name of the new BDev.
@@ -479,7 +920,7 @@ type ProvisionMallocBDevRequest struct {
 func (m *ProvisionMallocBDevRequest) Reset()                    { *m = ProvisionMallocBDevRequest{} }
 func (m *ProvisionMallocBDevRequest) String() string            { return proto.CompactTextString(m) }
 func (*ProvisionMallocBDevRequest) ProtoMessage()               {}
-func (*ProvisionMallocBDevRequest) Descriptor() ([]byte, []int) { return fileDescriptorOim, []int{13} }
+func (*ProvisionMallocBDevRequest) Descriptor() ([]byte, []int) { return fileDescriptorOim, []int{26} }
 
 func (m *ProvisionMallocBDevRequest) GetBdevName() string {
 	if m != nil {
@@ -501,7 +942,7 @@ type ProvisionMallocBDevReply struct {
 func (m *ProvisionMallocBDevReply) Reset()                    { *m = ProvisionMallocBDevReply{} }
 func (m *ProvisionMallocBDevReply) String() string            { return proto.CompactTextString(m) }
 func (*ProvisionMallocBDevReply) ProtoMessage()               {}
-func (*ProvisionMallocBDevReply) Descriptor() ([]byte, []int) { return fileDescriptorOim, []int{14} }
+func (*ProvisionMallocBDevReply) Descriptor() ([]byte, []int) { return fileDescriptorOim, []int{27} }
 
 type CheckMallocBDevRequest struct {
 	// The name of an existing BDev.
@@ -511,7 +952,7 @@ type CheckMallocBDevRequest struct {
 func (m *CheckMallocBDevRequest) Reset()                    { *m = CheckMallocBDevRequest{} }
 func (m *CheckMallocBDevRequest) String() string            { return proto.CompactTextString(m) }
 func (*CheckMallocBDevRequest) ProtoMessage()               {}
-func (*CheckMallocBDevRequest) Descriptor() ([]byte, []int) { return fileDescriptorOim, []int{15} }
+func (*CheckMallocBDevRequest) Descriptor() ([]byte, []int) { return fileDescriptorOim, []int{28} }
 
 func (m *CheckMallocBDevRequest) GetBdevName() string {
 	if m != nil {
@@ -526,14 +967,113 @@ type CheckMallocBDevReply struct {
 func (m *CheckMallocBDevReply) Reset()                    { *m = CheckMallocBDevReply{} }
 func (m *CheckMallocBDevReply) String() string            { return proto.CompactTextString(m) }
 func (*CheckMallocBDevReply) ProtoMessage()               {}
-func (*CheckMallocBDevReply) Descriptor() ([]byte, []int) { return fileDescriptorOim, []int{16} }
+func (*CheckMallocBDevReply) Descriptor() ([]byte, []int) { return fileDescriptorOim, []int{29} }
+
+type ListMallocBDevsRequest struct {
+}
+
+func (m *ListMallocBDevsRequest) Reset()                    { *m = ListMallocBDevsRequest{} }
+func (m *ListMallocBDevsRequest) String() string            { return proto.CompactTextString(m) }
+func (*ListMallocBDevsRequest) ProtoMessage()               {}
+func (*ListMallocBDevsRequest) Descriptor() ([]byte, []int) { return fileDescriptorOim, []int{30} }
+
+type ListMallocBDevsReply struct {
+	Bdevs []*MallocBDev `protobuf:"bytes,1,rep,name=bdevs" json:"bdevs,omitempty"`
+}
+
+func (m *ListMallocBDevsReply) Reset()                    { *m = ListMallocBDevsReply{} }
+func (m *ListMallocBDevsReply) String() string            { return proto.CompactTextString(m) }
+func (*ListMallocBDevsReply) ProtoMessage()               {}
+func (*ListMallocBDevsReply) Descriptor() ([]byte, []int) { return fileDescriptorOim, []int{31} }
+
+func (m *ListMallocBDevsReply) GetBdevs() []*MallocBDev {
+	if m != nil {
+		return m.Bdevs
+	}
+	return nil
+}
+
+type MallocBDev struct {
+	// The name of the BDev.
+	BdevName string `protobuf:"bytes,1,opt,name=bdev_name,json=bdevName,proto3" json:"bdev_name,omitempty"`
+	// The size in bytes.
+	Size_ int64 `protobuf:"varint,2,opt,name=size,proto3" json:"size,omitempty"`
+}
+
+func (m *MallocBDev) Reset()                    { *m = MallocBDev{} }
+func (m *MallocBDev) String() string            { return proto.CompactTextString(m) }
+func (*MallocBDev) ProtoMessage()               {}
+func (*MallocBDev) Descriptor() ([]byte, []int) { return fileDescriptorOim, []int{32} }
+
+func (m *MallocBDev) GetBdevName() string {
+	if m != nil {
+		return m.BdevName
+	}
+	return ""
+}
+
+func (m *MallocBDev) GetSize_() int64 {
+	if m != nil {
+		return m.Size_
+	}
+	return 0
+}
+
+type GetNodeInfoRequest struct {
+}
+
+func (m *GetNodeInfoRequest) Reset()                    { *m = GetNodeInfoRequest{} }
+func (m *GetNodeInfoRequest) String() string            { return proto.CompactTextString(m) }
+func (*GetNodeInfoRequest) ProtoMessage()               {}
+func (*GetNodeInfoRequest) Descriptor() ([]byte, []int) { return fileDescriptorOim, []int{33} }
+
+type GetNodeInfoReply struct {
+	// The maximum number of volumes that can be mapped at
+	// the same time. Once reached, MapVolume fails with
+	// gRPC RESOURCE_EXHAUSTED.
+	MaxVolumes int64 `protobuf:"varint,1,opt,name=max_volumes,json=maxVolumes,proto3" json:"max_volumes,omitempty"`
+	// The number of volumes that are currently mapped.
+	MappedVolumes int64 `protobuf:"varint,2,opt,name=mapped_volumes,json=mappedVolumes,proto3" json:"mapped_volumes,omitempty"`
+}
+
+func (m *GetNodeInfoReply) Reset()                    { *m = GetNodeInfoReply{} }
+func (m *GetNodeInfoReply) String() string            { return proto.CompactTextString(m) }
+func (*GetNodeInfoReply) ProtoMessage()               {}
+func (*GetNodeInfoReply) Descriptor() ([]byte, []int) { return fileDescriptorOim, []int{34} }
+
+func (m *GetNodeInfoReply) GetMaxVolumes() int64 {
+	if m != nil {
+		return m.MaxVolumes
+	}
+	return 0
+}
+
+func (m *GetNodeInfoReply) GetMappedVolumes() int64 {
+	if m != nil {
+		return m.MappedVolumes
+	}
+	return 0
+}
 
 func init() {
 	proto.RegisterType((*SetValueRequest)(nil), "oim.v0.SetValueRequest")
 	proto.RegisterType((*Value)(nil), "oim.v0.Value")
 	proto.RegisterType((*SetValueReply)(nil), "oim.v0.SetValueReply")
+	proto.RegisterType((*SetValuesRequest)(nil), "oim.v0.SetValuesRequest")
+	proto.RegisterType((*SetValuesReply)(nil), "oim.v0.SetValuesReply")
+	proto.RegisterType((*SetValueStatus)(nil), "oim.v0.SetValueStatus")
+	proto.RegisterType((*SetValueIfMatchRequest)(nil), "oim.v0.SetValueIfMatchRequest")
+	proto.RegisterType((*HeartbeatRequest)(nil), "oim.v0.HeartbeatRequest")
+	proto.RegisterType((*HeartbeatReply)(nil), "oim.v0.HeartbeatReply")
 	proto.RegisterType((*GetValuesRequest)(nil), "oim.v0.GetValuesRequest")
 	proto.RegisterType((*GetValuesReply)(nil), "oim.v0.GetValuesReply")
+	proto.RegisterType((*WatchRequest)(nil), "oim.v0.WatchRequest")
+	proto.RegisterType((*WatchReply)(nil), "oim.v0.WatchReply")
+	proto.RegisterType((*ExportRequest)(nil), "oim.v0.ExportRequest")
+	proto.RegisterType((*ImportRequest)(nil), "oim.v0.ImportRequest")
+	proto.RegisterType((*ImportReply)(nil), "oim.v0.ImportReply")
+	proto.RegisterType((*GetVersionRequest)(nil), "oim.v0.GetVersionRequest")
+	proto.RegisterType((*GetVersionReply)(nil), "oim.v0.GetVersionReply")
 	proto.RegisterType((*MapVolumeRequest)(nil), "oim.v0.MapVolumeRequest")
 	proto.RegisterType((*MallocParams)(nil), "oim.v0.MallocParams")
 	proto.RegisterType((*CephParams)(nil), "oim.v0.CephParams")
@@ -546,6 +1086,11 @@ func init() {
 	proto.RegisterType((*ProvisionMallocBDevReply)(nil), "oim.v0.ProvisionMallocBDevReply")
 	proto.RegisterType((*CheckMallocBDevRequest)(nil), "oim.v0.CheckMallocBDevRequest")
 	proto.RegisterType((*CheckMallocBDevReply)(nil), "oim.v0.CheckMallocBDevReply")
+	proto.RegisterType((*ListMallocBDevsRequest)(nil), "oim.v0.ListMallocBDevsRequest")
+	proto.RegisterType((*ListMallocBDevsReply)(nil), "oim.v0.ListMallocBDevsReply")
+	proto.RegisterType((*MallocBDev)(nil), "oim.v0.MallocBDev")
+	proto.RegisterType((*GetNodeInfoRequest)(nil), "oim.v0.GetNodeInfoRequest")
+	proto.RegisterType((*GetNodeInfoReply)(nil), "oim.v0.GetNodeInfoReply")
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -561,8 +1106,31 @@ const _ = grpc.SupportPackageIsVersion4
 type RegistryClient interface {
 	// Set or overwrite a registry DB entry.
 	SetValue(ctx context.Context, in *SetValueRequest, opts ...grpc.CallOption) (*SetValueReply, error)
+	// Set, overwrite or remove several registry DB entries
+	// at once. Either all or none of the changes are applied.
+	SetValues(ctx context.Context, in *SetValuesRequest, opts ...grpc.CallOption) (*SetValuesReply, error)
+	// Set, overwrite or remove a registry DB entry only if
+	// it currently has the expected value. Returns gRPC
+	// ABORTED status if the value is different.
+	SetValueIfMatch(ctx context.Context, in *SetValueIfMatchRequest, opts ...grpc.CallOption) (*SetValueReply, error)
+	// Renews the TTL of a registry DB entry. Returns gRPC
+	// NOT_FOUND status if the entry does not exist (anymore).
+	Heartbeat(ctx context.Context, in *HeartbeatRequest, opts ...grpc.CallOption) (*HeartbeatReply, error)
 	// Retrieves registry DB entries.
 	GetValues(ctx context.Context, in *GetValuesRequest, opts ...grpc.CallOption) (*GetValuesReply, error)
+	// Streams changes of registry DB entries. The stream
+	// starts with the current values, followed by all
+	// changes as they happen.
+	Watch(ctx context.Context, in *WatchRequest, opts ...grpc.CallOption) (Registry_WatchClient, error)
+	// Streams all registry DB entries including their
+	// metadata, for backups. Only allowed for the admin.
+	Export(ctx context.Context, in *ExportRequest, opts ...grpc.CallOption) (Registry_ExportClient, error)
+	// Adds the streamed entries to the registry DB once the
+	// client closes the stream, for restoring a backup.
+	// Only allowed for the admin.
+	Import(ctx context.Context, opts ...grpc.CallOption) (Registry_ImportClient, error)
+	// Returns information about the build of the registry.
+	GetVersion(ctx context.Context, in *GetVersionRequest, opts ...grpc.CallOption) (*GetVersionReply, error)
 }
 
 type registryClient struct {
@@ -582,22 +1150,179 @@ func (c *registryClient) SetValue(ctx context.Context, in *SetValueRequest, opts
 	return out, nil
 }
 
-func (c *registryClient) GetValues(ctx context.Context, in *GetValuesRequest, opts ...grpc.CallOption) (*GetValuesReply, error) {
-	out := new(GetValuesReply)
-	err := grpc.Invoke(ctx, "/oim.v0.Registry/GetValues", in, out, c.cc, opts...)
+func (c *registryClient) SetValues(ctx context.Context, in *SetValuesRequest, opts ...grpc.CallOption) (*SetValuesReply, error) {
+	out := new(SetValuesReply)
+	err := grpc.Invoke(ctx, "/oim.v0.Registry/SetValues", in, out, c.cc, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-// Server API for Registry service
+func (c *registryClient) SetValueIfMatch(ctx context.Context, in *SetValueIfMatchRequest, opts ...grpc.CallOption) (*SetValueReply, error) {
+	out := new(SetValueReply)
+	err := grpc.Invoke(ctx, "/oim.v0.Registry/SetValueIfMatch", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
 
-type RegistryServer interface {
-	// Set or overwrite a registry DB entry.
-	SetValue(context.Context, *SetValueRequest) (*SetValueReply, error)
-	// Retrieves registry DB entries.
-	GetValues(context.Context, *GetValuesRequest) (*GetValuesReply, error)
+func (c *registryClient) Heartbeat(ctx context.Context, in *HeartbeatRequest, opts ...grpc.CallOption) (*HeartbeatReply, error) {
+	out := new(HeartbeatReply)
+	err := grpc.Invoke(ctx, "/oim.v0.Registry/Heartbeat", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *registryClient) GetValues(ctx context.Context, in *GetValuesRequest, opts ...grpc.CallOption) (*GetValuesReply, error) {
+	out := new(GetValuesReply)
+	err := grpc.Invoke(ctx, "/oim.v0.Registry/GetValues", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *registryClient) Watch(ctx context.Context, in *WatchRequest, opts ...grpc.CallOption) (Registry_WatchClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_Registry_serviceDesc.Streams[0], c.cc, "/oim.v0.Registry/Watch", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &registryWatchClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Registry_WatchClient interface {
+	Recv() (*WatchReply, error)
+	grpc.ClientStream
+}
+
+type registryWatchClient struct {
+	grpc.ClientStream
+}
+
+func (x *registryWatchClient) Recv() (*WatchReply, error) {
+	m := new(WatchReply)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *registryClient) Export(ctx context.Context, in *ExportRequest, opts ...grpc.CallOption) (Registry_ExportClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_Registry_serviceDesc.Streams[1], c.cc, "/oim.v0.Registry/Export", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &registryExportClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Registry_ExportClient interface {
+	Recv() (*Value, error)
+	grpc.ClientStream
+}
+
+type registryExportClient struct {
+	grpc.ClientStream
+}
+
+func (x *registryExportClient) Recv() (*Value, error) {
+	m := new(Value)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *registryClient) Import(ctx context.Context, opts ...grpc.CallOption) (Registry_ImportClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_Registry_serviceDesc.Streams[2], c.cc, "/oim.v0.Registry/Import", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &registryImportClient{stream}
+	return x, nil
+}
+
+type Registry_ImportClient interface {
+	Send(*ImportRequest) error
+	CloseAndRecv() (*ImportReply, error)
+	grpc.ClientStream
+}
+
+type registryImportClient struct {
+	grpc.ClientStream
+}
+
+func (x *registryImportClient) Send(m *ImportRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *registryImportClient) CloseAndRecv() (*ImportReply, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(ImportReply)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *registryClient) GetVersion(ctx context.Context, in *GetVersionRequest, opts ...grpc.CallOption) (*GetVersionReply, error) {
+	out := new(GetVersionReply)
+	err := grpc.Invoke(ctx, "/oim.v0.Registry/GetVersion", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Server API for Registry service
+
+type RegistryServer interface {
+	// Set or overwrite a registry DB entry.
+	SetValue(context.Context, *SetValueRequest) (*SetValueReply, error)
+	// Set, overwrite or remove several registry DB entries
+	// at once. Either all or none of the changes are applied.
+	SetValues(context.Context, *SetValuesRequest) (*SetValuesReply, error)
+	// Set, overwrite or remove a registry DB entry only if
+	// it currently has the expected value. Returns gRPC
+	// ABORTED status if the value is different.
+	SetValueIfMatch(context.Context, *SetValueIfMatchRequest) (*SetValueReply, error)
+	// Renews the TTL of a registry DB entry. Returns gRPC
+	// NOT_FOUND status if the entry does not exist (anymore).
+	Heartbeat(context.Context, *HeartbeatRequest) (*HeartbeatReply, error)
+	// Retrieves registry DB entries.
+	GetValues(context.Context, *GetValuesRequest) (*GetValuesReply, error)
+	// Streams changes of registry DB entries. The stream
+	// starts with the current values, followed by all
+	// changes as they happen.
+	Watch(*WatchRequest, Registry_WatchServer) error
+	// Streams all registry DB entries including their
+	// metadata, for backups. Only allowed for the admin.
+	Export(*ExportRequest, Registry_ExportServer) error
+	// Adds the streamed entries to the registry DB once the
+	// client closes the stream, for restoring a backup.
+	// Only allowed for the admin.
+	Import(Registry_ImportServer) error
+	// Returns information about the build of the registry.
+	GetVersion(context.Context, *GetVersionRequest) (*GetVersionReply, error)
 }
 
 func RegisterRegistryServer(s *grpc.Server, srv RegistryServer) {
@@ -622,6 +1347,60 @@ func _Registry_SetValue_Handler(srv interface{}, ctx context.Context, dec func(i
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Registry_SetValues_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetValuesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RegistryServer).SetValues(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/oim.v0.Registry/SetValues",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RegistryServer).SetValues(ctx, req.(*SetValuesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Registry_SetValueIfMatch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetValueIfMatchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RegistryServer).SetValueIfMatch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/oim.v0.Registry/SetValueIfMatch",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RegistryServer).SetValueIfMatch(ctx, req.(*SetValueIfMatchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Registry_Heartbeat_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HeartbeatRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RegistryServer).Heartbeat(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/oim.v0.Registry/Heartbeat",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RegistryServer).Heartbeat(ctx, req.(*HeartbeatRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Registry_GetValues_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetValuesRequest)
 	if err := dec(in); err != nil {
@@ -640,6 +1419,92 @@ func _Registry_GetValues_Handler(srv interface{}, ctx context.Context, dec func(
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Registry_Watch_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(RegistryServer).Watch(m, &registryWatchServer{stream})
+}
+
+type Registry_WatchServer interface {
+	Send(*WatchReply) error
+	grpc.ServerStream
+}
+
+type registryWatchServer struct {
+	grpc.ServerStream
+}
+
+func (x *registryWatchServer) Send(m *WatchReply) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Registry_Export_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ExportRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(RegistryServer).Export(m, &registryExportServer{stream})
+}
+
+type Registry_ExportServer interface {
+	Send(*Value) error
+	grpc.ServerStream
+}
+
+type registryExportServer struct {
+	grpc.ServerStream
+}
+
+func (x *registryExportServer) Send(m *Value) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Registry_Import_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(RegistryServer).Import(&registryImportServer{stream})
+}
+
+type Registry_ImportServer interface {
+	SendAndClose(*ImportReply) error
+	Recv() (*ImportRequest, error)
+	grpc.ServerStream
+}
+
+type registryImportServer struct {
+	grpc.ServerStream
+}
+
+func (x *registryImportServer) SendAndClose(m *ImportReply) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *registryImportServer) Recv() (*ImportRequest, error) {
+	m := new(ImportRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _Registry_GetVersion_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetVersionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RegistryServer).GetVersion(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/oim.v0.Registry/GetVersion",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RegistryServer).GetVersion(ctx, req.(*GetVersionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _Registry_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "oim.v0.Registry",
 	HandlerType: (*RegistryServer)(nil),
@@ -648,12 +1513,44 @@ var _Registry_serviceDesc = grpc.ServiceDesc{
 			MethodName: "SetValue",
 			Handler:    _Registry_SetValue_Handler,
 		},
+		{
+			MethodName: "SetValues",
+			Handler:    _Registry_SetValues_Handler,
+		},
+		{
+			MethodName: "SetValueIfMatch",
+			Handler:    _Registry_SetValueIfMatch_Handler,
+		},
+		{
+			MethodName: "Heartbeat",
+			Handler:    _Registry_Heartbeat_Handler,
+		},
 		{
 			MethodName: "GetValues",
 			Handler:    _Registry_GetValues_Handler,
 		},
+		{
+			MethodName: "GetVersion",
+			Handler:    _Registry_GetVersion_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Watch",
+			Handler:       _Registry_Watch_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "Export",
+			Handler:       _Registry_Export_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "Import",
+			Handler:       _Registry_Import_Handler,
+			ClientStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "oim.proto",
 }
 
@@ -675,6 +1572,14 @@ type ControllerClient interface {
 	// Checks that the BDev exists. Returns
 	// gRPC NOT_FOUND status if not.
 	CheckMallocBDev(ctx context.Context, in *CheckMallocBDevRequest, opts ...grpc.CallOption) (*CheckMallocBDevReply, error)
+	// Lists all Malloc BDevs, sorted by name.
+	ListMallocBDevs(ctx context.Context, in *ListMallocBDevsRequest, opts ...grpc.CallOption) (*ListMallocBDevsReply, error)
+	// Returns information about the host that the
+	// controller is responsible for.
+	GetNodeInfo(ctx context.Context, in *GetNodeInfoRequest, opts ...grpc.CallOption) (*GetNodeInfoReply, error)
+	// Returns information about the build of the
+	// controller, like Registry.GetVersion.
+	GetVersion(ctx context.Context, in *GetVersionRequest, opts ...grpc.CallOption) (*GetVersionReply, error)
 }
 
 type controllerClient struct {
@@ -721,6 +1626,33 @@ func (c *controllerClient) CheckMallocBDev(ctx context.Context, in *CheckMallocB
 	return out, nil
 }
 
+func (c *controllerClient) ListMallocBDevs(ctx context.Context, in *ListMallocBDevsRequest, opts ...grpc.CallOption) (*ListMallocBDevsReply, error) {
+	out := new(ListMallocBDevsReply)
+	err := grpc.Invoke(ctx, "/oim.v0.Controller/ListMallocBDevs", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controllerClient) GetNodeInfo(ctx context.Context, in *GetNodeInfoRequest, opts ...grpc.CallOption) (*GetNodeInfoReply, error) {
+	out := new(GetNodeInfoReply)
+	err := grpc.Invoke(ctx, "/oim.v0.Controller/GetNodeInfo", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controllerClient) GetVersion(ctx context.Context, in *GetVersionRequest, opts ...grpc.CallOption) (*GetVersionReply, error) {
+	out := new(GetVersionReply)
+	err := grpc.Invoke(ctx, "/oim.v0.Controller/GetVersion", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // Server API for Controller service
 
 type ControllerServer interface {
@@ -739,6 +1671,14 @@ type ControllerServer interface {
 	// Checks that the BDev exists. Returns
 	// gRPC NOT_FOUND status if not.
 	CheckMallocBDev(context.Context, *CheckMallocBDevRequest) (*CheckMallocBDevReply, error)
+	// Lists all Malloc BDevs, sorted by name.
+	ListMallocBDevs(context.Context, *ListMallocBDevsRequest) (*ListMallocBDevsReply, error)
+	// Returns information about the host that the
+	// controller is responsible for.
+	GetNodeInfo(context.Context, *GetNodeInfoRequest) (*GetNodeInfoReply, error)
+	// Returns information about the build of the
+	// controller, like Registry.GetVersion.
+	GetVersion(context.Context, *GetVersionRequest) (*GetVersionReply, error)
 }
 
 func RegisterControllerServer(s *grpc.Server, srv ControllerServer) {
@@ -817,6 +1757,60 @@ func _Controller_CheckMallocBDev_Handler(srv interface{}, ctx context.Context, d
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Controller_ListMallocBDevs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListMallocBDevsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControllerServer).ListMallocBDevs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/oim.v0.Controller/ListMallocBDevs",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControllerServer).ListMallocBDevs(ctx, req.(*ListMallocBDevsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Controller_GetNodeInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetNodeInfoRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControllerServer).GetNodeInfo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/oim.v0.Controller/GetNodeInfo",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControllerServer).GetNodeInfo(ctx, req.(*GetNodeInfoRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Controller_GetVersion_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetVersionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControllerServer).GetVersion(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/oim.v0.Controller/GetVersion",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControllerServer).GetVersion(ctx, req.(*GetVersionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _Controller_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "oim.v0.Controller",
 	HandlerType: (*ControllerServer)(nil),
@@ -837,6 +1831,18 @@ var _Controller_serviceDesc = grpc.ServiceDesc{
 			MethodName: "CheckMallocBDev",
 			Handler:    _Controller_CheckMallocBDev_Handler,
 		},
+		{
+			MethodName: "ListMallocBDevs",
+			Handler:    _Controller_ListMallocBDevs_Handler,
+		},
+		{
+			MethodName: "GetNodeInfo",
+			Handler:    _Controller_GetNodeInfo_Handler,
+		},
+		{
+			MethodName: "GetVersion",
+			Handler:    _Controller_GetVersion_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "oim.proto",
@@ -867,6 +1873,11 @@ func (m *SetValueRequest) MarshalTo(dAtA []byte) (int, error) {
 		}
 		i += n1
 	}
+	if m.TtlSeconds != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintOim(dAtA, i, uint64(m.TtlSeconds))
+	}
 	return i, nil
 }
 
@@ -897,28 +1908,46 @@ func (m *Value) MarshalTo(dAtA []byte) (int, error) {
 		i = encodeVarintOim(dAtA, i, uint64(len(m.Value)))
 		i += copy(dAtA[i:], m.Value)
 	}
-	return i, nil
-}
-
-func (m *SetValueReply) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+	if m.RemainingTtlSeconds != 0 {
+		dAtA[i] = 0x18
+		i++
+		i = encodeVarintOim(dAtA, i, uint64(m.RemainingTtlSeconds))
+	}
+	if m.Created != nil {
+		dAtA[i] = 0x22
+		i++
+		i = encodeVarintOim(dAtA, i, uint64(m.Created.Size()))
+		n2, err := m.Created.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n2
+	}
+	if m.Updated != nil {
+		dAtA[i] = 0x2a
+		i++
+		i = encodeVarintOim(dAtA, i, uint64(m.Updated.Size()))
+		n3, err := m.Updated.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n3
+	}
+	if len(m.UpdatedBy) > 0 {
+		dAtA[i] = 0x32
+		i++
+		i = encodeVarintOim(dAtA, i, uint64(len(m.UpdatedBy)))
+		i += copy(dAtA[i:], m.UpdatedBy)
+	}
+	if m.TtlSeconds != 0 {
+		dAtA[i] = 0x38
+		i++
+		i = encodeVarintOim(dAtA, i, uint64(m.TtlSeconds))
 	}
-	return dAtA[:n], nil
-}
-
-func (m *SetValueReply) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
 	return i, nil
 }
 
-func (m *GetValuesRequest) Marshal() (dAtA []byte, err error) {
+func (m *SetValueReply) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -928,21 +1957,31 @@ func (m *GetValuesRequest) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *GetValuesRequest) MarshalTo(dAtA []byte) (int, error) {
+func (m *SetValueReply) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
 	_ = l
-	if len(m.Path) > 0 {
+	if len(m.PreviousValue) > 0 {
 		dAtA[i] = 0xa
 		i++
-		i = encodeVarintOim(dAtA, i, uint64(len(m.Path)))
-		i += copy(dAtA[i:], m.Path)
+		i = encodeVarintOim(dAtA, i, uint64(len(m.PreviousValue)))
+		i += copy(dAtA[i:], m.PreviousValue)
+	}
+	if m.Existed {
+		dAtA[i] = 0x10
+		i++
+		if m.Existed {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
 	}
 	return i, nil
 }
 
-func (m *GetValuesReply) Marshal() (dAtA []byte, err error) {
+func (m *SetValuesRequest) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -952,7 +1991,7 @@ func (m *GetValuesReply) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *GetValuesReply) MarshalTo(dAtA []byte) (int, error) {
+func (m *SetValuesRequest) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
@@ -972,7 +2011,7 @@ func (m *GetValuesReply) MarshalTo(dAtA []byte) (int, error) {
 	return i, nil
 }
 
-func (m *MapVolumeRequest) Marshal() (dAtA []byte, err error) {
+func (m *SetValuesReply) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -982,56 +2021,90 @@ func (m *MapVolumeRequest) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *MapVolumeRequest) MarshalTo(dAtA []byte) (int, error) {
+func (m *SetValuesReply) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
 	_ = l
-	if len(m.VolumeId) > 0 {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintOim(dAtA, i, uint64(len(m.VolumeId)))
-		i += copy(dAtA[i:], m.VolumeId)
-	}
-	if m.Params != nil {
-		nn2, err := m.Params.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
+	if len(m.Status) > 0 {
+		for _, msg := range m.Status {
+			dAtA[i] = 0xa
+			i++
+			i = encodeVarintOim(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
 		}
-		i += nn2
 	}
 	return i, nil
 }
 
-func (m *MapVolumeRequest_Malloc) MarshalTo(dAtA []byte) (int, error) {
-	i := 0
-	if m.Malloc != nil {
+func (m *SetValueStatus) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SetValueStatus) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Code != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintOim(dAtA, i, uint64(m.Code))
+	}
+	if len(m.Message) > 0 {
 		dAtA[i] = 0x12
 		i++
-		i = encodeVarintOim(dAtA, i, uint64(m.Malloc.Size()))
-		n3, err := m.Malloc.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n3
+		i = encodeVarintOim(dAtA, i, uint64(len(m.Message)))
+		i += copy(dAtA[i:], m.Message)
 	}
 	return i, nil
 }
-func (m *MapVolumeRequest_Ceph) MarshalTo(dAtA []byte) (int, error) {
-	i := 0
-	if m.Ceph != nil {
-		dAtA[i] = 0x1a
+
+func (m *SetValueIfMatchRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SetValueIfMatchRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Update != nil {
+		dAtA[i] = 0xa
 		i++
-		i = encodeVarintOim(dAtA, i, uint64(m.Ceph.Size()))
-		n4, err := m.Ceph.MarshalTo(dAtA[i:])
+		i = encodeVarintOim(dAtA, i, uint64(m.Update.Size()))
+		n4, err := m.Update.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
 		i += n4
 	}
+	if len(m.ExpectedValue) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintOim(dAtA, i, uint64(len(m.ExpectedValue)))
+		i += copy(dAtA[i:], m.ExpectedValue)
+	}
 	return i, nil
 }
-func (m *MallocParams) Marshal() (dAtA []byte, err error) {
+
+func (m *HeartbeatRequest) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -1041,15 +2114,21 @@ func (m *MallocParams) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *MallocParams) MarshalTo(dAtA []byte) (int, error) {
+func (m *HeartbeatRequest) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
 	_ = l
+	if len(m.Path) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintOim(dAtA, i, uint64(len(m.Path)))
+		i += copy(dAtA[i:], m.Path)
+	}
 	return i, nil
 }
 
-func (m *CephParams) Marshal() (dAtA []byte, err error) {
+func (m *HeartbeatReply) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -1059,45 +2138,60 @@ func (m *CephParams) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *CephParams) MarshalTo(dAtA []byte) (int, error) {
+func (m *HeartbeatReply) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
 	_ = l
-	if len(m.UserId) > 0 {
+	return i, nil
+}
+
+func (m *GetValuesRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *GetValuesRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Path) > 0 {
 		dAtA[i] = 0xa
 		i++
-		i = encodeVarintOim(dAtA, i, uint64(len(m.UserId)))
-		i += copy(dAtA[i:], m.UserId)
+		i = encodeVarintOim(dAtA, i, uint64(len(m.Path)))
+		i += copy(dAtA[i:], m.Path)
 	}
-	if len(m.Secret) > 0 {
-		dAtA[i] = 0x12
+	if m.MaxEntries != 0 {
+		dAtA[i] = 0x10
 		i++
-		i = encodeVarintOim(dAtA, i, uint64(len(m.Secret)))
-		i += copy(dAtA[i:], m.Secret)
+		i = encodeVarintOim(dAtA, i, uint64(m.MaxEntries))
 	}
-	if len(m.Monitors) > 0 {
+	if len(m.StartingToken) > 0 {
 		dAtA[i] = 0x1a
 		i++
-		i = encodeVarintOim(dAtA, i, uint64(len(m.Monitors)))
-		i += copy(dAtA[i:], m.Monitors)
+		i = encodeVarintOim(dAtA, i, uint64(len(m.StartingToken)))
+		i += copy(dAtA[i:], m.StartingToken)
 	}
-	if len(m.Pool) > 0 {
-		dAtA[i] = 0x22
+	if m.WithMetadata {
+		dAtA[i] = 0x20
 		i++
-		i = encodeVarintOim(dAtA, i, uint64(len(m.Pool)))
-		i += copy(dAtA[i:], m.Pool)
-	}
-	if len(m.Image) > 0 {
-		dAtA[i] = 0x2a
+		if m.WithMetadata {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
 		i++
-		i = encodeVarintOim(dAtA, i, uint64(len(m.Image)))
-		i += copy(dAtA[i:], m.Image)
 	}
 	return i, nil
 }
 
-func (m *MapVolumeReply) Marshal() (dAtA []byte, err error) {
+func (m *GetValuesReply) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -1107,35 +2201,33 @@ func (m *MapVolumeReply) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *MapVolumeReply) MarshalTo(dAtA []byte) (int, error) {
+func (m *GetValuesReply) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
 	_ = l
-	if m.PciAddress != nil {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintOim(dAtA, i, uint64(m.PciAddress.Size()))
-		n5, err := m.PciAddress.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
+	if len(m.Values) > 0 {
+		for _, msg := range m.Values {
+			dAtA[i] = 0xa
+			i++
+			i = encodeVarintOim(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
 		}
-		i += n5
 	}
-	if m.ScsiDisk != nil {
+	if len(m.NextToken) > 0 {
 		dAtA[i] = 0x12
 		i++
-		i = encodeVarintOim(dAtA, i, uint64(m.ScsiDisk.Size()))
-		n6, err := m.ScsiDisk.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n6
+		i = encodeVarintOim(dAtA, i, uint64(len(m.NextToken)))
+		i += copy(dAtA[i:], m.NextToken)
 	}
 	return i, nil
 }
 
-func (m *PCIAddress) Marshal() (dAtA []byte, err error) {
+func (m *WatchRequest) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -1145,35 +2237,26 @@ func (m *PCIAddress) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *PCIAddress) MarshalTo(dAtA []byte) (int, error) {
+func (m *WatchRequest) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
 	_ = l
-	if m.Domain != 0 {
-		dAtA[i] = 0x8
+	if len(m.Path) > 0 {
+		dAtA[i] = 0xa
 		i++
-		i = encodeVarintOim(dAtA, i, uint64(m.Domain))
+		i = encodeVarintOim(dAtA, i, uint64(len(m.Path)))
+		i += copy(dAtA[i:], m.Path)
 	}
-	if m.Bus != 0 {
+	if m.Revision != 0 {
 		dAtA[i] = 0x10
 		i++
-		i = encodeVarintOim(dAtA, i, uint64(m.Bus))
-	}
-	if m.Device != 0 {
-		dAtA[i] = 0x18
-		i++
-		i = encodeVarintOim(dAtA, i, uint64(m.Device))
-	}
-	if m.Function != 0 {
-		dAtA[i] = 0x20
-		i++
-		i = encodeVarintOim(dAtA, i, uint64(m.Function))
+		i = encodeVarintOim(dAtA, i, uint64(m.Revision))
 	}
 	return i, nil
 }
 
-func (m *SCSIDisk) Marshal() (dAtA []byte, err error) {
+func (m *WatchReply) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -1183,25 +2266,30 @@ func (m *SCSIDisk) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *SCSIDisk) MarshalTo(dAtA []byte) (int, error) {
+func (m *WatchReply) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
 	_ = l
-	if m.Target != 0 {
-		dAtA[i] = 0x8
+	if m.Value != nil {
+		dAtA[i] = 0xa
 		i++
-		i = encodeVarintOim(dAtA, i, uint64(m.Target))
+		i = encodeVarintOim(dAtA, i, uint64(m.Value.Size()))
+		n5, err := m.Value.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n5
 	}
-	if m.Lun != 0 {
+	if m.Revision != 0 {
 		dAtA[i] = 0x10
 		i++
-		i = encodeVarintOim(dAtA, i, uint64(m.Lun))
+		i = encodeVarintOim(dAtA, i, uint64(m.Revision))
 	}
 	return i, nil
 }
 
-func (m *UnmapVolumeRequest) Marshal() (dAtA []byte, err error) {
+func (m *ExportRequest) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -1211,21 +2299,15 @@ func (m *UnmapVolumeRequest) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *UnmapVolumeRequest) MarshalTo(dAtA []byte) (int, error) {
+func (m *ExportRequest) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
 	_ = l
-	if len(m.VolumeId) > 0 {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintOim(dAtA, i, uint64(len(m.VolumeId)))
-		i += copy(dAtA[i:], m.VolumeId)
-	}
 	return i, nil
 }
 
-func (m *UnmapVolumeReply) Marshal() (dAtA []byte, err error) {
+func (m *ImportRequest) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -1235,15 +2317,37 @@ func (m *UnmapVolumeReply) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *UnmapVolumeReply) MarshalTo(dAtA []byte) (int, error) {
+func (m *ImportRequest) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
 	_ = l
+	if len(m.Values) > 0 {
+		for _, msg := range m.Values {
+			dAtA[i] = 0xa
+			i++
+			i = encodeVarintOim(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	if m.Overwrite {
+		dAtA[i] = 0x10
+		i++
+		if m.Overwrite {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
 	return i, nil
 }
 
-func (m *ProvisionMallocBDevRequest) Marshal() (dAtA []byte, err error) {
+func (m *ImportReply) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -1253,26 +2357,25 @@ func (m *ProvisionMallocBDevRequest) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *ProvisionMallocBDevRequest) MarshalTo(dAtA []byte) (int, error) {
+func (m *ImportReply) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
 	_ = l
-	if len(m.BdevName) > 0 {
-		dAtA[i] = 0xa
+	if m.Imported != 0 {
+		dAtA[i] = 0x8
 		i++
-		i = encodeVarintOim(dAtA, i, uint64(len(m.BdevName)))
-		i += copy(dAtA[i:], m.BdevName)
+		i = encodeVarintOim(dAtA, i, uint64(m.Imported))
 	}
-	if m.Size_ != 0 {
+	if m.Skipped != 0 {
 		dAtA[i] = 0x10
 		i++
-		i = encodeVarintOim(dAtA, i, uint64(m.Size_))
+		i = encodeVarintOim(dAtA, i, uint64(m.Skipped))
 	}
 	return i, nil
 }
 
-func (m *ProvisionMallocBDevReply) Marshal() (dAtA []byte, err error) {
+func (m *GetVersionRequest) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -1282,7 +2385,7 @@ func (m *ProvisionMallocBDevReply) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *ProvisionMallocBDevReply) MarshalTo(dAtA []byte) (int, error) {
+func (m *GetVersionRequest) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
@@ -1290,7 +2393,7 @@ func (m *ProvisionMallocBDevReply) MarshalTo(dAtA []byte) (int, error) {
 	return i, nil
 }
 
-func (m *CheckMallocBDevRequest) Marshal() (dAtA []byte, err error) {
+func (m *GetVersionReply) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -1300,21 +2403,33 @@ func (m *CheckMallocBDevRequest) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *CheckMallocBDevRequest) MarshalTo(dAtA []byte) (int, error) {
+func (m *GetVersionReply) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
 	_ = l
-	if len(m.BdevName) > 0 {
+	if len(m.Version) > 0 {
 		dAtA[i] = 0xa
 		i++
-		i = encodeVarintOim(dAtA, i, uint64(len(m.BdevName)))
-		i += copy(dAtA[i:], m.BdevName)
+		i = encodeVarintOim(dAtA, i, uint64(len(m.Version)))
+		i += copy(dAtA[i:], m.Version)
+	}
+	if len(m.Commit) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintOim(dAtA, i, uint64(len(m.Commit)))
+		i += copy(dAtA[i:], m.Commit)
+	}
+	if len(m.BuildDate) > 0 {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintOim(dAtA, i, uint64(len(m.BuildDate)))
+		i += copy(dAtA[i:], m.BuildDate)
 	}
 	return i, nil
 }
 
-func (m *CheckMallocBDevReply) Marshal() (dAtA []byte, err error) {
+func (m *MapVolumeRequest) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -1324,278 +2439,2434 @@ func (m *CheckMallocBDevReply) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *CheckMallocBDevReply) MarshalTo(dAtA []byte) (int, error) {
+func (m *MapVolumeRequest) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
 	_ = l
+	if len(m.VolumeId) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintOim(dAtA, i, uint64(len(m.VolumeId)))
+		i += copy(dAtA[i:], m.VolumeId)
+	}
+	if m.Params != nil {
+		nn6, err := m.Params.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += nn6
+	}
 	return i, nil
 }
 
-func encodeVarintOim(dAtA []byte, offset int, v uint64) int {
-	for v >= 1<<7 {
-		dAtA[offset] = uint8(v&0x7f | 0x80)
-		v >>= 7
-		offset++
+func (m *MapVolumeRequest_Malloc) MarshalTo(dAtA []byte) (int, error) {
+	i := 0
+	if m.Malloc != nil {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintOim(dAtA, i, uint64(m.Malloc.Size()))
+		n7, err := m.Malloc.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n7
 	}
-	dAtA[offset] = uint8(v)
-	return offset + 1
+	return i, nil
 }
-func (m *SetValueRequest) Size() (n int) {
-	var l int
-	_ = l
-	if m.Value != nil {
-		l = m.Value.Size()
-		n += 1 + l + sovOim(uint64(l))
+func (m *MapVolumeRequest_Ceph) MarshalTo(dAtA []byte) (int, error) {
+	i := 0
+	if m.Ceph != nil {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintOim(dAtA, i, uint64(m.Ceph.Size()))
+		n8, err := m.Ceph.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n8
 	}
-	return n
+	return i, nil
 }
-
-func (m *Value) Size() (n int) {
-	var l int
-	_ = l
-	l = len(m.Path)
-	if l > 0 {
-		n += 1 + l + sovOim(uint64(l))
-	}
-	l = len(m.Value)
-	if l > 0 {
-		n += 1 + l + sovOim(uint64(l))
+func (m *MallocParams) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
-	return n
+	return dAtA[:n], nil
 }
 
-func (m *SetValueReply) Size() (n int) {
+func (m *MallocParams) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
-	return n
+	return i, nil
 }
 
-func (m *GetValuesRequest) Size() (n int) {
-	var l int
-	_ = l
-	l = len(m.Path)
-	if l > 0 {
-		n += 1 + l + sovOim(uint64(l))
+func (m *CephParams) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
-	return n
+	return dAtA[:n], nil
 }
 
-func (m *GetValuesReply) Size() (n int) {
+func (m *CephParams) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
-	if len(m.Values) > 0 {
-		for _, e := range m.Values {
-			l = e.Size()
-			n += 1 + l + sovOim(uint64(l))
-		}
+	if len(m.UserId) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintOim(dAtA, i, uint64(len(m.UserId)))
+		i += copy(dAtA[i:], m.UserId)
 	}
-	return n
-}
-
-func (m *MapVolumeRequest) Size() (n int) {
-	var l int
-	_ = l
-	l = len(m.VolumeId)
-	if l > 0 {
-		n += 1 + l + sovOim(uint64(l))
+	if len(m.Secret) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintOim(dAtA, i, uint64(len(m.Secret)))
+		i += copy(dAtA[i:], m.Secret)
 	}
-	if m.Params != nil {
-		n += m.Params.Size()
+	if len(m.Monitors) > 0 {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintOim(dAtA, i, uint64(len(m.Monitors)))
+		i += copy(dAtA[i:], m.Monitors)
 	}
-	return n
-}
-
-func (m *MapVolumeRequest_Malloc) Size() (n int) {
-	var l int
-	_ = l
-	if m.Malloc != nil {
-		l = m.Malloc.Size()
-		n += 1 + l + sovOim(uint64(l))
+	if len(m.Pool) > 0 {
+		dAtA[i] = 0x22
+		i++
+		i = encodeVarintOim(dAtA, i, uint64(len(m.Pool)))
+		i += copy(dAtA[i:], m.Pool)
 	}
-	return n
-}
-func (m *MapVolumeRequest_Ceph) Size() (n int) {
-	var l int
-	_ = l
-	if m.Ceph != nil {
-		l = m.Ceph.Size()
-		n += 1 + l + sovOim(uint64(l))
+	if len(m.Image) > 0 {
+		dAtA[i] = 0x2a
+		i++
+		i = encodeVarintOim(dAtA, i, uint64(len(m.Image)))
+		i += copy(dAtA[i:], m.Image)
 	}
-	return n
-}
-func (m *MallocParams) Size() (n int) {
-	var l int
-	_ = l
-	return n
+	return i, nil
 }
 
-func (m *CephParams) Size() (n int) {
-	var l int
-	_ = l
-	l = len(m.UserId)
-	if l > 0 {
-		n += 1 + l + sovOim(uint64(l))
-	}
-	l = len(m.Secret)
-	if l > 0 {
-		n += 1 + l + sovOim(uint64(l))
-	}
-	l = len(m.Monitors)
-	if l > 0 {
-		n += 1 + l + sovOim(uint64(l))
-	}
-	l = len(m.Pool)
-	if l > 0 {
-		n += 1 + l + sovOim(uint64(l))
-	}
-	l = len(m.Image)
-	if l > 0 {
-		n += 1 + l + sovOim(uint64(l))
+func (m *MapVolumeReply) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
-	return n
+	return dAtA[:n], nil
 }
 
-func (m *MapVolumeReply) Size() (n int) {
+func (m *MapVolumeReply) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
 	if m.PciAddress != nil {
-		l = m.PciAddress.Size()
-		n += 1 + l + sovOim(uint64(l))
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintOim(dAtA, i, uint64(m.PciAddress.Size()))
+		n9, err := m.PciAddress.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n9
 	}
 	if m.ScsiDisk != nil {
-		l = m.ScsiDisk.Size()
-		n += 1 + l + sovOim(uint64(l))
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintOim(dAtA, i, uint64(m.ScsiDisk.Size()))
+		n10, err := m.ScsiDisk.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n10
 	}
-	return n
+	return i, nil
 }
 
-func (m *PCIAddress) Size() (n int) {
+func (m *PCIAddress) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *PCIAddress) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
 	if m.Domain != 0 {
-		n += 1 + sovOim(uint64(m.Domain))
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintOim(dAtA, i, uint64(m.Domain))
 	}
 	if m.Bus != 0 {
-		n += 1 + sovOim(uint64(m.Bus))
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintOim(dAtA, i, uint64(m.Bus))
 	}
 	if m.Device != 0 {
-		n += 1 + sovOim(uint64(m.Device))
+		dAtA[i] = 0x18
+		i++
+		i = encodeVarintOim(dAtA, i, uint64(m.Device))
 	}
 	if m.Function != 0 {
-		n += 1 + sovOim(uint64(m.Function))
+		dAtA[i] = 0x20
+		i++
+		i = encodeVarintOim(dAtA, i, uint64(m.Function))
 	}
-	return n
+	return i, nil
 }
 
-func (m *SCSIDisk) Size() (n int) {
-	var l int
-	_ = l
-	if m.Target != 0 {
-		n += 1 + sovOim(uint64(m.Target))
-	}
-	if m.Lun != 0 {
-		n += 1 + sovOim(uint64(m.Lun))
+func (m *SCSIDisk) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
-	return n
+	return dAtA[:n], nil
 }
 
-func (m *UnmapVolumeRequest) Size() (n int) {
+func (m *SCSIDisk) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
-	l = len(m.VolumeId)
-	if l > 0 {
-		n += 1 + l + sovOim(uint64(l))
+	if m.Target != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintOim(dAtA, i, uint64(m.Target))
 	}
-	return n
+	if m.Lun != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintOim(dAtA, i, uint64(m.Lun))
+	}
+	return i, nil
 }
 
-func (m *UnmapVolumeReply) Size() (n int) {
+func (m *UnmapVolumeRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *UnmapVolumeRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
-	return n
+	if len(m.VolumeId) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintOim(dAtA, i, uint64(len(m.VolumeId)))
+		i += copy(dAtA[i:], m.VolumeId)
+	}
+	return i, nil
 }
 
-func (m *ProvisionMallocBDevRequest) Size() (n int) {
+func (m *UnmapVolumeReply) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *UnmapVolumeReply) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
-	l = len(m.BdevName)
-	if l > 0 {
-		n += 1 + l + sovOim(uint64(l))
+	return i, nil
+}
+
+func (m *ProvisionMallocBDevRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ProvisionMallocBDevRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.BdevName) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintOim(dAtA, i, uint64(len(m.BdevName)))
+		i += copy(dAtA[i:], m.BdevName)
 	}
 	if m.Size_ != 0 {
-		n += 1 + sovOim(uint64(m.Size_))
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintOim(dAtA, i, uint64(m.Size_))
 	}
-	return n
+	return i, nil
 }
 
-func (m *ProvisionMallocBDevReply) Size() (n int) {
+func (m *ProvisionMallocBDevReply) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ProvisionMallocBDevReply) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
-	return n
+	return i, nil
 }
 
-func (m *CheckMallocBDevRequest) Size() (n int) {
+func (m *CheckMallocBDevRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *CheckMallocBDevRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
-	l = len(m.BdevName)
-	if l > 0 {
-		n += 1 + l + sovOim(uint64(l))
+	if len(m.BdevName) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintOim(dAtA, i, uint64(len(m.BdevName)))
+		i += copy(dAtA[i:], m.BdevName)
 	}
-	return n
+	return i, nil
 }
 
-func (m *CheckMallocBDevReply) Size() (n int) {
+func (m *CheckMallocBDevReply) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *CheckMallocBDevReply) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
-	return n
+	return i, nil
 }
 
-func sovOim(x uint64) (n int) {
-	for {
-		n++
-		x >>= 7
-		if x == 0 {
-			break
-		}
+func (m *ListMallocBDevsRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
-	return n
+	return dAtA[:n], nil
 }
-func sozOim(x uint64) (n int) {
-	return sovOim(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+
+func (m *ListMallocBDevsRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	return i, nil
 }
-func (m *SetValueRequest) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowOim
-			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
-			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= (uint64(b) & 0x7F) << shift
-			if b < 0x80 {
-				break
+
+func (m *ListMallocBDevsReply) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ListMallocBDevsReply) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Bdevs) > 0 {
+		for _, msg := range m.Bdevs {
+			dAtA[i] = 0xa
+			i++
+			i = encodeVarintOim(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
 			}
+			i += n
 		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: SetValueRequest: wiretype end group for non-group")
+	}
+	return i, nil
+}
+
+func (m *MallocBDev) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *MallocBDev) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.BdevName) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintOim(dAtA, i, uint64(len(m.BdevName)))
+		i += copy(dAtA[i:], m.BdevName)
+	}
+	if m.Size_ != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintOim(dAtA, i, uint64(m.Size_))
+	}
+	return i, nil
+}
+
+func (m *GetNodeInfoRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *GetNodeInfoRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	return i, nil
+}
+
+func (m *GetNodeInfoReply) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *GetNodeInfoReply) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.MaxVolumes != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintOim(dAtA, i, uint64(m.MaxVolumes))
+	}
+	if m.MappedVolumes != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintOim(dAtA, i, uint64(m.MappedVolumes))
+	}
+	return i, nil
+}
+
+func encodeVarintOim(dAtA []byte, offset int, v uint64) int {
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return offset + 1
+}
+func (m *SetValueRequest) Size() (n int) {
+	var l int
+	_ = l
+	if m.Value != nil {
+		l = m.Value.Size()
+		n += 1 + l + sovOim(uint64(l))
+	}
+	if m.TtlSeconds != 0 {
+		n += 1 + sovOim(uint64(m.TtlSeconds))
+	}
+	return n
+}
+
+func (m *Value) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.Path)
+	if l > 0 {
+		n += 1 + l + sovOim(uint64(l))
+	}
+	l = len(m.Value)
+	if l > 0 {
+		n += 1 + l + sovOim(uint64(l))
+	}
+	if m.RemainingTtlSeconds != 0 {
+		n += 1 + sovOim(uint64(m.RemainingTtlSeconds))
+	}
+	if m.Created != nil {
+		l = m.Created.Size()
+		n += 1 + l + sovOim(uint64(l))
+	}
+	if m.Updated != nil {
+		l = m.Updated.Size()
+		n += 1 + l + sovOim(uint64(l))
+	}
+	l = len(m.UpdatedBy)
+	if l > 0 {
+		n += 1 + l + sovOim(uint64(l))
+	}
+	if m.TtlSeconds != 0 {
+		n += 1 + sovOim(uint64(m.TtlSeconds))
+	}
+	return n
+}
+
+func (m *SetValueReply) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.PreviousValue)
+	if l > 0 {
+		n += 1 + l + sovOim(uint64(l))
+	}
+	if m.Existed {
+		n += 2
+	}
+	return n
+}
+
+func (m *SetValuesRequest) Size() (n int) {
+	var l int
+	_ = l
+	if len(m.Values) > 0 {
+		for _, e := range m.Values {
+			l = e.Size()
+			n += 1 + l + sovOim(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *SetValuesReply) Size() (n int) {
+	var l int
+	_ = l
+	if len(m.Status) > 0 {
+		for _, e := range m.Status {
+			l = e.Size()
+			n += 1 + l + sovOim(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *SetValueStatus) Size() (n int) {
+	var l int
+	_ = l
+	if m.Code != 0 {
+		n += 1 + sovOim(uint64(m.Code))
+	}
+	l = len(m.Message)
+	if l > 0 {
+		n += 1 + l + sovOim(uint64(l))
+	}
+	return n
+}
+
+func (m *SetValueIfMatchRequest) Size() (n int) {
+	var l int
+	_ = l
+	if m.Update != nil {
+		l = m.Update.Size()
+		n += 1 + l + sovOim(uint64(l))
+	}
+	l = len(m.ExpectedValue)
+	if l > 0 {
+		n += 1 + l + sovOim(uint64(l))
+	}
+	return n
+}
+
+func (m *HeartbeatRequest) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.Path)
+	if l > 0 {
+		n += 1 + l + sovOim(uint64(l))
+	}
+	return n
+}
+
+func (m *HeartbeatReply) Size() (n int) {
+	var l int
+	_ = l
+	return n
+}
+
+func (m *GetValuesRequest) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.Path)
+	if l > 0 {
+		n += 1 + l + sovOim(uint64(l))
+	}
+	if m.MaxEntries != 0 {
+		n += 1 + sovOim(uint64(m.MaxEntries))
+	}
+	l = len(m.StartingToken)
+	if l > 0 {
+		n += 1 + l + sovOim(uint64(l))
+	}
+	if m.WithMetadata {
+		n += 2
+	}
+	return n
+}
+
+func (m *GetValuesReply) Size() (n int) {
+	var l int
+	_ = l
+	if len(m.Values) > 0 {
+		for _, e := range m.Values {
+			l = e.Size()
+			n += 1 + l + sovOim(uint64(l))
+		}
+	}
+	l = len(m.NextToken)
+	if l > 0 {
+		n += 1 + l + sovOim(uint64(l))
+	}
+	return n
+}
+
+func (m *WatchRequest) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.Path)
+	if l > 0 {
+		n += 1 + l + sovOim(uint64(l))
+	}
+	if m.Revision != 0 {
+		n += 1 + sovOim(uint64(m.Revision))
+	}
+	return n
+}
+
+func (m *WatchReply) Size() (n int) {
+	var l int
+	_ = l
+	if m.Value != nil {
+		l = m.Value.Size()
+		n += 1 + l + sovOim(uint64(l))
+	}
+	if m.Revision != 0 {
+		n += 1 + sovOim(uint64(m.Revision))
+	}
+	return n
+}
+
+func (m *ExportRequest) Size() (n int) {
+	var l int
+	_ = l
+	return n
+}
+
+func (m *ImportRequest) Size() (n int) {
+	var l int
+	_ = l
+	if len(m.Values) > 0 {
+		for _, e := range m.Values {
+			l = e.Size()
+			n += 1 + l + sovOim(uint64(l))
+		}
+	}
+	if m.Overwrite {
+		n += 2
+	}
+	return n
+}
+
+func (m *ImportReply) Size() (n int) {
+	var l int
+	_ = l
+	if m.Imported != 0 {
+		n += 1 + sovOim(uint64(m.Imported))
+	}
+	if m.Skipped != 0 {
+		n += 1 + sovOim(uint64(m.Skipped))
+	}
+	return n
+}
+
+func (m *GetVersionRequest) Size() (n int) {
+	var l int
+	_ = l
+	return n
+}
+
+func (m *GetVersionReply) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.Version)
+	if l > 0 {
+		n += 1 + l + sovOim(uint64(l))
+	}
+	l = len(m.Commit)
+	if l > 0 {
+		n += 1 + l + sovOim(uint64(l))
+	}
+	l = len(m.BuildDate)
+	if l > 0 {
+		n += 1 + l + sovOim(uint64(l))
+	}
+	return n
+}
+
+func (m *MapVolumeRequest) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.VolumeId)
+	if l > 0 {
+		n += 1 + l + sovOim(uint64(l))
+	}
+	if m.Params != nil {
+		n += m.Params.Size()
+	}
+	return n
+}
+
+func (m *MapVolumeRequest_Malloc) Size() (n int) {
+	var l int
+	_ = l
+	if m.Malloc != nil {
+		l = m.Malloc.Size()
+		n += 1 + l + sovOim(uint64(l))
+	}
+	return n
+}
+func (m *MapVolumeRequest_Ceph) Size() (n int) {
+	var l int
+	_ = l
+	if m.Ceph != nil {
+		l = m.Ceph.Size()
+		n += 1 + l + sovOim(uint64(l))
+	}
+	return n
+}
+func (m *MallocParams) Size() (n int) {
+	var l int
+	_ = l
+	return n
+}
+
+func (m *CephParams) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.UserId)
+	if l > 0 {
+		n += 1 + l + sovOim(uint64(l))
+	}
+	l = len(m.Secret)
+	if l > 0 {
+		n += 1 + l + sovOim(uint64(l))
+	}
+	l = len(m.Monitors)
+	if l > 0 {
+		n += 1 + l + sovOim(uint64(l))
+	}
+	l = len(m.Pool)
+	if l > 0 {
+		n += 1 + l + sovOim(uint64(l))
+	}
+	l = len(m.Image)
+	if l > 0 {
+		n += 1 + l + sovOim(uint64(l))
+	}
+	return n
+}
+
+func (m *MapVolumeReply) Size() (n int) {
+	var l int
+	_ = l
+	if m.PciAddress != nil {
+		l = m.PciAddress.Size()
+		n += 1 + l + sovOim(uint64(l))
+	}
+	if m.ScsiDisk != nil {
+		l = m.ScsiDisk.Size()
+		n += 1 + l + sovOim(uint64(l))
+	}
+	return n
+}
+
+func (m *PCIAddress) Size() (n int) {
+	var l int
+	_ = l
+	if m.Domain != 0 {
+		n += 1 + sovOim(uint64(m.Domain))
+	}
+	if m.Bus != 0 {
+		n += 1 + sovOim(uint64(m.Bus))
+	}
+	if m.Device != 0 {
+		n += 1 + sovOim(uint64(m.Device))
+	}
+	if m.Function != 0 {
+		n += 1 + sovOim(uint64(m.Function))
+	}
+	return n
+}
+
+func (m *SCSIDisk) Size() (n int) {
+	var l int
+	_ = l
+	if m.Target != 0 {
+		n += 1 + sovOim(uint64(m.Target))
+	}
+	if m.Lun != 0 {
+		n += 1 + sovOim(uint64(m.Lun))
+	}
+	return n
+}
+
+func (m *UnmapVolumeRequest) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.VolumeId)
+	if l > 0 {
+		n += 1 + l + sovOim(uint64(l))
+	}
+	return n
+}
+
+func (m *UnmapVolumeReply) Size() (n int) {
+	var l int
+	_ = l
+	return n
+}
+
+func (m *ProvisionMallocBDevRequest) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.BdevName)
+	if l > 0 {
+		n += 1 + l + sovOim(uint64(l))
+	}
+	if m.Size_ != 0 {
+		n += 1 + sovOim(uint64(m.Size_))
+	}
+	return n
+}
+
+func (m *ProvisionMallocBDevReply) Size() (n int) {
+	var l int
+	_ = l
+	return n
+}
+
+func (m *CheckMallocBDevRequest) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.BdevName)
+	if l > 0 {
+		n += 1 + l + sovOim(uint64(l))
+	}
+	return n
+}
+
+func (m *CheckMallocBDevReply) Size() (n int) {
+	var l int
+	_ = l
+	return n
+}
+
+func (m *ListMallocBDevsRequest) Size() (n int) {
+	var l int
+	_ = l
+	return n
+}
+
+func (m *ListMallocBDevsReply) Size() (n int) {
+	var l int
+	_ = l
+	if len(m.Bdevs) > 0 {
+		for _, e := range m.Bdevs {
+			l = e.Size()
+			n += 1 + l + sovOim(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *MallocBDev) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.BdevName)
+	if l > 0 {
+		n += 1 + l + sovOim(uint64(l))
+	}
+	if m.Size_ != 0 {
+		n += 1 + sovOim(uint64(m.Size_))
+	}
+	return n
+}
+
+func (m *GetNodeInfoRequest) Size() (n int) {
+	var l int
+	_ = l
+	return n
+}
+
+func (m *GetNodeInfoReply) Size() (n int) {
+	var l int
+	_ = l
+	if m.MaxVolumes != 0 {
+		n += 1 + sovOim(uint64(m.MaxVolumes))
+	}
+	if m.MappedVolumes != 0 {
+		n += 1 + sovOim(uint64(m.MappedVolumes))
+	}
+	return n
+}
+
+func sovOim(x uint64) (n int) {
+	for {
+		n++
+		x >>= 7
+		if x == 0 {
+			break
+		}
+	}
+	return n
+}
+func sozOim(x uint64) (n int) {
+	return sovOim(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (m *SetValueRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowOim
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: SetValueRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: SetValueRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Value", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOim
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthOim
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Value == nil {
+				m.Value = &Value{}
+			}
+			if err := m.Value.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TtlSeconds", wireType)
+			}
+			m.TtlSeconds = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOim
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.TtlSeconds |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipOim(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthOim
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Value) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowOim
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Value: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Value: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Path", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOim
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthOim
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Path = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Value", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOim
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthOim
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Value = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RemainingTtlSeconds", wireType)
+			}
+			m.RemainingTtlSeconds = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOim
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.RemainingTtlSeconds |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Created", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOim
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthOim
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Created == nil {
+				m.Created = &google_protobuf.Timestamp{}
+			}
+			if err := m.Created.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Updated", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOim
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthOim
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Updated == nil {
+				m.Updated = &google_protobuf.Timestamp{}
+			}
+			if err := m.Updated.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field UpdatedBy", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOim
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthOim
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.UpdatedBy = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TtlSeconds", wireType)
+			}
+			m.TtlSeconds = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOim
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.TtlSeconds |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipOim(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthOim
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *SetValueReply) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowOim
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: SetValueReply: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: SetValueReply: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PreviousValue", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOim
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthOim
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.PreviousValue = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Existed", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOim
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Existed = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipOim(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthOim
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *SetValuesRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowOim
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: SetValuesRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: SetValuesRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Values", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOim
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthOim
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Values = append(m.Values, &SetValueRequest{})
+			if err := m.Values[len(m.Values)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipOim(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthOim
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *SetValuesReply) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowOim
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: SetValuesReply: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: SetValuesReply: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Status", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOim
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthOim
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Status = append(m.Status, &SetValueStatus{})
+			if err := m.Status[len(m.Status)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipOim(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthOim
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *SetValueStatus) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowOim
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: SetValueStatus: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: SetValueStatus: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Code", wireType)
+			}
+			m.Code = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOim
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Code |= (int32(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Message", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOim
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthOim
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Message = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipOim(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthOim
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *SetValueIfMatchRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowOim
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: SetValueIfMatchRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: SetValueIfMatchRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Update", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOim
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthOim
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Update == nil {
+				m.Update = &SetValueRequest{}
+			}
+			if err := m.Update.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ExpectedValue", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOim
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthOim
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ExpectedValue = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipOim(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthOim
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *HeartbeatRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowOim
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: HeartbeatRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: HeartbeatRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Path", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOim
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthOim
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Path = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipOim(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthOim
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *HeartbeatReply) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowOim
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: HeartbeatReply: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: HeartbeatReply: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipOim(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthOim
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *GetValuesRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowOim
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: GetValuesRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: GetValuesRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Path", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOim
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthOim
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Path = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxEntries", wireType)
+			}
+			m.MaxEntries = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOim
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxEntries |= (int32(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field StartingToken", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOim
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthOim
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.StartingToken = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field WithMetadata", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOim
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.WithMetadata = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipOim(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthOim
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *GetValuesReply) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowOim
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: GetValuesReply: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: GetValuesReply: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Values", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOim
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthOim
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Values = append(m.Values, &Value{})
+			if err := m.Values[len(m.Values)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NextToken", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOim
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthOim
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.NextToken = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipOim(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthOim
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *WatchRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowOim
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: WatchRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: WatchRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Path", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOim
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthOim
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Path = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Revision", wireType)
+			}
+			m.Revision = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOim
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Revision |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipOim(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthOim
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *WatchReply) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowOim
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: WatchReply: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: WatchReply: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Value", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOim
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthOim
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Value == nil {
+				m.Value = &Value{}
+			}
+			if err := m.Value.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Revision", wireType)
+			}
+			m.Revision = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOim
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Revision |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipOim(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthOim
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ExportRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowOim
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ExportRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ExportRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipOim(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthOim
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ImportRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowOim
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ImportRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: SetValueRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ImportRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Value", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Values", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -1619,13 +4890,31 @@ func (m *SetValueRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Value == nil {
-				m.Value = &Value{}
-			}
-			if err := m.Value.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.Values = append(m.Values, &Value{})
+			if err := m.Values[len(m.Values)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Overwrite", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOim
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Overwrite = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipOim(dAtA[iNdEx:])
@@ -1647,7 +4936,7 @@ func (m *SetValueRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *Value) Unmarshal(dAtA []byte) error {
+func (m *ImportReply) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -1670,17 +4959,17 @@ func (m *Value) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: Value: wiretype end group for non-group")
+			return fmt.Errorf("proto: ImportReply: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Value: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ImportReply: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Path", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Imported", wireType)
 			}
-			var stringLen uint64
+			m.Imported = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowOim
@@ -1690,26 +4979,16 @@ func (m *Value) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				m.Imported |= (int32(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthOim
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Path = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
 		case 2:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Value", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Skipped", wireType)
 			}
-			var stringLen uint64
+			m.Skipped = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowOim
@@ -1719,21 +4998,61 @@ func (m *Value) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				m.Skipped |= (int32(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipOim(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
 				return ErrInvalidLengthOim
 			}
-			postIndex := iNdEx + intStringLen
-			if postIndex > l {
+			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Value = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *GetVersionRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowOim
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: GetVersionRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: GetVersionRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
 		default:
 			iNdEx = preIndex
 			skippy, err := skipOim(dAtA[iNdEx:])
@@ -1755,7 +5074,7 @@ func (m *Value) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *SetValueReply) Unmarshal(dAtA []byte) error {
+func (m *GetVersionReply) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -1778,12 +5097,99 @@ func (m *SetValueReply) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: SetValueReply: wiretype end group for non-group")
+			return fmt.Errorf("proto: GetVersionReply: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: SetValueReply: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: GetVersionReply: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Version", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOim
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthOim
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Version = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Commit", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOim
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthOim
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Commit = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BuildDate", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOim
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthOim
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.BuildDate = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipOim(dAtA[iNdEx:])
@@ -1805,7 +5211,7 @@ func (m *SetValueReply) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *GetValuesRequest) Unmarshal(dAtA []byte) error {
+func (m *MapVolumeRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -1828,15 +5234,15 @@ func (m *GetValuesRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: GetValuesRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: MapVolumeRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: GetValuesRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: MapVolumeRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Path", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field VolumeId", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -1861,7 +5267,71 @@ func (m *GetValuesRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Path = string(dAtA[iNdEx:postIndex])
+			m.VolumeId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Malloc", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOim
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthOim
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &MallocParams{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Params = &MapVolumeRequest_Malloc{v}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Ceph", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOim
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthOim
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &CephParams{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Params = &MapVolumeRequest_Ceph{v}
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -1884,7 +5354,7 @@ func (m *GetValuesRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *GetValuesReply) Unmarshal(dAtA []byte) error {
+func (m *MallocParams) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -1907,43 +5377,12 @@ func (m *GetValuesReply) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: GetValuesReply: wiretype end group for non-group")
+			return fmt.Errorf("proto: MallocParams: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: GetValuesReply: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: MallocParams: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
-		case 1:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Values", wireType)
-			}
-			var msglen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowOim
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			if msglen < 0 {
-				return ErrInvalidLengthOim
-			}
-			postIndex := iNdEx + msglen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Values = append(m.Values, &Value{})
-			if err := m.Values[len(m.Values)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipOim(dAtA[iNdEx:])
@@ -1965,7 +5404,7 @@ func (m *GetValuesReply) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *MapVolumeRequest) Unmarshal(dAtA []byte) error {
+func (m *CephParams) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -1988,15 +5427,15 @@ func (m *MapVolumeRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: MapVolumeRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: CephParams: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: MapVolumeRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: CephParams: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field VolumeId", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field UserId", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -2021,13 +5460,13 @@ func (m *MapVolumeRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.VolumeId = string(dAtA[iNdEx:postIndex])
+			m.UserId = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Malloc", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Secret", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowOim
@@ -2037,29 +5476,26 @@ func (m *MapVolumeRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				stringLen |= (uint64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthOim
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			v := &MallocParams{}
-			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			m.Params = &MapVolumeRequest_Malloc{v}
+			m.Secret = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Ceph", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Monitors", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowOim
@@ -2069,23 +5505,78 @@ func (m *MapVolumeRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				stringLen |= (uint64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthOim
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			v := &CephParams{}
-			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			m.Monitors = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Pool", wireType)
 			}
-			m.Params = &MapVolumeRequest_Ceph{v}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOim
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthOim
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Pool = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Image", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOim
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthOim
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Image = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -2108,7 +5599,7 @@ func (m *MapVolumeRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *MallocParams) Unmarshal(dAtA []byte) error {
+func (m *MapVolumeReply) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2131,12 +5622,78 @@ func (m *MallocParams) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: MallocParams: wiretype end group for non-group")
+			return fmt.Errorf("proto: MapVolumeReply: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: MallocParams: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: MapVolumeReply: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PciAddress", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOim
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthOim
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.PciAddress == nil {
+				m.PciAddress = &PCIAddress{}
+			}
+			if err := m.PciAddress.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ScsiDisk", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOim
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthOim
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.ScsiDisk == nil {
+				m.ScsiDisk = &SCSIDisk{}
+			}
+			if err := m.ScsiDisk.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipOim(dAtA[iNdEx:])
@@ -2158,7 +5715,7 @@ func (m *MallocParams) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *CephParams) Unmarshal(dAtA []byte) error {
+func (m *PCIAddress) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2181,17 +5738,17 @@ func (m *CephParams) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: CephParams: wiretype end group for non-group")
+			return fmt.Errorf("proto: PCIAddress: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: CephParams: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: PCIAddress: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field UserId", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Domain", wireType)
 			}
-			var stringLen uint64
+			m.Domain = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowOim
@@ -2201,26 +5758,16 @@ func (m *CephParams) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				m.Domain |= (uint32(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthOim
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.UserId = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
 		case 2:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Secret", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Bus", wireType)
 			}
-			var stringLen uint64
+			m.Bus = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowOim
@@ -2230,26 +5777,16 @@ func (m *CephParams) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				m.Bus |= (uint32(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthOim
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Secret = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
 		case 3:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Monitors", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Device", wireType)
 			}
-			var stringLen uint64
+			m.Device = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowOim
@@ -2259,26 +5796,16 @@ func (m *CephParams) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				m.Device |= (uint32(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthOim
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Monitors = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
 		case 4:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Pool", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Function", wireType)
 			}
-			var stringLen uint64
+			m.Function = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowOim
@@ -2288,26 +5815,66 @@ func (m *CephParams) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				m.Function |= (uint32(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipOim(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
 				return ErrInvalidLengthOim
 			}
-			postIndex := iNdEx + intStringLen
-			if postIndex > l {
+			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Pool = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		case 5:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Image", wireType)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *SCSIDisk) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowOim
 			}
-			var stringLen uint64
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: SCSIDisk: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: SCSIDisk: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Target", wireType)
+			}
+			m.Target = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowOim
@@ -2317,21 +5884,30 @@ func (m *CephParams) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				m.Target |= (uint32(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthOim
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Lun", wireType)
 			}
-			postIndex := iNdEx + intStringLen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
+			m.Lun = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOim
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Lun |= (uint32(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-			m.Image = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipOim(dAtA[iNdEx:])
@@ -2353,7 +5929,7 @@ func (m *CephParams) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *MapVolumeReply) Unmarshal(dAtA []byte) error {
+func (m *UnmapVolumeRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2376,17 +5952,17 @@ func (m *MapVolumeReply) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: MapVolumeReply: wiretype end group for non-group")
+			return fmt.Errorf("proto: UnmapVolumeRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: MapVolumeReply: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: UnmapVolumeRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field PciAddress", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field VolumeId", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowOim
@@ -2396,58 +5972,71 @@ func (m *MapVolumeReply) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				stringLen |= (uint64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthOim
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.PciAddress == nil {
-				m.PciAddress = &PCIAddress{}
-			}
-			if err := m.PciAddress.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.VolumeId = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 2:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ScsiDisk", wireType)
-			}
-			var msglen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowOim
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipOim(dAtA[iNdEx:])
+			if err != nil {
+				return err
 			}
-			if msglen < 0 {
+			if skippy < 0 {
 				return ErrInvalidLengthOim
 			}
-			postIndex := iNdEx + msglen
-			if postIndex > l {
+			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.ScsiDisk == nil {
-				m.ScsiDisk = &SCSIDisk{}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *UnmapVolumeReply) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowOim
 			}
-			if err := m.ScsiDisk.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
 			}
-			iNdEx = postIndex
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: UnmapVolumeReply: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: UnmapVolumeReply: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
 		default:
 			iNdEx = preIndex
 			skippy, err := skipOim(dAtA[iNdEx:])
@@ -2469,7 +6058,7 @@ func (m *MapVolumeReply) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *PCIAddress) Unmarshal(dAtA []byte) error {
+func (m *ProvisionMallocBDevRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2492,36 +6081,17 @@ func (m *PCIAddress) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: PCIAddress: wiretype end group for non-group")
+			return fmt.Errorf("proto: ProvisionMallocBDevRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: PCIAddress: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ProvisionMallocBDevRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Domain", wireType)
-			}
-			m.Domain = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowOim
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.Domain |= (uint32(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-		case 2:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Bus", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BdevName", wireType)
 			}
-			m.Bus = 0
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowOim
@@ -2531,35 +6101,26 @@ func (m *PCIAddress) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Bus |= (uint32(b) & 0x7F) << shift
+				stringLen |= (uint64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 3:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Device", wireType)
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthOim
 			}
-			m.Device = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowOim
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.Device |= (uint32(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
 			}
-		case 4:
+			m.BdevName = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Function", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Size_", wireType)
 			}
-			m.Function = 0
+			m.Size_ = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowOim
@@ -2569,7 +6130,7 @@ func (m *PCIAddress) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Function |= (uint32(b) & 0x7F) << shift
+				m.Size_ |= (int64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -2595,7 +6156,7 @@ func (m *PCIAddress) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *SCSIDisk) Unmarshal(dAtA []byte) error {
+func (m *ProvisionMallocBDevReply) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2618,50 +6179,12 @@ func (m *SCSIDisk) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: SCSIDisk: wiretype end group for non-group")
+			return fmt.Errorf("proto: ProvisionMallocBDevReply: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: SCSIDisk: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ProvisionMallocBDevReply: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
-		case 1:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Target", wireType)
-			}
-			m.Target = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowOim
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.Target |= (uint32(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-		case 2:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Lun", wireType)
-			}
-			m.Lun = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowOim
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.Lun |= (uint32(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipOim(dAtA[iNdEx:])
@@ -2683,7 +6206,7 @@ func (m *SCSIDisk) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *UnmapVolumeRequest) Unmarshal(dAtA []byte) error {
+func (m *CheckMallocBDevRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2706,15 +6229,15 @@ func (m *UnmapVolumeRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: UnmapVolumeRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: CheckMallocBDevRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: UnmapVolumeRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: CheckMallocBDevRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field VolumeId", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field BdevName", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -2739,7 +6262,7 @@ func (m *UnmapVolumeRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.VolumeId = string(dAtA[iNdEx:postIndex])
+			m.BdevName = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -2762,7 +6285,7 @@ func (m *UnmapVolumeRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *UnmapVolumeReply) Unmarshal(dAtA []byte) error {
+func (m *CheckMallocBDevReply) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2785,10 +6308,10 @@ func (m *UnmapVolumeReply) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: UnmapVolumeReply: wiretype end group for non-group")
+			return fmt.Errorf("proto: CheckMallocBDevReply: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: UnmapVolumeReply: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: CheckMallocBDevReply: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		default:
@@ -2812,7 +6335,7 @@ func (m *UnmapVolumeReply) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ProvisionMallocBDevRequest) Unmarshal(dAtA []byte) error {
+func (m *ListMallocBDevsRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2835,60 +6358,12 @@ func (m *ProvisionMallocBDevRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ProvisionMallocBDevRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: ListMallocBDevsRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ProvisionMallocBDevRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ListMallocBDevsRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
-		case 1:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field BdevName", wireType)
-			}
-			var stringLen uint64
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowOim
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthOim
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.BdevName = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		case 2:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Size_", wireType)
-			}
-			m.Size_ = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowOim
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.Size_ |= (int64(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipOim(dAtA[iNdEx:])
@@ -2910,7 +6385,7 @@ func (m *ProvisionMallocBDevRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ProvisionMallocBDevReply) Unmarshal(dAtA []byte) error {
+func (m *ListMallocBDevsReply) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2933,12 +6408,43 @@ func (m *ProvisionMallocBDevReply) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ProvisionMallocBDevReply: wiretype end group for non-group")
+			return fmt.Errorf("proto: ListMallocBDevsReply: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ProvisionMallocBDevReply: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ListMallocBDevsReply: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Bdevs", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOim
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthOim
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Bdevs = append(m.Bdevs, &MallocBDev{})
+			if err := m.Bdevs[len(m.Bdevs)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipOim(dAtA[iNdEx:])
@@ -2960,7 +6466,7 @@ func (m *ProvisionMallocBDevReply) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *CheckMallocBDevRequest) Unmarshal(dAtA []byte) error {
+func (m *MallocBDev) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2983,10 +6489,10 @@ func (m *CheckMallocBDevRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: CheckMallocBDevRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: MallocBDev: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: CheckMallocBDevRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: MallocBDev: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -3018,6 +6524,25 @@ func (m *CheckMallocBDevRequest) Unmarshal(dAtA []byte) error {
 			}
 			m.BdevName = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Size_", wireType)
+			}
+			m.Size_ = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOim
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Size_ |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipOim(dAtA[iNdEx:])
@@ -3039,7 +6564,7 @@ func (m *CheckMallocBDevRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *CheckMallocBDevReply) Unmarshal(dAtA []byte) error {
+func (m *GetNodeInfoRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -3062,12 +6587,100 @@ func (m *CheckMallocBDevReply) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: CheckMallocBDevReply: wiretype end group for non-group")
+			return fmt.Errorf("proto: GetNodeInfoRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: CheckMallocBDevReply: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: GetNodeInfoRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipOim(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthOim
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *GetNodeInfoReply) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowOim
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: GetNodeInfoReply: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: GetNodeInfoReply: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxVolumes", wireType)
+			}
+			m.MaxVolumes = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOim
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxVolumes |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MappedVolumes", wireType)
+			}
+			m.MappedVolumes = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOim
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MappedVolumes |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipOim(dAtA[iNdEx:])
@@ -3197,50 +6810,94 @@ var (
 func init() { proto.RegisterFile("oim.proto", fileDescriptorOim) }
 
 var fileDescriptorOim = []byte{
-	// 718 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x55, 0x4b, 0x6f, 0xd3, 0x40,
-	0x10, 0x8e, 0x9b, 0xd6, 0x38, 0x13, 0xd2, 0x46, 0x4b, 0x9b, 0x5a, 0x06, 0x45, 0xd5, 0x22, 0x50,
-	0x2f, 0xa4, 0x34, 0xe5, 0x71, 0x41, 0x42, 0x34, 0x45, 0x90, 0x43, 0x51, 0x71, 0x45, 0x0f, 0x48,
-	0x28, 0x72, 0xec, 0x6d, 0xb2, 0x74, 0xed, 0x35, 0x5e, 0xdb, 0x28, 0x5c, 0x39, 0x71, 0x43, 0xe2,
-	0x3f, 0x21, 0x8e, 0xfc, 0x04, 0x54, 0xfe, 0x08, 0xda, 0xf5, 0x23, 0x69, 0x93, 0x22, 0xf5, 0x36,
-	0x8f, 0x6f, 0xbe, 0x99, 0x9d, 0x87, 0x0d, 0x35, 0x4e, 0xfd, 0x4e, 0x18, 0xf1, 0x98, 0x23, 0x5d,
-	0x8a, 0xe9, 0x43, 0xab, 0x3d, 0xe2, 0x7c, 0xc4, 0xc8, 0x8e, 0xb2, 0x0e, 0x93, 0xd3, 0x9d, 0xcf,
-	0x91, 0x13, 0x86, 0x24, 0x12, 0x19, 0x0e, 0x3f, 0x81, 0xb5, 0x63, 0x12, 0x9f, 0x38, 0x2c, 0x21,
-	0x36, 0xf9, 0x94, 0x10, 0x11, 0xa3, 0xbb, 0xb0, 0x92, 0x4a, 0xdd, 0xd4, 0xb6, 0xb4, 0xed, 0x7a,
-	0xb7, 0xd1, 0xc9, 0xa8, 0x3a, 0x19, 0x28, 0xf3, 0xe1, 0x5d, 0x58, 0x51, 0x3a, 0x42, 0xb0, 0x1c,
-	0x3a, 0xf1, 0x58, 0x81, 0x6b, 0xb6, 0x92, 0xd1, 0x7a, 0xc1, 0xb0, 0xa4, 0x8c, 0x79, 0xc8, 0x1a,
-	0x34, 0xa6, 0xa9, 0x42, 0x36, 0xc1, 0xf7, 0xa1, 0xf9, 0x2a, 0x37, 0x88, 0x22, 0xf9, 0x02, 0x3a,
-	0xfc, 0x14, 0x56, 0x67, 0x70, 0x21, 0x9b, 0xa0, 0x7b, 0xa0, 0x2b, 0x4e, 0x61, 0x6a, 0x5b, 0xd5,
-	0xf9, 0x1a, 0x73, 0x27, 0xfe, 0xa1, 0x41, 0xf3, 0xd0, 0x09, 0x4f, 0x38, 0x4b, 0xfc, 0xf2, 0x79,
-	0xb7, 0xa1, 0x96, 0x2a, 0xc3, 0x80, 0x7a, 0x79, 0x1a, 0x23, 0x33, 0xf4, 0x3d, 0xd4, 0x01, 0xdd,
-	0x77, 0x18, 0xe3, 0xae, 0x2a, 0xbd, 0xde, 0x5d, 0x2f, 0x88, 0x0f, 0x95, 0xf5, 0xc8, 0x89, 0x1c,
-	0x5f, 0xbc, 0xae, 0xd8, 0x39, 0x0a, 0x6d, 0xc3, 0xb2, 0x4b, 0xc2, 0xb1, 0x59, 0x55, 0x68, 0x54,
-	0xa0, 0x7b, 0x24, 0x1c, 0x97, 0x58, 0x85, 0xd8, 0x37, 0x40, 0x0f, 0x95, 0x05, 0xaf, 0xc2, 0xcd,
-	0x59, 0x36, 0xfc, 0x55, 0x03, 0x98, 0x06, 0xa0, 0x4d, 0xb8, 0x91, 0x08, 0x12, 0x4d, 0xab, 0xd3,
-	0xa5, 0xda, 0xf7, 0x50, 0x0b, 0x74, 0x41, 0xdc, 0x88, 0xc4, 0x79, 0x5b, 0x73, 0x0d, 0x59, 0x60,
-	0xf8, 0x3c, 0xa0, 0x31, 0x8f, 0x84, 0xaa, 0xa3, 0x66, 0x97, 0xba, 0x6a, 0x27, 0xe7, 0xcc, 0x5c,
-	0xce, 0xdb, 0xc9, 0x39, 0x93, 0xd3, 0xa1, 0xbe, 0x33, 0x22, 0xe6, 0x4a, 0x36, 0x1d, 0xa5, 0xe0,
-	0x18, 0x56, 0x67, 0x5a, 0x25, 0x9b, 0xbc, 0x07, 0xf5, 0xd0, 0xa5, 0x03, 0xc7, 0xf3, 0x22, 0x22,
-	0x44, 0xbe, 0x0d, 0xe5, 0x13, 0x8f, 0x7a, 0xfd, 0x17, 0x99, 0xc7, 0x86, 0xd0, 0xa5, 0xb9, 0x8c,
-	0x1e, 0x40, 0x4d, 0xb8, 0x82, 0x0e, 0x3c, 0x2a, 0xce, 0xf2, 0x1e, 0x36, 0x8b, 0x90, 0xe3, 0xde,
-	0x71, 0xff, 0x80, 0x8a, 0x33, 0xdb, 0x90, 0x10, 0x29, 0xe1, 0x8f, 0x00, 0x53, 0x22, 0xf9, 0x42,
-	0x8f, 0xfb, 0x0e, 0x0d, 0x54, 0xb2, 0x86, 0x9d, 0x6b, 0xa8, 0x09, 0xd5, 0x61, 0x22, 0x14, 0x5d,
-	0xc3, 0x96, 0xa2, 0x42, 0x92, 0x94, 0xba, 0x44, 0xbd, 0x58, 0x22, 0x95, 0x26, 0x7b, 0x71, 0x9a,
-	0x04, 0x6e, 0x4c, 0x79, 0xa0, 0xde, 0xdc, 0xb0, 0x4b, 0x1d, 0x3f, 0x02, 0xa3, 0xa8, 0x40, 0xc6,
-	0xc7, 0x4e, 0x34, 0x22, 0x71, 0x91, 0x29, 0xd3, 0x64, 0x26, 0x96, 0x04, 0x45, 0x26, 0x96, 0x04,
-	0x78, 0x17, 0xd0, 0xbb, 0xc0, 0xbf, 0xce, 0x12, 0x61, 0x04, 0xcd, 0x0b, 0x21, 0x72, 0xd7, 0x0f,
-	0xc1, 0x3a, 0x8a, 0x78, 0x4a, 0x05, 0xe5, 0x41, 0x36, 0xfd, 0xfd, 0x03, 0x92, 0xce, 0xd0, 0x0d,
-	0x3d, 0x92, 0x0e, 0x02, 0xc7, 0x27, 0x05, 0x9d, 0x34, 0xbc, 0x71, 0x7c, 0x75, 0x61, 0x82, 0x7e,
-	0xc9, 0x8e, 0xa9, 0x6a, 0x2b, 0x19, 0x5b, 0x60, 0x2e, 0xa4, 0x93, 0xa9, 0x1e, 0x43, 0xab, 0x37,
-	0x26, 0xee, 0xd9, 0xf5, 0xd2, 0xe0, 0x16, 0xac, 0xcf, 0x85, 0x85, 0x6c, 0xd2, 0xfd, 0xa6, 0x81,
-	0x61, 0x93, 0x11, 0x15, 0x71, 0x34, 0x41, 0xcf, 0xc0, 0x28, 0x6e, 0x18, 0x6d, 0x96, 0x73, 0xbd,
-	0xf8, 0x01, 0xb1, 0x36, 0xe6, 0x1d, 0xb2, 0xae, 0x0a, 0x7a, 0x0e, 0xb5, 0xf2, 0x90, 0x91, 0x59,
-	0xa0, 0x2e, 0x7f, 0x03, 0xac, 0xd6, 0x02, 0x8f, 0x22, 0xe8, 0xfe, 0x5c, 0x02, 0xe8, 0xf1, 0x20,
-	0x8e, 0x38, 0x63, 0x24, 0x92, 0x7c, 0xe5, 0xce, 0x4e, 0xf9, 0x2e, 0x5f, 0xfc, 0x94, 0xef, 0xe2,
-	0x82, 0xe3, 0x0a, 0x7a, 0x09, 0xf5, 0x99, 0x49, 0x21, 0xab, 0x00, 0xce, 0x4f, 0xdc, 0x32, 0x17,
-	0xfa, 0x32, 0x9a, 0x0f, 0x70, 0x6b, 0xc1, 0x34, 0x10, 0x2e, 0x6f, 0xe5, 0xca, 0xc9, 0x5b, 0x5b,
-	0xff, 0xc5, 0x64, 0xf4, 0x6f, 0x61, 0xed, 0xd2, 0x64, 0x50, 0xbb, 0xfc, 0xd2, 0x2c, 0x9c, 0xb4,
-	0x75, 0xe7, 0x4a, 0xbf, 0xa2, 0xdc, 0xdf, 0xf8, 0x75, 0xde, 0xd6, 0x7e, 0x9f, 0xb7, 0xb5, 0x3f,
-	0xe7, 0x6d, 0xed, 0xfb, 0xdf, 0x76, 0xe5, 0x7d, 0x95, 0x53, 0x7f, 0xa8, 0xab, 0x9f, 0xc2, 0xde,
-	0xbf, 0x00, 0x00, 0x00, 0xff, 0xff, 0x09, 0x6d, 0x9f, 0x26, 0x49, 0x06, 0x00, 0x00,
+	// 1409 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x57, 0xdd, 0x6e, 0x1b, 0xc5,
+	0x17, 0xaf, 0x9b, 0xd8, 0xb1, 0x8f, 0xeb, 0xc4, 0xff, 0x49, 0xea, 0xee, 0x7f, 0x29, 0x69, 0x34,
+	0x55, 0x51, 0x6e, 0x48, 0x8b, 0xdb, 0x4a, 0x5c, 0x40, 0x29, 0x71, 0xab, 0xd4, 0x88, 0x54, 0x61,
+	0x13, 0x5a, 0xa9, 0x12, 0xb2, 0xd6, 0xbb, 0x93, 0x64, 0xc8, 0xee, 0xce, 0xb2, 0x33, 0x76, 0x13,
+	0x6e, 0x79, 0x01, 0x10, 0xaf, 0xc0, 0x83, 0x70, 0xc9, 0x25, 0x8f, 0x80, 0xca, 0x8b, 0xa0, 0xf9,
+	0xda, 0x5d, 0xaf, 0x9d, 0xd2, 0x4a, 0xdc, 0xed, 0xf9, 0x9d, 0xef, 0x8f, 0x39, 0xc7, 0x86, 0x16,
+	0xa3, 0xf1, 0x4e, 0x9a, 0x31, 0xc1, 0x50, 0x43, 0x7e, 0x4e, 0xef, 0xb9, 0xb7, 0x4e, 0x18, 0x3b,
+	0x89, 0xc8, 0x5d, 0x85, 0x8e, 0x27, 0xc7, 0x77, 0x05, 0x8d, 0x09, 0x17, 0x7e, 0x9c, 0x6a, 0x41,
+	0x77, 0xb3, 0x2a, 0xf0, 0x3a, 0xf3, 0xd3, 0x94, 0x64, 0x5c, 0xf3, 0xf1, 0x4b, 0x58, 0x3b, 0x24,
+	0xe2, 0x85, 0x1f, 0x4d, 0x88, 0x47, 0x7e, 0x98, 0x10, 0x2e, 0xd0, 0x6d, 0xa8, 0x4f, 0x25, 0xed,
+	0xd4, 0xb6, 0x6a, 0xdb, 0xed, 0x7e, 0x67, 0x47, 0xfb, 0xda, 0xd1, 0x42, 0x9a, 0x87, 0x6e, 0x41,
+	0x5b, 0x88, 0x68, 0xc4, 0x49, 0xc0, 0x92, 0x90, 0x3b, 0x57, 0xb7, 0x6a, 0xdb, 0x4b, 0x1e, 0x08,
+	0x11, 0x1d, 0x6a, 0x04, 0xff, 0x72, 0x15, 0xea, 0x4a, 0x03, 0x21, 0x58, 0x4e, 0x7d, 0x71, 0xaa,
+	0xcc, 0xb5, 0x3c, 0xf5, 0x8d, 0x36, 0xac, 0x8f, 0xab, 0x0a, 0x34, 0x46, 0xfb, 0x70, 0x3d, 0x23,
+	0xb1, 0x4f, 0x13, 0x9a, 0x9c, 0x8c, 0xca, 0xe6, 0x97, 0x94, 0xf9, 0xf5, 0x9c, 0x79, 0x94, 0xfb,
+	0x41, 0x0f, 0x60, 0x25, 0xc8, 0x88, 0x2f, 0x48, 0xe8, 0x2c, 0xab, 0x78, 0xdd, 0x1d, 0x9d, 0xf2,
+	0x8e, 0x4d, 0x79, 0xe7, 0xc8, 0xd6, 0xc4, 0xb3, 0xa2, 0x52, 0x6b, 0x92, 0x86, 0x4a, 0xab, 0xfe,
+	0xef, 0x5a, 0x46, 0x14, 0x7d, 0x08, 0x60, 0x3e, 0x47, 0xe3, 0x0b, 0xa7, 0xa1, 0x42, 0x6f, 0x19,
+	0x64, 0xf7, 0xa2, 0x5a, 0x93, 0x95, 0xb9, 0x9a, 0x1c, 0x40, 0xa7, 0x28, 0x76, 0x1a, 0x5d, 0xa0,
+	0x3b, 0xb0, 0x9a, 0x66, 0x64, 0x4a, 0xd9, 0x84, 0x8f, 0x8a, 0x9a, 0xb7, 0xbc, 0x8e, 0x45, 0x75,
+	0x05, 0x1d, 0x58, 0x21, 0xe7, 0x94, 0xcb, 0x68, 0x65, 0xbd, 0x9a, 0x9e, 0x25, 0xf1, 0x00, 0xba,
+	0xd6, 0x22, 0xb7, 0xfd, 0xbb, 0x0b, 0x0d, 0x65, 0x8b, 0x3b, 0xb5, 0xad, 0xa5, 0xed, 0x76, 0xff,
+	0x86, 0x6d, 0x60, 0xa5, 0xd1, 0x9e, 0x11, 0xc3, 0x8f, 0x61, 0xb5, 0x64, 0x44, 0xc6, 0xb5, 0x03,
+	0x0d, 0x2e, 0x7c, 0x31, 0xb1, 0x26, 0x7a, 0x55, 0x13, 0x87, 0x8a, 0xeb, 0x19, 0x29, 0xfc, 0x08,
+	0x56, 0x67, 0x39, 0xb2, 0xe9, 0x01, 0x0b, 0x75, 0x3e, 0x75, 0x4f, 0x7d, 0xcb, 0x34, 0x62, 0xc2,
+	0xb9, 0x7f, 0x62, 0xdb, 0x6e, 0x49, 0x9c, 0x42, 0xcf, 0xea, 0x0f, 0x8f, 0xf7, 0x7d, 0x11, 0x9c,
+	0x96, 0x92, 0xd1, 0x05, 0x36, 0xd3, 0x78, 0x79, 0x32, 0x5a, 0x4c, 0x96, 0x94, 0x9c, 0xa7, 0x24,
+	0x90, 0x4d, 0x2a, 0x8f, 0x58, 0xc7, 0xa2, 0x4a, 0x0b, 0x7f, 0x04, 0xdd, 0x67, 0xc4, 0xcf, 0xc4,
+	0x98, 0xf8, 0xc2, 0xfa, 0x5a, 0x30, 0xa8, 0xb8, 0x0b, 0xab, 0x25, 0xb9, 0x34, 0xba, 0xc0, 0xbf,
+	0xd6, 0xa0, 0xbb, 0x57, 0xad, 0xf9, 0xa2, 0x19, 0xbf, 0x05, 0xed, 0xd8, 0x3f, 0x1f, 0x91, 0x44,
+	0x64, 0x94, 0xe8, 0x27, 0x52, 0xf7, 0x20, 0xf6, 0xcf, 0x9f, 0x6a, 0x44, 0x86, 0xca, 0x85, 0x9f,
+	0x09, 0x35, 0xed, 0xec, 0x8c, 0x24, 0x6a, 0xce, 0x5b, 0x5e, 0xc7, 0xa2, 0x47, 0x12, 0x44, 0xb7,
+	0xa1, 0xf3, 0x9a, 0x8a, 0xd3, 0x51, 0x4c, 0x84, 0x1f, 0xfa, 0xc2, 0x57, 0x73, 0xde, 0xf4, 0xae,
+	0x49, 0x70, 0xdf, 0x60, 0xf8, 0x05, 0xac, 0xee, 0xcd, 0xf6, 0xf0, 0x4e, 0x65, 0x0c, 0x2a, 0xef,
+	0xd8, 0x30, 0xe5, 0x4c, 0x27, 0xe4, 0x5c, 0x98, 0x00, 0x74, 0xad, 0x5a, 0x12, 0x51, 0xce, 0xf1,
+	0x23, 0xb8, 0xf6, 0xb2, 0xdc, 0x8f, 0x45, 0x89, 0xba, 0xd0, 0x94, 0xe3, 0xca, 0x29, 0x4b, 0xcc,
+	0x22, 0xc8, 0x69, 0xbc, 0x0f, 0x60, 0xf4, 0x65, 0x4c, 0xef, 0xb4, 0x5a, 0xde, 0x66, 0x6e, 0x0d,
+	0x3a, 0x4f, 0xcf, 0x53, 0x96, 0xd9, 0x9e, 0xe1, 0x23, 0xe8, 0x0c, 0xe3, 0x12, 0xf0, 0xae, 0x69,
+	0xdf, 0x84, 0x16, 0x9b, 0x92, 0xec, 0x75, 0x46, 0x05, 0x31, 0x8f, 0xaa, 0x00, 0xf0, 0x00, 0xda,
+	0xd6, 0xaa, 0x0c, 0xdb, 0x85, 0x26, 0x55, 0x24, 0x09, 0xcd, 0x40, 0xe7, 0xb4, 0x1c, 0x6a, 0x7e,
+	0x46, 0xd3, 0xd4, 0xbc, 0xcd, 0xba, 0x67, 0x49, 0xbc, 0x0e, 0xff, 0x93, 0x2d, 0x21, 0x99, 0x8c,
+	0xdc, 0xc6, 0x3b, 0x86, 0xb5, 0x32, 0x28, 0xad, 0x3b, 0xb0, 0x32, 0xd5, 0xb4, 0xa9, 0xaa, 0x25,
+	0x51, 0x0f, 0x1a, 0x01, 0x8b, 0x63, 0x2a, 0x4c, 0x5f, 0x0c, 0x25, 0x7b, 0x36, 0x9e, 0xd0, 0x28,
+	0x1c, 0xa9, 0x87, 0xa1, 0x87, 0xa6, 0xa5, 0x90, 0x27, 0xbe, 0x20, 0x6a, 0x42, 0xf7, 0xfd, 0xf4,
+	0x05, 0x8b, 0x26, 0x71, 0xbe, 0xd5, 0x3f, 0x80, 0xd6, 0x54, 0x01, 0x23, 0x1a, 0x1a, 0x3f, 0x4d,
+	0x0d, 0x0c, 0x43, 0xf9, 0xde, 0x63, 0x3f, 0x8a, 0x58, 0xa0, 0x1c, 0xb5, 0xfb, 0x1b, 0xb6, 0x68,
+	0xfb, 0x0a, 0x3d, 0xf0, 0x33, 0x3f, 0xe6, 0xcf, 0xae, 0x78, 0x46, 0x0a, 0x6d, 0xc3, 0x72, 0x40,
+	0xd2, 0x53, 0xe5, 0xba, 0xdd, 0x47, 0x56, 0x7a, 0x40, 0xd2, 0xd3, 0x5c, 0x56, 0x49, 0xec, 0x36,
+	0xa1, 0x91, 0x2a, 0x04, 0xaf, 0xc2, 0xb5, 0xb2, 0x35, 0xfc, 0x53, 0x0d, 0xa0, 0x50, 0x40, 0x37,
+	0x60, 0x65, 0xc2, 0x49, 0x56, 0x44, 0xd7, 0x90, 0xe4, 0x30, 0x94, 0x45, 0xe0, 0x24, 0xc8, 0x48,
+	0x5e, 0x04, 0x4d, 0xc9, 0xa6, 0xc4, 0x2c, 0xa1, 0x82, 0x65, 0xdc, 0x94, 0x20, 0xa7, 0xd5, 0x94,
+	0x32, 0x16, 0x39, 0xcb, 0x66, 0x4a, 0x19, 0x8b, 0xe4, 0xc9, 0xa1, 0xb1, 0xdc, 0x3d, 0x75, 0x7d,
+	0x72, 0x14, 0x81, 0x05, 0xac, 0x96, 0x4a, 0x25, 0xdb, 0x71, 0x1f, 0xda, 0x69, 0x40, 0x47, 0x7e,
+	0x18, 0x66, 0x84, 0x73, 0xa7, 0x36, 0x9b, 0xe2, 0xc1, 0x60, 0xf8, 0xa5, 0xe6, 0x78, 0x90, 0x06,
+	0xd4, 0x7c, 0xa3, 0x8f, 0xa1, 0xc5, 0x03, 0x4e, 0x47, 0x21, 0xe5, 0x67, 0xa6, 0x86, 0xdd, 0x7c,
+	0x53, 0x0d, 0x0e, 0x87, 0x4f, 0x28, 0x3f, 0xf3, 0x9a, 0x52, 0x44, 0x7e, 0xe1, 0xef, 0x01, 0x0a,
+	0x43, 0x32, 0xc3, 0x90, 0xc9, 0xcb, 0xa6, 0x9c, 0x75, 0x3c, 0x43, 0xa1, 0x2e, 0x2c, 0x8d, 0x27,
+	0x7a, 0x71, 0x74, 0x3c, 0xf9, 0xa9, 0x24, 0xc9, 0x94, 0x06, 0xba, 0xe9, 0x1d, 0xcf, 0x50, 0xb2,
+	0x16, 0xc7, 0x93, 0x24, 0x10, 0x72, 0x86, 0x96, 0x15, 0x27, 0xa7, 0xf1, 0x03, 0x68, 0xda, 0x08,
+	0xa4, 0xbe, 0xf0, 0xb3, 0x13, 0x22, 0xac, 0x27, 0x4d, 0x49, 0x4f, 0xd1, 0x24, 0xb1, 0x9e, 0xa2,
+	0x49, 0x82, 0x3f, 0x01, 0xf4, 0x6d, 0x12, 0xbf, 0xcf, 0x10, 0x61, 0x04, 0xdd, 0x19, 0x15, 0xb9,
+	0x2c, 0xf7, 0xc1, 0x3d, 0xc8, 0x98, 0x7e, 0xbc, 0xba, 0xfb, 0xbb, 0x4f, 0xc8, 0xb4, 0x64, 0x6e,
+	0x1c, 0x92, 0xe9, 0x28, 0xf1, 0x63, 0x7b, 0xf9, 0x9a, 0x12, 0x78, 0xee, 0xc7, 0xea, 0x67, 0x03,
+	0xa7, 0x3f, 0x12, 0xb3, 0x02, 0xd4, 0x37, 0x76, 0xc1, 0x59, 0x68, 0x4e, 0xba, 0x7a, 0x08, 0xbd,
+	0xc1, 0x29, 0x09, 0xce, 0xde, 0xcf, 0x0d, 0xee, 0xc1, 0xc6, 0x9c, 0x9a, 0x34, 0xe7, 0x40, 0xef,
+	0x6b, 0xca, 0x45, 0x01, 0xdb, 0x5d, 0x8f, 0x1f, 0xc3, 0xc6, 0x1c, 0x47, 0x0e, 0xce, 0x36, 0xd4,
+	0xa5, 0x55, 0xbb, 0x78, 0xd0, 0xec, 0x1b, 0x52, 0x96, 0xb5, 0x00, 0xfe, 0x1c, 0xa0, 0x00, 0xdf,
+	0xbf, 0x0a, 0x1b, 0x80, 0xf6, 0x88, 0x78, 0xce, 0x42, 0x32, 0x4c, 0x8e, 0x99, 0x0d, 0xeb, 0x15,
+	0x74, 0x67, 0x50, 0x19, 0x92, 0x39, 0x41, 0xba, 0x45, 0x7a, 0x96, 0x97, 0xd4, 0x09, 0xd2, 0x3d,
+	0x52, 0x27, 0x28, 0x96, 0xbf, 0x07, 0xc3, 0x5c, 0x46, 0x3b, 0xea, 0x68, 0xd4, 0x88, 0xf5, 0x7f,
+	0x5f, 0x86, 0xa6, 0x47, 0x4e, 0x28, 0x17, 0xd9, 0x05, 0xfa, 0x0c, 0x9a, 0xf6, 0xf8, 0xa2, 0xcb,
+	0xce, 0xb1, 0x7b, 0x7d, 0x9e, 0x21, 0xab, 0x7a, 0x05, 0x7d, 0x01, 0x2d, 0x0b, 0x71, 0xe4, 0x54,
+	0xa5, 0x6c, 0x91, 0xdd, 0xde, 0x02, 0x8e, 0x36, 0xf0, 0x15, 0xac, 0x55, 0x7e, 0x2b, 0xa0, 0xcd,
+	0xaa, 0xf0, 0xec, 0x8f, 0x88, 0xb7, 0x06, 0x93, 0x5f, 0xf7, 0x22, 0x98, 0xea, 0x0f, 0x03, 0xb7,
+	0xb7, 0x80, 0x93, 0x1b, 0xd8, 0x9b, 0xcf, 0x66, 0xef, 0xd2, 0x6c, 0xf6, 0xaa, 0xd9, 0x3c, 0x84,
+	0xba, 0xba, 0x8f, 0x28, 0x5f, 0xb9, 0xe5, 0x73, 0xeb, 0xa2, 0x0a, 0xaa, 0x94, 0xee, 0xd5, 0x50,
+	0x1f, 0x1a, 0xfa, 0x0e, 0xa2, 0x3c, 0xb7, 0x99, 0xbb, 0xe8, 0xce, 0x9e, 0x3d, 0xa5, 0xf3, 0x29,
+	0x34, 0x86, 0xf1, 0xac, 0xce, 0xcc, 0xe9, 0x74, 0xd7, 0xab, 0xb0, 0xf2, 0xb6, 0x5d, 0x43, 0xbb,
+	0x00, 0xc5, 0xd1, 0x42, 0xff, 0x2f, 0x27, 0x33, 0x73, 0xdd, 0xdc, 0x1b, 0x8b, 0x58, 0xca, 0x4a,
+	0xff, 0xb7, 0x65, 0x80, 0x01, 0x4b, 0x44, 0xc6, 0xa2, 0x88, 0x64, 0xb2, 0x70, 0xf9, 0xde, 0x2d,
+	0x0a, 0x57, 0xbd, 0x5a, 0x6e, 0x6f, 0x01, 0x47, 0x17, 0xee, 0x29, 0xb4, 0x4b, 0xdb, 0x06, 0xb9,
+	0x56, 0x70, 0x7e, 0x6b, 0xb9, 0xce, 0x42, 0x9e, 0x36, 0xf3, 0x1d, 0xac, 0x2f, 0xd8, 0x28, 0x08,
+	0xe7, 0xfb, 0xfe, 0xd2, 0xed, 0xe5, 0x6e, 0xbd, 0x55, 0x46, 0x9b, 0xff, 0x06, 0xd6, 0x2a, 0xdb,
+	0xa5, 0x18, 0xd6, 0xc5, 0xdb, 0xca, 0xbd, 0x79, 0x29, 0x3f, 0x37, 0x59, 0x59, 0x3f, 0x85, 0xc9,
+	0xc5, 0x1b, 0xcb, 0xbd, 0x79, 0x29, 0x3f, 0xaf, 0x65, 0x69, 0x75, 0x14, 0xb5, 0x9c, 0xdf, 0x32,
+	0xae, 0xb3, 0x90, 0xa7, 0xcd, 0xfc, 0x07, 0x63, 0xb2, 0x7b, 0xfd, 0x8f, 0x37, 0x9b, 0xb5, 0x3f,
+	0xdf, 0x6c, 0xd6, 0xfe, 0x7a, 0xb3, 0x59, 0xfb, 0xf9, 0xef, 0xcd, 0x2b, 0xaf, 0x96, 0x18, 0x8d,
+	0xc7, 0x0d, 0xf5, 0xb7, 0xec, 0xfe, 0x3f, 0x03, 0x00, 0xdc, 0x28, 0x3a, 0xbd, 0x03, 0x0f, 0x00,
+	0x00,
 }
@@ -0,0 +1,43 @@
+/*
+Copyright (C) 2018 Intel Corporation.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package version provides information about the build of the OIM
+// binaries. The variables are set at build time via the linker, for
+// example:
+//
+//	go build -ldflags '-X github.com/intel/oim/pkg/version.Version=v0.1.0-3-gabcdef0' ...
+package version
+
+import (
+	"fmt"
+
+	"github.com/intel/oim/pkg/spec/oim/v0"
+)
+
+var (
+	// Version is the release version, as reported by "git describe".
+	Version = "unknown"
+	// Commit is the full git commit hash.
+	Commit = "unknown"
+	// BuildDate is the time of the build in RFC 3339 format.
+	BuildDate = "unknown"
+)
+
+// Get returns the build information in the format used by the
+// GetVersion gRPC calls.
+func Get() *oim.GetVersionReply {
+	return &oim.GetVersionReply{
+		Version:   Version,
+		Commit:    Commit,
+		BuildDate: BuildDate,
+	}
+}
+
+// String returns a single line that is suitable for printing by
+// the -version flag of a program.
+func String(program string) string {
+	return fmt.Sprintf("%s %s (commit %s, built %s)", program, Version, Commit, BuildDate)
+}
@@ -17,20 +17,28 @@ limitations under the License.
 package e2e
 
 import (
+	"bytes"
+	"context"
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/onsi/ginkgo"
 	"github.com/onsi/gomega"
 	"github.com/pkg/errors"
+	"google.golang.org/grpc"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/kubernetes/pkg/version"
 	"k8s.io/kubernetes/test/e2e/framework"
 	"k8s.io/kubernetes/test/e2e/framework/ginkgowrapper"
+	"k8s.io/kubernetes/test/e2e/framework/testfiles"
 
 	"github.com/intel/oim/pkg/log"
+	"github.com/intel/oim/pkg/oim-common"
+	"github.com/intel/oim/pkg/spec/oim/v0"
+	"github.com/intel/oim/test/e2e/existing"
 	"github.com/intel/oim/test/pkg/qemu"
 	"github.com/intel/oim/test/pkg/spdk"
 )
@@ -42,7 +50,10 @@ func setupProviderConfig(data *[]byte) error {
 	switch framework.TestContext.Provider {
 	case "":
 		if *data == nil {
-			if err := spdk.Init(spdk.WithVHostSCSI()); err != nil {
+			// The first two nodes get SPDK, which is
+			// enough to cover volumes on more than one
+			// node without using too many huge pages.
+			if err := spdk.Init(spdk.WithVHostSCSI(), spdk.WithNodes(2)); err != nil {
 				return err
 			}
 			if err := qemu.Init(qemu.WithKubernetes()); err != nil {
@@ -51,6 +62,9 @@ func setupProviderConfig(data *[]byte) error {
 			if qemu.VM == nil {
 				return errors.New("a QEMU image is required for this test")
 			}
+			if err := installCRDs(); err != nil {
+				return err
+			}
 			// Tell child nodes about our SPDK path.
 			*data = []byte(spdk.SPDKPath)
 			initialized = true
@@ -86,11 +100,67 @@ func setupProviderConfig(data *[]byte) error {
 		if abs(os.Getenv("KUBECONFIG")) != abs(config) {
 			return errors.Errorf("KUBECONFIG must be set to %s", abs(config))
 		}
+	case "local", "external":
+		// An existing cluster with OIM already deployed. QEMU and
+		// SPDK do not get started, which causes tests that need
+		// them to be skipped. The others use the OIM registry
+		// of the cluster.
+		if initialized {
+			return nil
+		}
+		if framework.TestContext.KubeConfig == "" {
+			return errors.Errorf("-kubeconfig or KUBECONFIG is required for provider %q", framework.TestContext.Provider)
+		}
+		if err := checkOIMRegistry(); err != nil {
+			return err
+		}
+		initialized = true
 	}
 
 	return nil
 }
 
+// installCRDs creates the CustomResourceDefinitions that the OIM
+// deployment depends on inside the QEMU cluster.
+func installCRDs() error {
+	const crd = "deploy/kubernetes/malloc/csinodeinfo.yaml"
+	data, err := testfiles.Read(crd)
+	if err != nil {
+		return err
+	}
+	target := "/tmp/" + filepath.Base(crd)
+	if err := qemu.VM.Install(target, bytes.NewReader(data), 0444); err != nil {
+		return err
+	}
+	if out, err := qemu.VM.SSH("kubectl", "apply", "-f", target); err != nil {
+		return errors.Wrapf(err, "creating %s: %s", crd, out)
+	}
+	return nil
+}
+
+// checkOIMRegistry ensures that the registry of an existing OIM
+// deployment responds before running tests against it.
+func checkOIMRegistry() error {
+	if *existing.Registry == "" || *existing.CA == "" || *existing.Key == "" || *existing.Secret == "" {
+		return errors.New("-oim-registry, -oim-ca, -oim-key and -oim-secret are required for an existing cluster")
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	conn, err := oimcommon.Dial(ctx, *existing.Registry,
+		oimcommon.WithTLSFiles(*existing.CA, *existing.Key, "component.registry"),
+		oimcommon.WithGRPCOptions(grpc.WithBlock()))
+	if err != nil {
+		return errors.Wrapf(err, "connect to OIM registry %s", *existing.Registry)
+	}
+	defer conn.Close()
+	reply, err := oim.NewRegistryClient(conn).GetVersion(ctx, &oim.GetVersionRequest{})
+	if err != nil {
+		return errors.Wrapf(err, "OIM registry %s", *existing.Registry)
+	}
+	log.L().Infow("using existing OIM registry", "address", *existing.Registry, "version", reply.GetVersion())
+	return nil
+}
+
 // There are certain operations we only want to run once per overall test invocation
 // (such as deleting old namespaces, or verifying that all system pods are running.
 // Because of the way Ginkgo runs tests in parallel, we must use SynchronizedBeforeSuite
@@ -0,0 +1,36 @@
+/*
+Copyright 2018 Intel Corporation.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package existing contains the settings for running E2E tests
+// against an existing cluster where OIM is already deployed.
+package existing
+
+import (
+	"flag"
+
+	"k8s.io/kubernetes/test/e2e/framework"
+)
+
+var (
+	// Registry is the gRPC endpoint of the OIM registry.
+	Registry = flag.String("oim-registry", "", "gRPC endpoint of the OIM registry in an existing cluster (providers \"local\" and \"external\")")
+	// CA is the CA .crt file for connecting to the registry.
+	CA = flag.String("oim-ca", "", "CA .crt file for connecting to -oim-registry")
+	// Key is the base name of the .key and .crt files for connecting to the registry.
+	Key = flag.String("oim-key", "", "base name of the .key and .crt files for connecting to -oim-registry")
+	// Secret is the .yaml file with the "oim-ca" secret for the OIM CSI driver.
+	Secret = flag.String("oim-secret", "", ".yaml file with the oim-ca secret for the OIM CSI driver in an existing cluster, as created by test/setup-ca.sh")
+)
+
+// Enabled returns true if the tests run against an existing cluster
+// instead of one created inside QEMU.
+func Enabled() bool {
+	switch framework.TestContext.Provider {
+	case "local", "external":
+		return true
+	}
+	return false
+}
@@ -0,0 +1,75 @@
+/*
+Copyright 2018 Intel Corporation.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package podlogs contains helpers for collecting the output of
+// containers during an E2E test run.
+package podlogs
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+
+	"k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clientset "k8s.io/client-go/kubernetes"
+)
+
+// DumpAllLogs writes the current output of all containers in the
+// namespace into <dir>/<pod>-<container>.log. In contrast to
+// following the logs, this captures a snapshot and returns once
+// everything has been written, which makes it suitable for
+// collecting information after a test failure.
+//
+// All pods and containers are tried even when some of them fail,
+// the first error is returned.
+func DumpAllLogs(ctx context.Context, cs clientset.Interface, ns string, dir string) error {
+	pods, err := cs.CoreV1().Pods(ns).List(metav1.ListOptions{})
+	if err != nil {
+		return errors.Wrapf(err, "list pods in namespace %s", ns)
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return errors.Wrap(err, "create log directory")
+	}
+
+	var result error
+	for _, pod := range pods.Items {
+		var containers []v1.Container
+		containers = append(containers, pod.Spec.InitContainers...)
+		containers = append(containers, pod.Spec.Containers...)
+		for _, container := range containers {
+			err := dumpLog(ctx, cs, &pod, container.Name, filepath.Join(dir, pod.Name+"-"+container.Name+".log"))
+			if err != nil && result == nil {
+				result = err
+			}
+		}
+	}
+	return result
+}
+
+func dumpLog(ctx context.Context, cs clientset.Interface, pod *v1.Pod, container string, filename string) error {
+	req := cs.CoreV1().Pods(pod.Namespace).GetLogs(pod.Name, &v1.PodLogOptions{
+		Container: container,
+	})
+	stream, err := req.Context(ctx).Stream()
+	if err != nil {
+		return errors.Wrapf(err, "get log of %s/%s", pod.Name, container)
+	}
+	defer stream.Close()
+
+	file, err := os.Create(filename)
+	if err != nil {
+		return errors.Wrap(err, "create log file")
+	}
+	defer file.Close()
+	if _, err := io.Copy(file, stream); err != nil {
+		return errors.Wrapf(err, "copy log of %s/%s", pod.Name, container)
+	}
+	return file.Close()
+}
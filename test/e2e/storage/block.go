@@ -0,0 +1,157 @@
+/*
+Copyright 2018 Intel Corporation.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package storage
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/api/core/v1"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	clientset "k8s.io/client-go/kubernetes"
+	"k8s.io/kubernetes/test/e2e/framework"
+
+	// nolint: golint
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+// blockDevicePath is where the raw block volume appears inside the
+// test pods.
+const blockDevicePath = "/dev/oim-test"
+
+// testBlockProvisioning provisions a raw block volume for the claim,
+// writes a pattern to the device in one pod and checks that it is
+// still there in a second pod. The test is skipped when the cluster
+// or the driver do not support raw block volumes.
+func testBlockProvisioning(t storageClassTest, client clientset.Interface, claim *v1.PersistentVolumeClaim) {
+	block := v1.PersistentVolumeBlock
+	claim.Spec.VolumeMode = &block
+
+	By("creating a block claim")
+	claim, err := client.CoreV1().PersistentVolumeClaims(claim.Namespace).Create(claim)
+	Expect(err).NotTo(HaveOccurred())
+	defer func() {
+		framework.Logf("deleting claim %q/%q", claim.Namespace, claim.Name)
+		err = client.CoreV1().PersistentVolumeClaims(claim.Namespace).Delete(claim.Name, nil)
+		if err != nil && !apierrs.IsNotFound(err) {
+			framework.Failf("Error deleting claim %q. Error: %v", claim.Name, err)
+		}
+	}()
+	if claim.Spec.VolumeMode == nil || *claim.Spec.VolumeMode != block {
+		// The field gets dropped when the BlockVolume feature
+		// gate is off.
+		Skip("raw block volumes are not enabled in the cluster")
+	}
+	err = framework.WaitForPersistentVolumeClaimPhase(v1.ClaimBound, client, claim.Namespace, claim.Name, framework.Poll, framework.ClaimProvisionTimeout)
+	if err != nil && blockProvisioningFailed(client, claim) {
+		Skip("the CSI driver does not support raw block volumes")
+	}
+	Expect(err).NotTo(HaveOccurred())
+
+	By("checking the PV")
+	claim, err = client.CoreV1().PersistentVolumeClaims(claim.Namespace).Get(claim.Name, metav1.GetOptions{})
+	Expect(err).NotTo(HaveOccurred())
+	pv, err := client.CoreV1().PersistentVolumes().Get(claim.Spec.VolumeName, metav1.GetOptions{})
+	Expect(err).NotTo(HaveOccurred())
+	Expect(pv.Spec.VolumeMode).NotTo(BeNil(), "volume mode")
+	Expect(*pv.Spec.VolumeMode).To(Equal(block), "volume mode")
+
+	// The pattern is unique for each claim, so data left behind
+	// by some earlier test cannot cause a false positive.
+	pattern := "oim block test " + claim.Name
+	By("writing a pattern to the block device")
+	runInPodWithBlockVolume(client, "first", claim.Namespace, claim.Name, t.nodeName, t.nodeSelector,
+		fmt.Sprintf("printf '%s' | dd of=%s bs=512 conv=sync,fsync", pattern, blockDevicePath))
+
+	By("checking the pattern in a second pod")
+	nodeName := t.nodeName
+	if t.nodeName2 != "" {
+		nodeName = t.nodeName2
+	}
+	runInPodWithBlockVolume(client, "second", claim.Namespace, claim.Name, nodeName, t.nodeSelector,
+		fmt.Sprintf("head -c 512 %s | grep -q '%s'", blockDevicePath, pattern))
+}
+
+// blockProvisioningFailed checks whether the provisioner rejected the
+// claim because of its volume mode.
+func blockProvisioningFailed(client clientset.Interface, claim *v1.PersistentVolumeClaim) bool {
+	for _, message := range provisioningFailures(client, claim) {
+		if strings.Contains(strings.ToLower(message), "block") {
+			framework.Logf("provisioning failed: %s", message)
+			return true
+		}
+	}
+	return false
+}
+
+// provisioningFailures returns the messages of all ProvisioningFailed
+// events for the claim.
+func provisioningFailures(client clientset.Interface, claim *v1.PersistentVolumeClaim) []string {
+	events, err := client.CoreV1().Events(claim.Namespace).List(metav1.ListOptions{
+		FieldSelector: fields.OneTermEqualSelector("involvedObject.name", claim.Name).String(),
+	})
+	if err != nil {
+		framework.Logf("listing events of claim %q/%q: %v", claim.Namespace, claim.Name, err)
+		return nil
+	}
+	var messages []string
+	for _, event := range events.Items {
+		if event.Reason == "ProvisioningFailed" {
+			messages = append(messages, event.Message)
+		}
+	}
+	return messages
+}
+
+// runInPodWithBlockVolume runs a command in a pod with the given
+// block claim available as blockDevicePath.
+func runInPodWithBlockVolume(c clientset.Interface, suffix, ns, claimName, nodeName string, nodeSelector map[string]string, command string) {
+	pod := &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: "pvc-block-tester-" + suffix + "-",
+		},
+		Spec: v1.PodSpec{
+			NodeName:     nodeName,
+			NodeSelector: nodeSelector,
+			Containers: []v1.Container{
+				{
+					Name:    "volume-tester",
+					Image:   "busybox",
+					Command: []string{"/bin/sh"},
+					Args:    []string{"-c", command},
+					VolumeDevices: []v1.VolumeDevice{
+						{
+							Name:       "my-volume",
+							DevicePath: blockDevicePath,
+						},
+					},
+				},
+			},
+			RestartPolicy: v1.RestartPolicyNever,
+			Volumes: []v1.Volume{
+				{
+					Name: "my-volume",
+					VolumeSource: v1.VolumeSource{
+						PersistentVolumeClaim: &v1.PersistentVolumeClaimVolumeSource{
+							ClaimName: claimName,
+						},
+					},
+				},
+			},
+		},
+	}
+
+	pod, err := c.CoreV1().Pods(ns).Create(pod)
+	framework.ExpectNoError(err, "Failed to create pod: %v", err)
+	defer func() {
+		framework.DeletePodOrFail(c, ns, pod.Name)
+	}()
+	framework.ExpectNoError(framework.WaitForPodSuccessInNamespaceSlow(c, pod.Name, pod.Namespace))
+}
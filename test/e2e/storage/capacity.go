@@ -0,0 +1,173 @@
+/*
+Copyright 2018 Intel Corporation.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package storage
+
+import (
+	"fmt"
+	"time"
+
+	"k8s.io/api/core/v1"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+	clientset "k8s.io/client-go/kubernetes"
+	"k8s.io/kubernetes/test/e2e/framework"
+
+	"github.com/intel/oim/pkg/oim-controller"
+
+	// nolint: golint
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+const (
+	// maxCapacityVolumes limits how many volumes
+	// testCapacityExhaustion creates before giving up.
+	maxCapacityVolumes = 32
+
+	// capacityTimeout is how long testCapacityExhaustion waits
+	// for each claim before considering it rejected.
+	capacityTimeout = 2 * time.Minute
+)
+
+// testCapacityExhaustion creates claims with the storage class until
+// provisioning fails, then checks that the failure is reported as
+// ResourceExhausted and that provisioning resumes once a volume gets
+// deleted. The test is skipped if the backend still has room after
+// maxCapacityVolumes volumes.
+func testCapacityExhaustion(client clientset.Interface, ns string, t storageClassTest, scName string) {
+	var claims []*v1.PersistentVolumeClaim
+	defer func() {
+		for _, claim := range claims {
+			framework.Logf("deleting claim %q/%q", claim.Namespace, claim.Name)
+			err := client.CoreV1().PersistentVolumeClaims(claim.Namespace).Delete(claim.Name, nil)
+			if err != nil && !apierrs.IsNotFound(err) {
+				framework.Failf("Error deleting claim %q. Error: %v", claim.Name, err)
+			}
+		}
+	}()
+
+	By(fmt.Sprintf("creating claims of size %s until provisioning fails", t.claimSize))
+	var rejected *v1.PersistentVolumeClaim
+	for i := 0; i < maxCapacityVolumes && rejected == nil; i++ {
+		claim := newClaim(t, ns, "")
+		claim.Spec.StorageClassName = &scName
+		claim, err := client.CoreV1().PersistentVolumeClaims(ns).Create(claim)
+		Expect(err).NotTo(HaveOccurred())
+		claims = append(claims, claim)
+		if err := framework.WaitForPersistentVolumeClaimPhase(v1.ClaimBound, client, ns, claim.Name, framework.Poll, capacityTimeout); err != nil {
+			rejected = claim
+		}
+	}
+	if rejected == nil {
+		Skip(fmt.Sprintf("storage not exhausted by %d volumes of size %s", maxCapacityVolumes, t.claimSize))
+	}
+
+	By("checking the rejected claim " + rejected.Name)
+	claim, err := client.CoreV1().PersistentVolumeClaims(ns).Get(rejected.Name, metav1.GetOptions{})
+	Expect(err).NotTo(HaveOccurred())
+	Expect(claim.Status.Phase).To(Equal(v1.ClaimPending), "phase of rejected claim")
+	Expect(provisioningFailures(client, claim)).To(ContainElement(ContainSubstring("ResourceExhausted")), "provisioning failure events")
+
+	By("freeing one volume")
+	freed, err := client.CoreV1().PersistentVolumeClaims(ns).Get(claims[0].Name, metav1.GetOptions{})
+	Expect(err).NotTo(HaveOccurred())
+	framework.ExpectNoError(client.CoreV1().PersistentVolumeClaims(ns).Delete(freed.Name, nil))
+	claims = claims[1:]
+	framework.ExpectNoError(framework.WaitForPersistentVolumeDeleted(client, freed.Spec.VolumeName, 5*time.Second, 5*time.Minute))
+
+	By("waiting for the rejected claim to get provisioned")
+	err = framework.WaitForPersistentVolumeClaimPhase(v1.ClaimBound, client, ns, rejected.Name, framework.Poll, framework.ClaimProvisionTimeout)
+	Expect(err).NotTo(HaveOccurred())
+}
+
+// testTargetExhaustion starts pods with one volume each on a single
+// node until a pod cannot start because all SCSI targets of the node
+// are in use. Depending on whether Kubernetes knows about the volume
+// limit of the node, the pod is either not scheduled or mapping the
+// volume fails with ResourceExhausted. Once one of the running pods
+// is deleted, the remaining pod must start.
+func testTargetExhaustion(client clientset.Interface, ns string, t storageClassTest) {
+	nodes, err := client.CoreV1().Nodes().List(metav1.ListOptions{
+		LabelSelector: labels.SelectorFromSet(t.nodeSelector).String(),
+	})
+	Expect(err).NotTo(HaveOccurred())
+	Expect(nodes.Items).NotTo(BeEmpty(), "nodes with OIM")
+	node := nodes.Items[0].Name
+	nodeSelector := map[string]string{"kubernetes.io/hostname": node}
+	class := createNodeLocalClass(client, ns, t, node)
+	defer func() {
+		framework.ExpectNoError(client.StorageV1().StorageClasses().Delete(class.Name, nil))
+	}()
+
+	var (
+		pods   []*v1.Pod
+		claims []*v1.PersistentVolumeClaim
+	)
+	defer func() {
+		for _, pod := range pods {
+			framework.DeletePodOrFail(client, ns, pod.Name)
+		}
+		for _, claim := range claims {
+			framework.Logf("deleting claim %q/%q", claim.Namespace, claim.Name)
+			err := client.CoreV1().PersistentVolumeClaims(claim.Namespace).Delete(claim.Name, nil)
+			if err != nil && !apierrs.IsNotFound(err) {
+				framework.Failf("Error deleting claim %q. Error: %v", claim.Name, err)
+			}
+		}
+	}()
+
+	// Other tests may use some targets of the same node, so
+	// the limit can be reached earlier.
+	By(fmt.Sprintf("starting pods with one volume each on node %s until one does not run", node))
+	var rejected *v1.Pod
+	for i := 0; i <= oimcontroller.MaxSCSITargets && rejected == nil; i++ {
+		claim := newClaim(t, ns, "")
+		claim.Spec.StorageClassName = &class.Name
+		claim, err := client.CoreV1().PersistentVolumeClaims(ns).Create(claim)
+		Expect(err).NotTo(HaveOccurred())
+		claims = append(claims, claim)
+		pod := createTesterPod(client, ns, claim.Name, "target", nodeSelector, "sleep 1000000")
+		pods = append(pods, pod)
+		if err := framework.WaitTimeoutForPodRunningInNamespace(client, pod.Name, ns, capacityTimeout); err != nil {
+			rejected = pod
+		}
+	}
+	Expect(rejected).NotTo(BeNil(), "pod beyond the limit of %d SCSI targets", oimcontroller.MaxSCSITargets)
+
+	By("checking the pod " + rejected.Name)
+	Expect(podWarnings(client, rejected)).To(ContainElement(Or(
+		ContainSubstring("ResourceExhausted"),
+		ContainSubstring("max volume count"),
+	)), "warning events")
+
+	By("deleting one running pod")
+	framework.DeletePodOrFail(client, ns, pods[0].Name)
+	pods = pods[1:]
+
+	By("waiting for the pod to run")
+	framework.ExpectNoError(framework.WaitTimeoutForPodRunningInNamespace(client, rejected.Name, ns, framework.PodStartTimeout))
+}
+
+// podWarnings returns the messages of all warning events for the pod.
+func podWarnings(client clientset.Interface, pod *v1.Pod) []string {
+	events, err := client.CoreV1().Events(pod.Namespace).List(metav1.ListOptions{
+		FieldSelector: fields.OneTermEqualSelector("involvedObject.name", pod.Name).String(),
+	})
+	if err != nil {
+		framework.Logf("listing events of pod %q/%q: %v", pod.Namespace, pod.Name, err)
+		return nil
+	}
+	var messages []string
+	for _, event := range events.Items {
+		if event.Type == v1.EventTypeWarning {
+			messages = append(messages, event.Message)
+		}
+	}
+	return messages
+}
@@ -9,6 +9,7 @@ package storage
 
 import (
 	"context"
+	"fmt"
 	"io/ioutil"
 	"os"
 
@@ -26,13 +27,14 @@ import (
 )
 
 // OIMControlPlane manages the components making up the OIM control plane
-// (registry, controller).
+// (registry, one controller per node with SPDK).
 type OIMControlPlane struct {
-	registryServer, controllerServer *oimcommon.NonBlockingGRPCServer
-	controller                       *oimcontroller.Controller
-	tmpDir                           string
-	ctx                              context.Context
-	cancel                           context.CancelFunc
+	registryServer    *oimcommon.NonBlockingGRPCServer
+	controllerServers []*oimcommon.NonBlockingGRPCServer
+	controllers       []*oimcontroller.Controller
+	tmpDir            string
+	ctx               context.Context
+	cancel            context.CancelFunc
 
 	controllerID    string
 	registryAddress string
@@ -76,30 +78,7 @@ func (op *OIMControlPlane) StartOIMControlPlane(ctx context.Context) {
 	// No tcp4:/// prefix. It causes gRPC to block?!
 	op.registryAddress = addr.String()
 
-	By("starting OIM controller")
-	op.controllerID = "host-0"
-	op.tmpDir, err = ioutil.TempDir("", "oim-e2e-test")
-	Expect(err).NotTo(HaveOccurred())
-	transportCreds, err := oimcommon.LoadTLS(os.ExpandEnv("${TEST_WORK}/ca/ca.crt"), os.ExpandEnv("${TEST_WORK}/ca/controller.host-0.key"), "component.registry")
-	Expect(err).NotTo(HaveOccurred())
-	controllerAddress := "unix:///" + op.tmpDir + "/controller.sock"
-	op.controller, err = oimcontroller.New(
-		oimcontroller.WithVHostController(spdk.VHost),
-		oimcontroller.WithVHostDev(":.0"), // Only PCI function provided by controller, rest comes from registry.
-		oimcontroller.WithSPDK(spdk.SPDKPath),
-		oimcontroller.WithCreds(transportCreds),
-	)
-	Expect(err).NotTo(HaveOccurred())
-	controllerCreds, err := oimcommon.LoadTLS(os.ExpandEnv("${TEST_WORK}/ca/ca.crt"), os.ExpandEnv("${TEST_WORK}/ca/controller.host-0.key"), "component.registry")
-	Expect(err).NotTo(HaveOccurred())
-	cs, controllerService := oimcontroller.Server(controllerAddress, op.controller, controllerCreds)
-	op.controllerServer = cs
-	err = op.controllerServer.Start(ctx, controllerService)
-	Expect(err).NotTo(HaveOccurred())
-	err = op.controller.Start()
-	Expect(err).NotTo(HaveOccurred())
-
-	// Register the controller in the registry.
+	// Register each controller in the registry.
 	clientCreds, err := oimcommon.LoadTLS(os.ExpandEnv("${TEST_WORK}/ca/ca.crt"), os.ExpandEnv("${TEST_WORK}/ca/user.admin.key"), "component.registry")
 	Expect(err).NotTo(HaveOccurred())
 	opts := oimcommon.ChooseDialOpts(op.registryAddress, grpc.WithTransportCredentials(clientCreds))
@@ -107,20 +86,51 @@ func (op *OIMControlPlane) StartOIMControlPlane(ctx context.Context) {
 	Expect(err).NotTo(HaveOccurred())
 	defer conn.Close()
 	registryClient := oim.NewRegistryClient(conn)
-	_, err = registryClient.SetValue(context.Background(), &oim.SetValueRequest{
-		Value: &oim.Value{
-			Path:  op.controllerID + "/" + oimcommon.RegistryAddress,
-			Value: controllerAddress,
-		},
-	})
-	Expect(err).NotTo(HaveOccurred())
-	_, err = registryClient.SetValue(context.Background(), &oim.SetValueRequest{
-		Value: &oim.Value{
-			Path:  op.controllerID + "/" + oimcommon.RegistryPCI,
-			Value: spdk.VHostDev,
-		},
-	})
+
+	op.tmpDir, err = ioutil.TempDir("", "oim-e2e-test")
 	Expect(err).NotTo(HaveOccurred())
+	op.controllerID = "host-0"
+	// There is one SCSI controller for each node with SPDK,
+	// in the same order as the nodes.
+	for node := range spdk.VHostPaths {
+		controllerID := fmt.Sprintf("host-%d", node)
+		By("starting OIM controller " + controllerID)
+		key := os.ExpandEnv("${TEST_WORK}/ca/controller." + controllerID + ".key")
+		transportCreds, err := oimcommon.LoadTLS(os.ExpandEnv("${TEST_WORK}/ca/ca.crt"), key, "component.registry")
+		Expect(err).NotTo(HaveOccurred())
+		controllerAddress := "unix:///" + op.tmpDir + "/" + controllerID + ".sock"
+		controller, err := oimcontroller.New(
+			oimcontroller.WithVHostController(spdk.VHostName(node)),
+			oimcontroller.WithVHostDev(":.0"), // Only PCI function provided by controller, rest comes from registry.
+			oimcontroller.WithSPDK(spdk.SPDKPath),
+			oimcontroller.WithCreds(transportCreds),
+		)
+		Expect(err).NotTo(HaveOccurred())
+		op.controllers = append(op.controllers, controller)
+		controllerCreds, err := oimcommon.LoadTLS(os.ExpandEnv("${TEST_WORK}/ca/ca.crt"), key, "component.registry")
+		Expect(err).NotTo(HaveOccurred())
+		cs, controllerService := oimcontroller.Server(controllerAddress, controller, controllerCreds)
+		op.controllerServers = append(op.controllerServers, cs)
+		err = cs.Start(ctx, controllerService)
+		Expect(err).NotTo(HaveOccurred())
+		err = controller.Start()
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = registryClient.SetValue(context.Background(), &oim.SetValueRequest{
+			Value: &oim.Value{
+				Path:  controllerID + "/" + oimcommon.RegistryAddress,
+				Value: controllerAddress,
+			},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		_, err = registryClient.SetValue(context.Background(), &oim.SetValueRequest{
+			Value: &oim.Value{
+				Path:  controllerID + "/" + oimcommon.RegistryPCI,
+				Value: spdk.VHostDev,
+			},
+		})
+		Expect(err).NotTo(HaveOccurred())
+	}
 }
 
 // StopOIMControlPlane stops the servers.
@@ -134,13 +144,15 @@ func (op *OIMControlPlane) StopOIMControlPlane(ctx context.Context) {
 		op.registryServer.ForceStop(ctx)
 		op.registryServer.Wait(ctx)
 	}
-	if op.controllerServer != nil {
-		op.controllerServer.ForceStop(ctx)
-		op.controllerServer.Wait(ctx)
+	for _, controllerServer := range op.controllerServers {
+		controllerServer.ForceStop(ctx)
+		controllerServer.Wait(ctx)
 	}
-	if op.controller != nil {
-		op.controller.Stop()
+	op.controllerServers = nil
+	for _, controller := range op.controllers {
+		controller.Stop()
 	}
+	op.controllers = nil
 	if op.tmpDir != "" {
 		err := os.RemoveAll(op.tmpDir)
 		Expect(err).NotTo(HaveOccurred())
@@ -19,6 +19,7 @@ package storage
 import (
 	"context"
 	"os"
+	"path/filepath"
 	"strings"
 
 	appsv1 "k8s.io/api/apps/v1"
@@ -27,7 +28,8 @@ import (
 	"k8s.io/kubernetes/test/e2e/framework"
 	"k8s.io/kubernetes/test/e2e/storage/utils"
 
-	"github.com/intel/oim/test/pkg/spdk"
+	"github.com/intel/oim/test/e2e/existing"
+	"github.com/intel/oim/test/e2e/podlogs"
 
 	// nolint: golint
 	. "github.com/onsi/ginkgo"
@@ -38,19 +40,17 @@ var _ = utils.SIGDescribe("OIM Volumes", func() {
 	f := framework.NewDefaultFramework("oim")
 
 	var (
-		cs           clientset.Interface
-		ns           *v1.Namespace
-		config       framework.VolumeTestConfig
-		ctx          = context.Background()
-		controlPlane OIMControlPlane
-		destructors  []func()
+		cs              clientset.Interface
+		ns              *v1.Namespace
+		config          framework.VolumeTestConfig
+		ctx             = context.Background()
+		controlPlane    OIMControlPlane
+		destructors     []func()
+		registryAddress string
+		secret          string
 	)
 
 	BeforeEach(func() {
-		if spdk.SPDK == nil {
-			Skip("No SPDK vhost.")
-		}
-
 		cs = f.ClientSet
 		ns = f.Namespace
 		config = framework.VolumeTestConfig{
@@ -60,7 +60,16 @@ var _ = utils.SIGDescribe("OIM Volumes", func() {
 			WaitForCompletion: true,
 		}
 
+		if existing.Enabled() {
+			// Use the control plane of the cluster.
+			registryAddress = *existing.Registry
+			secret = *existing.Secret
+			return
+		}
+
 		controlPlane.StartOIMControlPlane(ctx)
+		registryAddress = controlPlane.registryAddress
+		secret = os.ExpandEnv("${TEST_WORK}/ca/secret.yaml")
 		var cleanup framework.CleanupActionHandle
 		destructor := func() {
 			if cleanup == nil {
@@ -78,6 +87,13 @@ var _ = utils.SIGDescribe("OIM Volumes", func() {
 	})
 
 	AfterEach(func() {
+		// Collect logs while the pods still exist.
+		if CurrentGinkgoTestDescription().Failed && framework.TestContext.ReportDir != "" {
+			dir := filepath.Join(framework.TestContext.ReportDir, "logs", f.UniqueName)
+			if err := podlogs.DumpAllLogs(ctx, cs, ns.Name, dir); err != nil {
+				framework.Logf("dumping pod logs: %s", err)
+			}
+		}
 		for _, destructor := range destructors {
 			destructor()
 		}
@@ -91,7 +107,7 @@ var _ = utils.SIGDescribe("OIM Volumes", func() {
 				container := &(*containers)[i]
 				for e := range container.Args {
 					// Replace @OIM_REGISTRY_ADDRESS@ in the DaemonSet.
-					container.Args[e] = strings.Replace(container.Args[e], "@OIM_REGISTRY_ADDRESS@", controlPlane.registryAddress, 1)
+					container.Args[e] = strings.Replace(container.Args[e], "@OIM_REGISTRY_ADDRESS@", registryAddress, 1)
 				}
 			}
 		}
@@ -113,7 +129,7 @@ var _ = utils.SIGDescribe("OIM Volumes", func() {
 					patchOIM(object)
 					return nil
 				},
-				os.ExpandEnv("${TEST_WORK}/ca/secret.yaml"),
+				secret,
 				"deploy/kubernetes/malloc/malloc-rbac.yaml",
 				"deploy/kubernetes/malloc/malloc-daemonset.yaml",
 				"deploy/kubernetes/malloc/malloc-storageclass.yaml",
@@ -138,6 +154,46 @@ var _ = utils.SIGDescribe("OIM Volumes", func() {
 			// a missing UnmapVolume call in nodeserver.go must be detected
 			testDynamicProvisioning(t, cs, claim, nil)
 		})
+
+		It("should provision raw block volumes", func() {
+			t := storageClassTest{
+				provisioner:  "oim-malloc-" + f.UniqueName,
+				claimSize:    "1Mi",
+				nodeSelector: map[string]string{"intel.com/oim": "1"},
+			}
+
+			claim := newClaim(t, ns.GetName(), "block")
+			scName := "oim-malloc-sc-" + f.UniqueName
+			claim.Spec.StorageClassName = &scName
+			testBlockProvisioning(t, cs, claim)
+		})
+
+		It("should keep volumes on the node where they were provisioned", func() {
+			t := storageClassTest{
+				provisioner:  "oim-malloc-" + f.UniqueName,
+				claimSize:    "1Mi",
+				nodeSelector: map[string]string{"intel.com/oim": "1"},
+			}
+			testTopology(cs, ns.GetName(), t)
+		})
+
+		It("should fail cleanly when storage is exhausted", func() {
+			t := storageClassTest{
+				provisioner:  "oim-malloc-" + f.UniqueName,
+				claimSize:    "256Mi",
+				nodeSelector: map[string]string{"intel.com/oim": "1"},
+			}
+			testCapacityExhaustion(cs, ns.GetName(), t, "oim-malloc-sc-"+f.UniqueName)
+		})
+
+		It("should fail cleanly when all SCSI targets of a node are in use", func() {
+			t := storageClassTest{
+				provisioner:  "oim-malloc-" + f.UniqueName,
+				claimSize:    "1Mi",
+				nodeSelector: map[string]string{"intel.com/oim": "1"},
+			}
+			testTargetExhaustion(cs, ns.GetName(), t)
+		})
 	})
 
 	Describe("Sanity CSI plugin test using OIM CSI with Ceph", func() {
@@ -148,7 +204,7 @@ var _ = utils.SIGDescribe("OIM Volumes", func() {
 					patchOIM(object)
 					return nil
 				},
-				os.ExpandEnv("${TEST_WORK}/ca/secret.yaml"),
+				secret,
 				"deploy/kubernetes/ceph-csi/rbd-rbac.yaml",
 				"deploy/kubernetes/ceph-csi/rbd-node.yaml",
 				"deploy/kubernetes/ceph-csi/oim-node.yaml",
@@ -185,7 +241,7 @@ var _ = utils.SIGDescribe("OIM Volumes", func() {
 				// hosts to cover both of our scenarios: mounting
 				// through OIM and mounting through ceph-csi.
 				nodeName:  "host-0", // with OIM
-				nodeName2: "host-1", // without
+				nodeName2: "host-2", // without
 			}
 
 			claim := newClaim(t, ns.GetName(), "")
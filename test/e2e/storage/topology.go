@@ -0,0 +1,174 @@
+/*
+Copyright 2018 Intel Corporation.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package storage
+
+import (
+	"sync"
+
+	"k8s.io/api/core/v1"
+	storage "k8s.io/api/storage/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	clientset "k8s.io/client-go/kubernetes"
+	"k8s.io/kubernetes/test/e2e/framework"
+
+	"github.com/intel/oim/pkg/oim-csi-driver"
+
+	// nolint: golint
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+// testTopology provisions one volume on each node that matches
+// t.nodeSelector, all in parallel, and checks that the volumes are
+// only used on the node where they were created. The test gets
+// skipped unless there are at least two such nodes and the
+// external-provisioner passes on the topology of the volumes.
+func testTopology(client clientset.Interface, ns string, t storageClassTest) {
+	nodes, err := client.CoreV1().Nodes().List(metav1.ListOptions{
+		LabelSelector: labels.SelectorFromSet(t.nodeSelector).String(),
+	})
+	Expect(err).NotTo(HaveOccurred())
+	if len(nodes.Items) < 2 {
+		Skip("need at least two nodes with OIM")
+	}
+
+	// All claims get created before waiting for any of them, so
+	// provisioning runs in parallel. Whether the test can run at
+	// all is only known once the volumes exist, which is why
+	// waiting happens here and not in the goroutines below: Skip
+	// must be called by the main goroutine.
+	claims := make([]*v1.PersistentVolumeClaim, len(nodes.Items))
+	for i := range nodes.Items {
+		node := nodes.Items[i].Name
+		class, claim := createNodeLocalClaim(client, ns, t, node)
+		defer func() {
+			framework.ExpectNoError(client.StorageV1().StorageClasses().Delete(class.Name, nil))
+		}()
+		defer func() {
+			framework.ExpectNoError(client.CoreV1().PersistentVolumeClaims(ns).Delete(claim.Name, nil))
+		}()
+		claims[i] = claim
+	}
+	volumes := make([]*v1.PersistentVolume, len(claims))
+	for i, claim := range claims {
+		err = framework.WaitForPersistentVolumeClaimPhase(v1.ClaimBound, client, ns, claim.Name, framework.Poll, framework.ClaimProvisionTimeout)
+		Expect(err).NotTo(HaveOccurred())
+		claim, err = client.CoreV1().PersistentVolumeClaims(ns).Get(claim.Name, metav1.GetOptions{})
+		Expect(err).NotTo(HaveOccurred())
+		claims[i] = claim
+		pv, err := client.CoreV1().PersistentVolumes().Get(claim.Spec.VolumeName, metav1.GetOptions{})
+		Expect(err).NotTo(HaveOccurred())
+		if pv.Spec.NodeAffinity == nil {
+			Skip("topology is not enabled in the external-provisioner")
+		}
+		volumes[i] = pv
+	}
+
+	var wg sync.WaitGroup
+	for i := range nodes.Items {
+		node := nodes.Items[i].Name
+		other := nodes.Items[(i+1)%len(nodes.Items)].Name
+		claim := claims[i]
+		pv := volumes[i]
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer GinkgoRecover()
+			testNodeLocalVolume(client, ns, t, claim, pv, node, other)
+		}()
+	}
+	wg.Wait()
+}
+
+// createNodeLocalClaim creates a StorageClass which restricts volumes
+// to the node and a claim for it.
+func createNodeLocalClaim(client clientset.Interface, ns string, t storageClassTest, node string) (*storage.StorageClass, *v1.PersistentVolumeClaim) {
+	class := createNodeLocalClass(client, ns, t, node)
+
+	By("creating a claim for node " + node)
+	claim := newClaim(t, ns, node)
+	claim.Spec.StorageClassName = &class.Name
+	claim, err := client.CoreV1().PersistentVolumeClaims(ns).Create(claim)
+	Expect(err).NotTo(HaveOccurred())
+	return class, claim
+}
+
+// createNodeLocalClass creates a StorageClass which restricts volumes
+// to the node.
+func createNodeLocalClass(client clientset.Interface, ns string, t storageClassTest, node string) *storage.StorageClass {
+	By("creating a StorageClass for node " + node)
+	class := newStorageClass(t, ns, node)
+	class.AllowedTopologies = []v1.TopologySelectorTerm{{
+		MatchLabelExpressions: []v1.TopologySelectorLabelRequirement{{
+			Key:    oimcsidriver.TopologyKeyNode,
+			Values: []string{node},
+		}},
+	}}
+	class, err := client.StorageV1().StorageClasses().Create(class)
+	Expect(err).NotTo(HaveOccurred())
+	return class
+}
+
+// testNodeLocalVolume checks that a pod using the bound claim lands
+// on the node of the volume and that a pod on the other node cannot
+// be scheduled.
+func testNodeLocalVolume(client clientset.Interface, ns string, t storageClassTest, claim *v1.PersistentVolumeClaim, pv *v1.PersistentVolume, node, other string) {
+	By("checking that a pod runs on node " + node)
+	pod := createTesterPod(client, ns, claim.Name, "local", t.nodeSelector, "echo hello > /mnt/test/data")
+	defer framework.DeletePodOrFail(client, ns, pod.Name)
+	framework.ExpectNoError(framework.WaitForPodSuccessInNamespaceSlow(client, pod.Name, ns))
+	pod, err := client.CoreV1().Pods(ns).Get(pod.Name, metav1.GetOptions{})
+	Expect(err).NotTo(HaveOccurred())
+	Expect(pod.Spec.NodeName).To(Equal(node), "node of pod using volume %s", pv.Name)
+
+	By("checking that a pod cannot use the volume on node " + other)
+	remote := createTesterPod(client, ns, claim.Name, "remote", map[string]string{"kubernetes.io/hostname": other}, "cat /mnt/test/data")
+	defer framework.DeletePodOrFail(client, ns, remote.Name)
+	framework.ExpectNoError(framework.WaitForPodNameUnschedulableInNamespace(client, remote.Name, ns))
+}
+
+// createTesterPod starts a pod which runs the command with the claim
+// mounted at /mnt/test.
+func createTesterPod(c clientset.Interface, ns, claimName, suffix string, nodeSelector map[string]string, command string) *v1.Pod {
+	pod := &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: "pvc-topology-tester-" + suffix + "-",
+		},
+		Spec: v1.PodSpec{
+			NodeSelector: nodeSelector,
+			Containers: []v1.Container{
+				{
+					Name:    "volume-tester",
+					Image:   "busybox",
+					Command: []string{"/bin/sh"},
+					Args:    []string{"-c", command},
+					VolumeMounts: []v1.VolumeMount{
+						{
+							Name:      "my-volume",
+							MountPath: "/mnt/test",
+						},
+					},
+				},
+			},
+			RestartPolicy: v1.RestartPolicyNever,
+			Volumes: []v1.Volume{
+				{
+					Name: "my-volume",
+					VolumeSource: v1.VolumeSource{
+						PersistentVolumeClaim: &v1.PersistentVolumeClaimVolumeSource{
+							ClaimName: claimName,
+						},
+					},
+				},
+			},
+		},
+	}
+	pod, err := c.CoreV1().Pods(ns).Create(pod)
+	framework.ExpectNoError(err, "Failed to create pod: %v", err)
+	return pod
+}
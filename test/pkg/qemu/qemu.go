@@ -8,7 +8,8 @@ SPDX-License-Identifier: Apache-2.0
 // and manages the virtual machine instance(s) for tests. If TEST_QEMU_IMAGE
 // is a symlink to a file of the format <base name>.0.img, then
 // all other images with the same base name will also be started. SPDK
-// is only set up for the first node.
+// is set up for as many nodes as there are SCSI controllers in
+// spdk.VHostPaths.
 package qemu
 
 import (
@@ -86,19 +87,7 @@ func Init(options ...Option) error {
 	}
 	lock = &l
 
-	opts := []string{}
-	if spdk.SPDK != nil {
-		// Run as explained in http://www.spdk.io/doc/vhost.html#vhost_qemu_config,
-		// with a small memory size because we don't know how much huge pages
-		// were set aside.
-		opts = append(opts,
-			"-object", "memory-backend-file,id=mem,size=2048M,mem-path=/dev/hugepages,share=on",
-			"-numa", "node,memdev=mem",
-			"-m", "2048",
-			"-chardev", "socket,id=vhost0,path="+spdk.VHostPath,
-			"-device", "vhost-user-scsi-pci,id=scsi0,chardev=vhost0,bus=pci.0,addr=0x15",
-		)
-	}
+	opts := spdkOpts(0, 2048)
 	log.L().Infof("Starting %s with: %v", qemuImage, opts)
 	vm, err := StartQEMU(qemuImage, opts...)
 	if err != nil {
@@ -124,12 +113,13 @@ func Init(options ...Option) error {
 				}
 				return fmt.Errorf("%s: %s", img, err)
 			}
-			log.L().Infof("Starting additional image %s", img)
-			vm, err := StartQEMU(img)
+			opts := spdkOpts(i, 1024)
+			log.L().Infof("Starting additional image %s with: %v", img, opts)
+			vm, err := StartQEMU(img, opts...)
 			if err != nil {
 				procs, _ := exec.Command("ps", "-ef", "--forest").CombinedOutput() // nolint: gosec
-				return fmt.Errorf("Starting QEMU %s failed: %s\nRunning processes:\n%s",
-					img, err, procs)
+				return fmt.Errorf("Starting QEMU %s with %s failed: %s\nRunning processes:\n%s",
+					img, opts, err, procs)
 			}
 			vms = append(vms, vm)
 		}
@@ -146,10 +136,45 @@ func Init(options ...Option) error {
 		return fmt.Errorf("Starting Kubernetes with %s failed: %s\n%s", kube, err, out)
 	}
 
+	// Only nodes with SPDK can use OIM. The intel.com/oim-node
+	// label is normally set by kubelet for the topology reported by
+	// the OIM CSI driver, which depends on alpha features that are
+	// not enabled in the cluster.
+	for i := range vms {
+		node := fmt.Sprintf("host-%d", i)
+		args := []string{"kubectl", "label", "--overwrite", "nodes", node}
+		if i < len(spdk.VHostPaths) {
+			args = append(args, "intel.com/oim=1", "intel.com/oim-node="+node)
+		} else {
+			args = append(args, "intel.com/oim-", "intel.com/oim-node-")
+		}
+		if out, err := VM.SSH(args...); err != nil {
+			return fmt.Errorf("Labeling node %s failed: %s\n%s", node, err, out)
+		}
+	}
+
 	log.L().Info("VM and Kubernetes ready.")
 	return nil
 }
 
+// spdkOpts returns the QEMU parameters which connect the virtual
+// machine with the given index to its SCSI controller, if there is
+// one. The memory size must be small enough to fit into the huge
+// pages set aside on the host.
+func spdkOpts(index int, memory int) []string {
+	if spdk.SPDK == nil || index >= len(spdk.VHostPaths) {
+		return nil
+	}
+	// Run as explained in http://www.spdk.io/doc/vhost.html#vhost_qemu_config.
+	return []string{
+		"-object", fmt.Sprintf("memory-backend-file,id=mem,size=%dM,mem-path=/dev/hugepages,share=on", memory),
+		"-numa", "node,memdev=mem",
+		"-m", fmt.Sprintf("%d", memory),
+		"-chardev", "socket,id=vhost0,path=" + spdk.VHostPaths[index],
+		"-device", "vhost-user-scsi-pci,id=scsi0,chardev=vhost0,bus=pci.0,addr=0x15",
+	}
+}
+
 // SimpleInit is meant to be used in a parallel Ginkgo test suite where some other node
 // called Init. SimpleInit then sets up VM so that running SSH commands work. Finalize
 // must not be called.
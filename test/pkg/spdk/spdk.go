@@ -35,11 +35,15 @@ var (
 	SPDKPath string
 	// VHostPath is the vhost socket for the SCSI VHost controller of the running SPDK.
 	VHostPath string
+	// VHostPaths contains the vhost sockets of all SCSI VHost
+	// controllers, one per node. The first entry is VHostPath.
+	VHostPaths []string
 
 	// VHost controller name.
 	VHost = "e2e-test-vhost"
 
 	// VHostDev is the BDF string for the SCSI VHost controller
+	// inside each virtual machine.
 	VHostDev = "0000:00:15.0"
 
 	spdkSock = os.Getenv("TEST_SPDK_VHOST_SOCKET")
@@ -48,12 +52,14 @@ var (
 	spdkCmd  *exec.Cmd
 	tmpDir   string
 	spdkOut  io.WriteCloser
+	vhosts   []string
 
 	o opts
 )
 
 type opts struct {
 	controller bool
+	nodes      int
 	socket     string
 }
 
@@ -67,6 +73,23 @@ func WithVHostSCSI() Option {
 	}
 }
 
+// WithNodes sets the number of SCSI controllers created by
+// WithVHostSCSI, one for each of the first nodes. The default is one.
+func WithNodes(nodes int) Option {
+	return func(o *opts) {
+		o.nodes = nodes
+	}
+}
+
+// VHostName returns the name of the SCSI controller for the node
+// with the given index. The first node uses VHost.
+func VHostName(node int) string {
+	if node == 0 {
+		return VHost
+	}
+	return fmt.Sprintf("%s-%d", VHost, node)
+}
+
 // WithSPDKSocket overrides the default env variables and
 // causes Init to connect to an existing daemon, without
 // locking it for exclusive use. This is meant to be used
@@ -79,9 +102,10 @@ func WithSPDKSocket(path string) Option {
 	}
 }
 
-// Init connects to SPDK and creates a VHost SCSI controller.
+// Init connects to SPDK and creates the VHost SCSI controllers.
 // Must be matched by a Finalize call, even after a failure.
 func Init(options ...Option) error {
+	o = opts{nodes: 1}
 	for _, op := range options {
 		op(&o)
 	}
@@ -94,7 +118,7 @@ func Init(options ...Option) error {
 		}
 		SPDK = s
 		SPDKPath = o.socket
-		return nil
+		return findVHosts()
 	}
 
 	// Set up VHost SCSI, if we have SPDK.
@@ -200,24 +224,29 @@ func Init(options ...Option) error {
 	SPDKPath = spdkSock
 
 	if o.controller {
-		args := spdk.ConstructVHostSCSIControllerArgs{
-			Controller: VHost,
-		}
-		err = spdk.ConstructVHostSCSIController(context.Background(), SPDK, args)
-		if err != nil {
-			return err
-		}
-		VHostPath = filepath.Join(filepath.Dir(spdkSock), VHost)
-
-		// If we are not running as root, we need to
-		// change permissions on the new socket.
-		if os.Getuid() != 0 {
-			cmd := exec.Command("sudo", "chmod", "a+rw", VHostPath) // nolint: gosec
-			out, err := cmd.CombinedOutput()
+		for node := 0; node < o.nodes; node++ {
+			args := spdk.ConstructVHostSCSIControllerArgs{
+				Controller: VHostName(node),
+			}
+			err = spdk.ConstructVHostSCSIController(context.Background(), SPDK, args)
 			if err != nil {
-				return fmt.Errorf("'sudo chmod' on vhost socket %s failed: %s\n%s", VHostPath, err, string(out))
+				return err
+			}
+			vhosts = append(vhosts, args.Controller)
+			path := filepath.Join(filepath.Dir(spdkSock), args.Controller)
+			VHostPaths = append(VHostPaths, path)
+
+			// If we are not running as root, we need to
+			// change permissions on the new socket.
+			if os.Getuid() != 0 {
+				cmd := exec.Command("sudo", "chmod", "a+rw", path) // nolint: gosec
+				out, err := cmd.CombinedOutput()
+				if err != nil {
+					return fmt.Errorf("'sudo chmod' on vhost socket %s failed: %s\n%s", path, err, string(out))
+				}
 			}
 		}
+		VHostPath = VHostPaths[0]
 	} else {
 		VHostPath = ""
 	}
@@ -225,23 +254,45 @@ func Init(options ...Option) error {
 	return nil
 }
 
+// findVHosts sets VHostPaths and VHostPath for the SCSI controllers
+// that were created by some other process for the running SPDK.
+func findVHosts() error {
+	controllers, err := spdk.GetVHostControllers(context.Background(), SPDK)
+	if err != nil {
+		return err
+	}
+	exists := map[string]bool{}
+	for _, controller := range controllers {
+		exists[controller.Controller] = true
+	}
+	for node := 0; exists[VHostName(node)]; node++ {
+		VHostPaths = append(VHostPaths, filepath.Join(filepath.Dir(SPDKPath), VHostName(node)))
+	}
+	if len(VHostPaths) > 0 {
+		VHostPath = VHostPaths[0]
+	}
+	return nil
+}
+
 // Finalize frees any resources allocated by Init. Safe to call without
 // Init or after Init failure.
 func Finalize() error {
 	if SPDK != nil {
-		if VHostPath != "" {
+		for _, vhost := range vhosts {
 			args := spdk.RemoveVHostControllerArgs{
-				Controller: VHost,
+				Controller: vhost,
 			}
 			// We try to clean up, but that can fail when someone left a disk attached
 			// to the controller ("Trying to remove non-empty controller").
 			// Just log such errors and proceed, as we'll kill the process anyway.
-			log.L().Infof("Removing VHost SCSI controller %s", VHost)
+			log.L().Infof("Removing VHost SCSI controller %s", args.Controller)
 			if err := spdk.RemoveVHostController(context.Background(), SPDK, args); err != nil {
 				log.L().Errorw("RemoveVHostController failed", "error", err)
 			}
-			VHostPath = ""
 		}
+		vhosts = nil
+		VHostPaths = nil
+		VHostPath = ""
 		if err := SPDK.Close(); err != nil {
 			log.L().Errorw("close SPDK socket", "error", err)
 		}
@@ -49,9 +49,10 @@ do_enable_lvm () {
 
 do_configure_post_master () {
     # Allow normal pods on master node. This is particularly important because
-    # we only attach SPDK to that node, so pods using storage acceleration *have*
-    # to run on that node. To ensure that, we set the "intel.com/oim" label to 1 and
-    # use that as a node selector.
+    # SPDK is always attached to that node, so pods using storage acceleration can
+    # run there. Nodes with SPDK get the "intel.com/oim" label set to 1, which
+    # is used as a node selector. "make start" and the e2e tests update the label
+    # depending on which nodes they connect to SPDK.
     _work/ssh-clear-kvm.0 kubectl taint nodes --all node-role.kubernetes.io/master-
     _work/ssh-clear-kvm.0 kubectl label nodes host-0 intel.com/oim=1
 }